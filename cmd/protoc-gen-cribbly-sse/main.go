@@ -0,0 +1,322 @@
+// protoc-gen-cribbly-sse generates server-sent event endpoints for server-streaming RPCs annotated
+// with the (cribbly.v1.sse) method option.
+//
+// With target=go (the default) it generates, next to the Connect code, a handler interface per
+// service and a constructor per annotated method that returns the method's path and an
+// http.Handler built on internal/sse. With target=ts it generates an EventSource-based client per
+// service for frontend/src/gen, built on frontend/src/lib/sse.ts.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	contextPackage = protogen.GoImportPath("context")
+	httpPackage    = protogen.GoImportPath("net/http")
+	ssePackage     = protogen.GoImportPath("github.com/cszczepaniak/cribbly/internal/sse")
+
+	// sseOption is the method option that marks a method to serve as server-sent events; see
+	// proto/cribbly/v1/sse.proto.
+	sseOption protoreflect.FullName = "cribbly.v1.sse"
+
+	// tsRuntime is where generated TypeScript imports the EventSource helpers from.
+	tsRuntime = "@/lib/sse"
+)
+
+func main() {
+	var flags flag.FlagSet
+	target := flags.String("target", "go", `"go" or "ts"`)
+
+	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+
+		opt, err := newSSEOption(gen)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range gen.Files {
+			if !f.Generate {
+				continue
+			}
+			svcs, err := opt.services(f)
+			if err != nil {
+				return err
+			}
+			if len(svcs) == 0 {
+				continue
+			}
+
+			switch *target {
+			case "go":
+				generateGo(gen, f, svcs)
+			case "ts":
+				generateTS(gen, f, svcs)
+			default:
+				return fmt.Errorf("unknown target %q", *target)
+			}
+		}
+		return nil
+	})
+}
+
+type sseMethod struct {
+	*protogen.Method
+	path string
+}
+
+type sseService struct {
+	*protogen.Service
+	methods []sseMethod
+}
+
+// sseOptionType reads the sse method option. The plugin doesn't link the option's generated Go code,
+// which lives in the output directory that buf cleans before running plugins, so the option is
+// built from its descriptor in the request instead. Without the descriptor, no file in the request
+// can set the option, and none of its methods are found.
+type sseOptionType struct {
+	xt       protoreflect.ExtensionType
+	resolver *protoregistry.Types
+}
+
+func newSSEOption(gen *protogen.Plugin) (sseOptionType, error) {
+	for _, f := range gen.Files {
+		xd := f.Desc.Extensions().ByName(sseOption.Name())
+		if xd == nil || xd.FullName() != sseOption {
+			continue
+		}
+
+		xt := dynamicpb.NewExtensionType(xd)
+		resolver := new(protoregistry.Types)
+		err := resolver.RegisterExtension(xt)
+		if err != nil {
+			return sseOptionType{}, err
+		}
+		return sseOptionType{xt: xt, resolver: resolver}, nil
+	}
+	return sseOptionType{}, nil
+}
+
+// path returns the sse path that m's options set, or "" if they don't.
+func (o sseOptionType) path(m *protogen.Method) (string, error) {
+	if o.xt == nil {
+		return "", nil
+	}
+
+	// The option arrives as an unknown field, since the plugin's registry doesn't know it; parsing
+	// the options again with the option's type resolves it.
+	b, err := proto.Marshal(m.Desc.Options())
+	if err != nil {
+		return "", err
+	}
+	opts := &descriptorpb.MethodOptions{}
+	err = proto.UnmarshalOptions{Resolver: o.resolver}.Unmarshal(b, opts)
+	if err != nil {
+		return "", err
+	}
+
+	xd := o.xt.TypeDescriptor()
+	if !opts.ProtoReflect().Has(xd) {
+		return "", nil
+	}
+	v := opts.ProtoReflect().Get(xd).Message()
+	return v.Get(v.Descriptor().Fields().ByName("path")).String(), nil
+}
+
+func (o sseOptionType) services(f *protogen.File) ([]sseService, error) {
+	var svcs []sseService
+	for _, s := range f.Services {
+		svc := sseService{Service: s}
+		for _, m := range s.Methods {
+			p, err := o.path(m)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", m.Desc.FullName(), err)
+			}
+			if p == "" {
+				continue
+			}
+			svc.methods = append(svc.methods, sseMethod{Method: m, path: p})
+		}
+		if len(svc.methods) > 0 {
+			svcs = append(svcs, svc)
+		}
+	}
+	return svcs, nil
+}
+
+func validate(gen *protogen.Plugin, m sseMethod) bool {
+	if !m.Desc.IsStreamingServer() || m.Desc.IsStreamingClient() {
+		gen.Error(fmt.Errorf("%s: only server-streaming methods can be served as server-sent events", m.Desc.FullName()))
+		return false
+	}
+	if !strings.HasPrefix(m.path, "/") {
+		gen.Error(fmt.Errorf("%s: sse path %q must start with a slash", m.Desc.FullName(), m.path))
+		return false
+	}
+	return true
+}
+
+func generateGo(gen *protogen.Plugin, f *protogen.File, svcs []sseService) {
+	pkg := f.GoPackageName + "sse"
+	filename := path.Join(
+		path.Dir(f.GeneratedFilenamePrefix),
+		string(pkg),
+		path.Base(f.GeneratedFilenamePrefix)+".sse.go",
+	)
+	g := gen.NewGeneratedFile(filename, protogen.GoImportPath(path.Join(string(f.GoImportPath), string(pkg))))
+
+	g.P("// Code generated by protoc-gen-cribbly-sse. DO NOT EDIT.")
+	g.P("//")
+	g.P("// Source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", pkg)
+	g.P()
+
+	g.P("const (")
+	for _, s := range svcs {
+		for _, m := range s.methods {
+			name := s.GoName + m.GoName + "Path"
+			g.P("// ", name, " is the path that ", s.Desc.FullName(), ".", m.Desc.Name(), " is served at.")
+			g.P(name, " = ", fmt.Sprintf("%q", m.path))
+		}
+	}
+	g.P(")")
+	g.P()
+
+	for _, s := range svcs {
+		iface := s.GoName + "SSEHandler"
+		g.P("// ", iface, " serves the server-sent event streams of ", s.Desc.FullName(), ".")
+		g.P("type ", iface, " interface {")
+		for _, m := range s.methods {
+			if !validate(gen, m) {
+				return
+			}
+			g.AnnotateSymbol(iface+"."+m.GoName, protogen.Annotation{Location: m.Location})
+			g.P(m.Comments.Leading, m.GoName, "(",
+				g.QualifiedGoIdent(contextPackage.Ident("Context")), ", ",
+				"*", g.QualifiedGoIdent(m.Input.GoIdent), ", ",
+				"*", g.QualifiedGoIdent(ssePackage.Ident("Stream")), "[*", g.QualifiedGoIdent(m.Output.GoIdent), "]",
+				") error")
+		}
+		g.P("}")
+		g.P()
+
+		for _, m := range s.methods {
+			ctor := "New" + s.GoName + m.GoName + "SSEHandler"
+			g.P("// ", ctor, " returns the path ", s.Desc.FullName(), ".", m.Desc.Name(), " is served at and")
+			g.P("// a handler that serves it as a text/event-stream.")
+			g.P("func ", ctor, "(svc ", iface, ") (string, ", g.QualifiedGoIdent(httpPackage.Ident("Handler")), ") {")
+			g.P("return ", s.GoName+m.GoName+"Path", ", ", g.QualifiedGoIdent(ssePackage.Ident("NewHandler")), "(svc.", m.GoName, ")")
+			g.P("}")
+			g.P()
+		}
+	}
+}
+
+func generateTS(gen *protogen.Plugin, f *protogen.File, svcs []sseService) {
+	base := strings.TrimSuffix(f.Desc.Path(), ".proto")
+	g := gen.NewGeneratedFile(base+"_sse.ts", "")
+	pbModule := "./" + path.Base(base) + "_pb"
+
+	g.P("// @generated by protoc-gen-cribbly-sse with parameter \"target=ts\"")
+	g.P("// @generated from file ", f.Desc.Path(), " (package ", f.Desc.Package(), ", syntax proto3)")
+	g.P("/* eslint-disable */")
+	g.P()
+
+	var schemas, types []string
+	seen := map[string]bool{}
+	for _, s := range svcs {
+		for _, m := range s.methods {
+			if !validate(gen, m) {
+				return
+			}
+			if m.Input.Desc.ParentFile().Path() != f.Desc.Path() || m.Output.Desc.ParentFile().Path() != f.Desc.Path() {
+				gen.Error(fmt.Errorf("%s: sse request and response messages must be defined in %s", m.Desc.FullName(), f.Desc.Path()))
+				return
+			}
+			for _, name := range []string{m.Input.GoIdent.GoName + "Schema", m.Output.GoIdent.GoName + "Schema"} {
+				if !seen[name] {
+					seen[name] = true
+					schemas = append(schemas, name)
+				}
+			}
+			if !seen[m.Output.GoIdent.GoName] {
+				seen[m.Output.GoIdent.GoName] = true
+				types = append(types, m.Output.GoIdent.GoName)
+			}
+		}
+	}
+
+	g.P("import type { MessageInitShape } from \"@bufbuild/protobuf\";")
+	g.P("import { openStream } from \"", tsRuntime, "\";")
+	g.P("import type { StreamHandlers, StreamOptions } from \"", tsRuntime, "\";")
+	g.P("import { ", strings.Join(schemas, ", "), " } from \"", pbModule, "\";")
+	g.P("import type { ", strings.Join(types, ", "), " } from \"", pbModule, "\";")
+	g.P()
+
+	for _, s := range svcs {
+		g.P("/**")
+		g.P(" * Server-sent event streams of ", s.Desc.FullName(), ".")
+		g.P(" */")
+		g.P("export const ", s.GoName, "SSE = {")
+		for i, m := range s.methods {
+			if i > 0 {
+				g.P()
+			}
+			in := m.Input.GoIdent.GoName
+			out := m.Output.GoIdent.GoName
+			g.P("  /**")
+			for _, line := range tsComment(m.Comments.Leading) {
+				g.P("   *", line)
+			}
+			g.P("   * Served at ", m.path, ". Returns a function that closes the stream.")
+			g.P("   *")
+			g.P("   * @generated from rpc ", m.Desc.FullName())
+			g.P("   */")
+			g.P("  ", lowerFirst(m.GoName), "(")
+			g.P("    request: MessageInitShape<typeof ", in, "Schema>,")
+			g.P("    handlers: StreamHandlers<", out, ">,")
+			g.P("    options?: StreamOptions,")
+			g.P("  ): () => void {")
+			g.P("    return openStream(", fmt.Sprintf("%q", m.path), ", ", in, "Schema, request, ", out, "Schema, handlers, options);")
+			g.P("  },")
+		}
+		g.P("};")
+		g.P()
+	}
+}
+
+// tsComment returns the lines of a proto comment formatted for a JSDoc block, followed by a blank
+// line if there were any.
+func tsComment(c protogen.Comments) []string {
+	s := strings.TrimSuffix(string(c), "\n")
+	if s == "" {
+		return nil
+	}
+
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		l = strings.TrimRight(strings.TrimPrefix(l, " "), " \t")
+		if l != "" {
+			l = " " + l
+		}
+		lines = append(lines, l)
+	}
+	return append(lines, "")
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -7,6 +7,7 @@ import {
   DeleteAllPlayersRequestSchema,
   DeletePlayerRequestSchema,
   GenerateRandomPlayersRequestSchema,
+  GetPlayerHistoryRequestSchema,
   ListPlayersRequestSchema,
   PlayerService,
   UpdatePlayerRequestSchema,
@@ -56,3 +57,9 @@ export async function generateRandomPlayers(count: number) {
     create(GenerateRandomPlayersRequestSchema, { count }),
   )
 }
+
+export async function getPlayerHistory(personId: string) {
+  return client.getPlayerHistory(
+    create(GetPlayerHistoryRequestSchema, { personId }),
+  )
+}
@@ -0,0 +1,62 @@
+import { create } from "@bufbuild/protobuf"
+import { createClient } from "@connectrpc/connect"
+import { createConnectTransport } from "@connectrpc/connect-web"
+import { applyDevAdminHeaders } from "@/api/devAdmin"
+import {
+  AdvanceTeamRequestSchema,
+  DeleteBracketRequestSchema,
+  GetBracketRequestSchema,
+  RevertAdvanceRequestSchema,
+  SeedBracketRequestSchema,
+  TournamentService,
+  WatchBracketRequestSchema,
+} from "@/gen/cribbly/v1/tournament_pb"
+
+const transport = createConnectTransport({
+  baseUrl: "/api",
+  fetch: (input, init) => {
+    const headers = new Headers(init?.headers)
+    applyDevAdminHeaders(headers)
+    return fetch(input, { ...init, headers, credentials: "include" })
+  },
+})
+
+const client = createClient(TournamentService, transport)
+
+export async function getBracket() {
+  return client.getBracket(create(GetBracketRequestSchema, {}))
+}
+
+export async function seedBracket(size: number) {
+  return client.seedBracket(create(SeedBracketRequestSchema, { size }))
+}
+
+/** Records `teamId` as the winner of the game at (`round`, `idx`). */
+export async function advanceTeam(round: number, idx: number, teamId: string) {
+  return client.advanceTeam(
+    create(AdvanceTeamRequestSchema, { round, idx, teamId }),
+  )
+}
+
+/** Undoes `advanceTeam` for the game at (`round`, `idx`). */
+export async function revertAdvance(
+  round: number,
+  idx: number,
+  teamId: string,
+) {
+  return client.revertAdvance(
+    create(RevertAdvanceRequestSchema, { round, idx, teamId }),
+  )
+}
+
+export async function deleteBracket() {
+  return client.deleteBracket(create(DeleteBracketRequestSchema, {}))
+}
+
+/**
+ * Yields the current bracket, then the whole bracket again whenever it changes. Abort `signal` to
+ * stop watching.
+ */
+export function watchBracket(signal?: AbortSignal) {
+  return client.watchBracket(create(WatchBracketRequestSchema, {}), { signal })
+}
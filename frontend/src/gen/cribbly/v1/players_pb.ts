@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file cribbly/v1/players.proto.
  */
 export const file_cribbly_v1_players: GenFile = /*@__PURE__*/
-  fileDesc("ChhjcmliYmx5L3YxL3BsYXllcnMucHJvdG8SCmNyaWJibHkudjEiTAoGUGxheWVyEgoKAmlkGAEgASgJEhIKCmZpcnN0X25hbWUYAiABKAkSEQoJbGFzdF9uYW1lGAMgASgJEg8KB3RlYW1faWQYBCABKAkiFAoSTGlzdFBsYXllcnNSZXF1ZXN0IjoKE0xpc3RQbGF5ZXJzUmVzcG9uc2USIwoHcGxheWVycxgBIAMoCzISLmNyaWJibHkudjEuUGxheWVyIjwKE0NyZWF0ZVBsYXllclJlcXVlc3QSEgoKZmlyc3RfbmFtZRgBIAEoCRIRCglsYXN0X25hbWUYAiABKAkiOwoUQ3JlYXRlUGxheWVyUmVzcG9uc2USIwoHcGxheWVycxgBIAMoCzISLmNyaWJibHkudjEuUGxheWVyIkgKE1VwZGF0ZVBsYXllclJlcXVlc3QSCgoCaWQYASABKAkSEgoKZmlyc3RfbmFtZRgCIAEoCRIRCglsYXN0X25hbWUYAyABKAkiOwoUVXBkYXRlUGxheWVyUmVzcG9uc2USIwoHcGxheWVycxgBIAMoCzISLmNyaWJibHkudjEuUGxheWVyIiEKE0RlbGV0ZVBsYXllclJlcXVlc3QSCgoCaWQYASABKAkiOwoURGVsZXRlUGxheWVyUmVzcG9uc2USIwoHcGxheWVycxgBIAMoCzISLmNyaWJibHkudjEuUGxheWVyIhkKF0RlbGV0ZUFsbFBsYXllcnNSZXF1ZXN0IhoKGERlbGV0ZUFsbFBsYXllcnNSZXNwb25zZSItChxHZW5lcmF0ZVJhbmRvbVBsYXllcnNSZXF1ZXN0Eg0KBWNvdW50GAEgASgFIkQKHUdlbmVyYXRlUmFuZG9tUGxheWVyc1Jlc3BvbnNlEiMKB3BsYXllcnMYASADKAsyEi5jcmliYmx5LnYxLlBsYXllcjKxBAoNUGxheWVyU2VydmljZRJQCgtMaXN0UGxheWVycxIeLmNyaWJibHkudjEuTGlzdFBsYXllcnNSZXF1ZXN0Gh8uY3JpYmJseS52MS5MaXN0UGxheWVyc1Jlc3BvbnNlIgASUwoMQ3JlYXRlUGxheWVyEh8uY3JpYmJseS52MS5DcmVhdGVQbGF5ZXJSZXF1ZXN0GiAuY3JpYmJseS52MS5DcmVhdGVQbGF5ZXJSZXNwb25zZSIAElMKDFVwZGF0ZVBsYXllchIfLmNyaWJibHkudjEuVXBkYXRlUGxheWVyUmVxdWVzdBogLmNyaWJibHkudjEuVXBkYXRlUGxheWVyUmVzcG9uc2UiABJTCgxEZWxldGVQbGF5ZXISHy5jcmliYmx5LnYxLkRlbGV0ZVBsYXllclJlcXVlc3QaIC5jcmliYmx5LnYxLkRlbGV0ZVBsYXllclJlc3BvbnNlIgASXwoQRGVsZXRlQWxsUGxheWVycxIjLmNyaWJibHkudjEuRGVsZXRlQWxsUGxheWVyc1JlcXVlc3QaJC5jcmliYmx5LnYxLkRlbGV0ZUFsbFBsYXllcnNSZXNwb25zZSIAEm4KFUdlbmVyYXRlUmFuZG9tUGxheWVycxIoLmNyaWJibHkudjEuR2VuZXJhdGVSYW5kb21QbGF5ZXJzUmVxdWVzdBopLmNyaWJibHkudjEuR2VuZXJhdGVSYW5kb21QbGF5ZXJzUmVzcG9uc2UiAEJDWkFnaXRodWIuY29tL2NzemN6ZXBhbmlhay9jcmliYmx5L2ludGVybmFsL2dlbi9jcmliYmx5L3YxO2NyaWJibHl2MWIGcHJvdG8z");
+  fileDesc("ChhjcmliYmx5L3YxL3BsYXllcnMucHJvdG8SCmNyaWJibHkudjEiXwoGUGxheWVyEgoKAmlkGAEgASgJEhIKCmZpcnN0X25hbWUYAiABKAkSEQoJbGFzdF9uYW1lGAMgASgJEg8KB3RlYW1faWQYBCABKAkSEQoJcGVyc29uX2lkGAUgASgJIhQKEkxpc3RQbGF5ZXJzUmVxdWVzdCI6ChNMaXN0UGxheWVyc1Jlc3BvbnNlEiMKB3BsYXllcnMYASADKAsyEi5jcmliYmx5LnYxLlBsYXllciI8ChNDcmVhdGVQbGF5ZXJSZXF1ZXN0EhIKCmZpcnN0X25hbWUYASABKAkSEQoJbGFzdF9uYW1lGAIgASgJIjsKFENyZWF0ZVBsYXllclJlc3BvbnNlEiMKB3BsYXllcnMYASADKAsyEi5jcmliYmx5LnYxLlBsYXllciJIChNVcGRhdGVQbGF5ZXJSZXF1ZXN0EgoKAmlkGAEgASgJEhIKCmZpcnN0X25hbWUYAiABKAkSEQoJbGFzdF9uYW1lGAMgASgJIjsKFFVwZGF0ZVBsYXllclJlc3BvbnNlEiMKB3BsYXllcnMYASADKAsyEi5jcmliYmx5LnYxLlBsYXllciIhChNEZWxldGVQbGF5ZXJSZXF1ZXN0EgoKAmlkGAEgASgJIjsKFERlbGV0ZVBsYXllclJlc3BvbnNlEiMKB3BsYXllcnMYASADKAsyEi5jcmliYmx5LnYxLlBsYXllciIZChdEZWxldGVBbGxQbGF5ZXJzUmVxdWVzdCIaChhEZWxldGVBbGxQbGF5ZXJzUmVzcG9uc2UiLQocR2VuZXJhdGVSYW5kb21QbGF5ZXJzUmVxdWVzdBINCgVjb3VudBgBIAEoBSJECh1HZW5lcmF0ZVJhbmRvbVBsYXllcnNSZXNwb25zZRIjCgdwbGF5ZXJzGAEgAygLMhIuY3JpYmJseS52MS5QbGF5ZXIiLAoXR2V0UGxheWVySGlzdG9yeVJlcXVlc3QSEQoJcGVyc29uX2lkGAEgASgJIswBChhHZXRQbGF5ZXJIaXN0b3J5UmVzcG9uc2USEQoJcGVyc29uX2lkGAEgASgJEhIKCmZpcnN0X25hbWUYAiABKAkSEQoJbGFzdF9uYW1lGAMgASgJEiIKBnJlY29yZBgEIAEoCzISLmNyaWJibHkudjEuUmVjb3JkEicKBmV2ZW50cxgFIAMoCzIXLmNyaWJibHkudjEuUGxheWVyRXZlbnQSKQoJb3Bwb25lbnRzGAYgAygLMhYuY3JpYmJseS52MS5IZWFkVG9IZWFkIiYKBlJlY29yZBIMCgR3aW5zGAEgASgFEg4KBmxvc3NlcxgCIAEoBSKRAgoLUGxheWVyRXZlbnQSEAoIZXZlbnRfaWQYASABKAkSEgoKZXZlbnRfbmFtZRgCIAEoCRIMCgRkYXRlGAMgASgJEg8KB3RlYW1faWQYBCABKAkSEQoJdGVhbV9uYW1lGAUgASgJEiQKCHBhcnRuZXJzGAYgAygLMhIuY3JpYmJseS52MS5QbGF5ZXISFQoNZGl2aXNpb25fbmFtZRgHIAEoCRIOCgZmaW5pc2gYCCABKAUSEQoJZmluaXNoX29mGAkgASgFEhYKDmJyYWNrZXRfcmVzdWx0GAogASgJEiIKBnJlY29yZBgLIAEoCzISLmNyaWJibHkudjEuUmVjb3JkEg4KBnJhdGluZxgMIAEoBSJqCgpIZWFkVG9IZWFkEhEKCXBlcnNvbl9pZBgBIAEoCRISCgpmaXJzdF9uYW1lGAIgASgJEhEKCWxhc3RfbmFtZRgDIAEoCRIiCgZyZWNvcmQYBCABKAsyEi5jcmliYmx5LnYxLlJlY29yZDKSBQoNUGxheWVyU2VydmljZRJQCgtMaXN0UGxheWVycxIeLmNyaWJibHkudjEuTGlzdFBsYXllcnNSZXF1ZXN0Gh8uY3JpYmJseS52MS5MaXN0UGxheWVyc1Jlc3BvbnNlIgASUwoMQ3JlYXRlUGxheWVyEh8uY3JpYmJseS52MS5DcmVhdGVQbGF5ZXJSZXF1ZXN0GiAuY3JpYmJseS52MS5DcmVhdGVQbGF5ZXJSZXNwb25zZSIAElMKDFVwZGF0ZVBsYXllchIfLmNyaWJibHkudjEuVXBkYXRlUGxheWVyUmVxdWVzdBogLmNyaWJibHkudjEuVXBkYXRlUGxheWVyUmVzcG9uc2UiABJTCgxEZWxldGVQbGF5ZXISHy5jcmliYmx5LnYxLkRlbGV0ZVBsYXllclJlcXVlc3QaIC5jcmliYmx5LnYxLkRlbGV0ZVBsYXllclJlc3BvbnNlIgASXwoQRGVsZXRlQWxsUGxheWVycxIjLmNyaWJibHkudjEuRGVsZXRlQWxsUGxheWVyc1JlcXVlc3QaJC5jcmliYmx5LnYxLkRlbGV0ZUFsbFBsYXllcnNSZXNwb25zZSIAEm4KFUdlbmVyYXRlUmFuZG9tUGxheWVycxIoLmNyaWJibHkudjEuR2VuZXJhdGVSYW5kb21QbGF5ZXJzUmVxdWVzdBopLmNyaWJibHkudjEuR2VuZXJhdGVSYW5kb21QbGF5ZXJzUmVzcG9uc2UiABJfChBHZXRQbGF5ZXJIaXN0b3J5EiMuY3JpYmJseS52MS5HZXRQbGF5ZXJIaXN0b3J5UmVxdWVzdBokLmNyaWJibHkudjEuR2V0UGxheWVySGlzdG9yeVJlc3BvbnNlIgBCQ1pBZ2l0aHViLmNvbS9jc3pjemVwYW5pYWsvY3JpYmJseS9pbnRlcm5hbC9nZW4vY3JpYmJseS92MTtjcmliYmx5djFiBnByb3RvMw==");
 
 /**
  * @generated from message cribbly.v1.Player
@@ -37,6 +37,13 @@ export type Player = Message<"cribbly.v1.Player"> & {
    * @generated from field: string team_id = 4;
    */
   teamId: string;
+
+  /**
+   * The person this player is. Unlike id, it's the same in every event they play in.
+   *
+   * @generated from field: string person_id = 5;
+   */
+  personId: string;
 };
 
 /**
@@ -254,7 +261,219 @@ export const GenerateRandomPlayersResponseSchema: GenMessage<GenerateRandomPlaye
   messageDesc(file_cribbly_v1_players, 12);
 
 /**
- * Admin API for managing registered players (same data as legacy /admin/players).
+ * @generated from message cribbly.v1.GetPlayerHistoryRequest
+ */
+export type GetPlayerHistoryRequest = Message<"cribbly.v1.GetPlayerHistoryRequest"> & {
+  /**
+   * @generated from field: string person_id = 1;
+   */
+  personId: string;
+};
+
+/**
+ * Describes the message cribbly.v1.GetPlayerHistoryRequest.
+ * Use `create(GetPlayerHistoryRequestSchema)` to create a new message.
+ */
+export const GetPlayerHistoryRequestSchema: GenMessage<GetPlayerHistoryRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_players, 13);
+
+/**
+ * @generated from message cribbly.v1.GetPlayerHistoryResponse
+ */
+export type GetPlayerHistoryResponse = Message<"cribbly.v1.GetPlayerHistoryResponse"> & {
+  /**
+   * @generated from field: string person_id = 1;
+   */
+  personId: string;
+
+  /**
+   * @generated from field: string first_name = 2;
+   */
+  firstName: string;
+
+  /**
+   * @generated from field: string last_name = 3;
+   */
+  lastName: string;
+
+  /**
+   * Across every event.
+   *
+   * @generated from field: cribbly.v1.Record record = 4;
+   */
+  record?: Record | undefined;
+
+  /**
+   * Most recent first.
+   *
+   * @generated from field: repeated cribbly.v1.PlayerEvent events = 5;
+   */
+  events: PlayerEvent[];
+
+  /**
+   * The people the player has played most, most games first.
+   *
+   * @generated from field: repeated cribbly.v1.HeadToHead opponents = 6;
+   */
+  opponents: HeadToHead[];
+};
+
+/**
+ * Describes the message cribbly.v1.GetPlayerHistoryResponse.
+ * Use `create(GetPlayerHistoryResponseSchema)` to create a new message.
+ */
+export const GetPlayerHistoryResponseSchema: GenMessage<GetPlayerHistoryResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_players, 14);
+
+/**
+ * @generated from message cribbly.v1.Record
+ */
+export type Record = Message<"cribbly.v1.Record"> & {
+  /**
+   * @generated from field: int32 wins = 1;
+   */
+  wins: number;
+
+  /**
+   * @generated from field: int32 losses = 2;
+   */
+  losses: number;
+};
+
+/**
+ * Describes the message cribbly.v1.Record.
+ * Use `create(RecordSchema)` to create a new message.
+ */
+export const RecordSchema: GenMessage<Record> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_players, 15);
+
+/**
+ * How a player did at one event.
+ *
+ * @generated from message cribbly.v1.PlayerEvent
+ */
+export type PlayerEvent = Message<"cribbly.v1.PlayerEvent"> & {
+  /**
+   * @generated from field: string event_id = 1;
+   */
+  eventId: string;
+
+  /**
+   * @generated from field: string event_name = 2;
+   */
+  eventName: string;
+
+  /**
+   * YYYY-MM-DD.
+   *
+   * @generated from field: string date = 3;
+   */
+  date: string;
+
+  /**
+   * Empty when the player was not on a team.
+   *
+   * @generated from field: string team_id = 4;
+   */
+  teamId: string;
+
+  /**
+   * @generated from field: string team_name = 5;
+   */
+  teamName: string;
+
+  /**
+   * @generated from field: repeated cribbly.v1.Player partners = 6;
+   */
+  partners: Player[];
+
+  /**
+   * Empty when the team was not in a division.
+   *
+   * @generated from field: string division_name = 7;
+   */
+  divisionName: string;
+
+  /**
+   * The team's place in its division's prelim standings (or the whole event's, without divisions),
+   * starting at 1, out of finish_of teams. 0 until the team has played.
+   *
+   * @generated from field: int32 finish = 8;
+   */
+  finish: number;
+
+  /**
+   * @generated from field: int32 finish_of = 9;
+   */
+  finishOf: number;
+
+  /**
+   * How far the team got in the brackets, like "Champion" or "Quarterfinals". Empty when the team
+   * was not in a bracket.
+   *
+   * @generated from field: string bracket_result = 10;
+   */
+  bracketResult: string;
+
+  /**
+   * @generated from field: cribbly.v1.Record record = 11;
+   */
+  record?: Record | undefined;
+
+  /**
+   * The player's rating after the event; 0 if ratings haven't been computed.
+   *
+   * @generated from field: int32 rating = 12;
+   */
+  rating: number;
+};
+
+/**
+ * Describes the message cribbly.v1.PlayerEvent.
+ * Use `create(PlayerEventSchema)` to create a new message.
+ */
+export const PlayerEventSchema: GenMessage<PlayerEvent> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_players, 16);
+
+/**
+ * How a player has done against one opponent, across every event.
+ *
+ * @generated from message cribbly.v1.HeadToHead
+ */
+export type HeadToHead = Message<"cribbly.v1.HeadToHead"> & {
+  /**
+   * @generated from field: string person_id = 1;
+   */
+  personId: string;
+
+  /**
+   * @generated from field: string first_name = 2;
+   */
+  firstName: string;
+
+  /**
+   * @generated from field: string last_name = 3;
+   */
+  lastName: string;
+
+  /**
+   * Games the player won and lost against this opponent.
+   *
+   * @generated from field: cribbly.v1.Record record = 4;
+   */
+  record?: Record | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.HeadToHead.
+ * Use `create(HeadToHeadSchema)` to create a new message.
+ */
+export const HeadToHeadSchema: GenMessage<HeadToHead> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_players, 17);
+
+/**
+ * API for registered players (same data as legacy /admin/players). Everything but GetPlayerHistory
+ * needs an admin.
  *
  * @generated from service cribbly.v1.PlayerService
  */
@@ -307,6 +526,16 @@ export const PlayerService: GenService<{
     input: typeof GenerateRandomPlayersRequestSchema;
     output: typeof GenerateRandomPlayersResponseSchema;
   },
+  /**
+   * A person's teams, finishes and records in every event they've played in.
+   *
+   * @generated from rpc cribbly.v1.PlayerService.GetPlayerHistory
+   */
+  getPlayerHistory: {
+    methodKind: "unary";
+    input: typeof GetPlayerHistoryRequestSchema;
+    output: typeof GetPlayerHistoryResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_cribbly_v1_players, 0);
 
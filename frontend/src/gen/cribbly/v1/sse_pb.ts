@@ -0,0 +1,46 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file cribbly/v1/sse.proto (package cribbly.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenExtension, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { extDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_google_protobuf_descriptor } from "@bufbuild/protobuf/wkt";
+import type { MethodOptions } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file cribbly/v1/sse.proto.
+ */
+export const file_cribbly_v1_sse: GenFile = /*@__PURE__*/
+  fileDesc("ChRjcmliYmx5L3YxL3NzZS5wcm90bxIKY3JpYmJseS52MRogZ29vZ2xlL3Byb3RvYnVmL2Rlc2NyaXB0b3IucHJvdG8iGgoKU1NFT3B0aW9ucxIMCgRwYXRoGAEgASgJOkoKA3NzZRIeLmdvb2dsZS5wcm90b2J1Zi5NZXRob2RPcHRpb25zGOCJAyABKAsyFi5jcmliYmx5LnYxLlNTRU9wdGlvbnNSA3NzZUJDWkFnaXRodWIuY29tL2NzemN6ZXBhbmlhay9jcmliYmx5L2ludGVybmFsL2dlbi9jcmliYmx5L3YxO2NyaWJibHl2MWIGcHJvdG8z", [file_google_protobuf_descriptor]);
+
+/**
+ * SSEOptions exposes a server-streaming RPC as a plain text/event-stream endpoint, which browsers
+ * can consume with EventSource. protoc-gen-cribbly-sse generates a Go handler and a TypeScript
+ * client for every method that sets it.
+ *
+ * @generated from message cribbly.v1.SSEOptions
+ */
+export type SSEOptions = Message<"cribbly.v1.SSEOptions"> & {
+  /**
+   * The path the stream is served at, e.g. "/standings/stream". The request message is read from
+   * the "message" query parameter as JSON; each response message is sent as one event.
+   *
+   * @generated from field: string path = 1;
+   */
+  path: string;
+};
+
+/**
+ * Describes the message cribbly.v1.SSEOptions.
+ * Use `create(SSEOptionsSchema)` to create a new message.
+ */
+export const SSEOptionsSchema: GenMessage<SSEOptions> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_sse, 0);
+
+/**
+ * @generated from extension: cribbly.v1.SSEOptions sse = 50400;
+ */
+export const sse: GenExtension<MethodOptions, SSEOptions> = /*@__PURE__*/
+  extDesc(file_cribbly_v1_sse, 0);
+
@@ -0,0 +1,211 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file cribbly/v1/standings.proto (package cribbly.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_cribbly_v1_sse } from "./sse_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file cribbly/v1/standings.proto.
+ */
+export const file_cribbly_v1_standings: GenFile = /*@__PURE__*/
+  fileDesc("ChpjcmliYmx5L3YxL3N0YW5kaW5ncy5wcm90bxIKY3JpYmJseS52MRoUY3JpYmJseS92MS9zc2UucHJvdG8i0AIKCFN0YW5kaW5nEg8KB3RlYW1faWQYASABKAkSEQoJdGVhbV9uYW1lGAIgASgJEgwKBHdpbnMYAyABKAUSDgoGbG9zc2VzGAQgASgFEhAKCHdpbl9yYXRlGAUgASgBEhcKD3BvaW50c19wZXJfZ2FtZRgGIAEoARITCgtnYW1lX3BvaW50cxgHIAEoBRIUCgxza3Vua3NfZ2l2ZW4YCCABKAUSGwoTZG91YmxlX3NrdW5rc19naXZlbhgJIAEoBRIUCgxza3Vua3NfdGFrZW4YCiABKAUSGwoTZG91YmxlX3NrdW5rc190YWtlbhgLIAEoBRIWCg5wb2ludHNfYWxsb3dlZBgMIAEoBRIQCgh0aWVicmVhaxgNIAEoCRIXCg9jb2luX2ZsaXBwZWRfYXQYDiABKAkSGQoRY29pbl9mbGlwX3BlbmRpbmcYDyABKAgiFQoTR2V0U3RhbmRpbmdzUmVxdWVzdCI/ChRHZXRTdGFuZGluZ3NSZXNwb25zZRInCglzdGFuZGluZ3MYASADKAsyFC5jcmliYmx5LnYxLlN0YW5kaW5nIhcKFVdhdGNoU3RhbmRpbmdzUmVxdWVzdCJBChZXYXRjaFN0YW5kaW5nc1Jlc3BvbnNlEicKCXN0YW5kaW5ncxgBIAMoCzIULmNyaWJibHkudjEuU3RhbmRpbmcy2wEKEFN0YW5kaW5nc1NlcnZpY2USUwoMR2V0U3RhbmRpbmdzEh8uY3JpYmJseS52MS5HZXRTdGFuZGluZ3NSZXF1ZXN0GiAuY3JpYmJseS52MS5HZXRTdGFuZGluZ3NSZXNwb25zZSIAEnIKDldhdGNoU3RhbmRpbmdzEiEuY3JpYmJseS52MS5XYXRjaFN0YW5kaW5nc1JlcXVlc3QaIi5jcmliYmx5LnYxLldhdGNoU3RhbmRpbmdzUmVzcG9uc2UiF4LOGBMKES9zdGFuZGluZ3Mvc3RyZWFtMAFCQ1pBZ2l0aHViLmNvbS9jc3pjemVwYW5pYWsvY3JpYmJseS9pbnRlcm5hbC9nZW4vY3JpYmJseS92MTtjcmliYmx5djFiBnByb3RvMw", [file_cribbly_v1_sse]);
+
+/**
+ * @generated from message cribbly.v1.Standing
+ */
+export type Standing = Message<"cribbly.v1.Standing"> & {
+  /**
+   * @generated from field: string team_id = 1;
+   */
+  teamId: string;
+
+  /**
+   * @generated from field: string team_name = 2;
+   */
+  teamName: string;
+
+  /**
+   * @generated from field: int32 wins = 3;
+   */
+  wins: number;
+
+  /**
+   * @generated from field: int32 losses = 4;
+   */
+  losses: number;
+
+  /**
+   * Wins per game played, from 0 to 1. Zero when the team hasn't played.
+   *
+   * @generated from field: double win_rate = 5;
+   */
+  winRate: number;
+
+  /**
+   * Zero when the team hasn't played.
+   *
+   * @generated from field: double points_per_game = 6;
+   */
+  pointsPerGame: number;
+
+  /**
+   * Total under the event's game point scheme; standings are ranked by it.
+   *
+   * @generated from field: int32 game_points = 7;
+   */
+  gamePoints: number;
+
+  /**
+   * Wins where the loser finished under 91, including double skunks.
+   *
+   * @generated from field: int32 skunks_given = 8;
+   */
+  skunksGiven: number;
+
+  /**
+   * Wins where the loser finished under 61.
+   *
+   * @generated from field: int32 double_skunks_given = 9;
+   */
+  doubleSkunksGiven: number;
+
+  /**
+   * @generated from field: int32 skunks_taken = 10;
+   */
+  skunksTaken: number;
+
+  /**
+   * @generated from field: int32 double_skunks_taken = 11;
+   */
+  doubleSkunksTaken: number;
+
+  /**
+   * @generated from field: int32 points_allowed = 12;
+   */
+  pointsAllowed: number;
+
+  /**
+   * The tiebreaker that put this team ahead of the next one (e.g. "head-to-head" or "coin-flip").
+   * Empty unless the two were level on games played and game points.
+   *
+   * @generated from field: string tiebreak = 13;
+   */
+  tiebreak: string;
+
+  /**
+   * When the coin flip was recorded, as RFC 3339, if tiebreak is "coin-flip".
+   *
+   * @generated from field: string coin_flipped_at = 14;
+   */
+  coinFlippedAt: string;
+
+  /**
+   * True if this team is level with the next one on everything but a coin flip that hasn't been
+   * made yet. Coins are flipped when the standings are final.
+   *
+   * @generated from field: bool coin_flip_pending = 15;
+   */
+  coinFlipPending: boolean;
+};
+
+/**
+ * Describes the message cribbly.v1.Standing.
+ * Use `create(StandingSchema)` to create a new message.
+ */
+export const StandingSchema: GenMessage<Standing> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_standings, 0);
+
+/**
+ * @generated from message cribbly.v1.GetStandingsRequest
+ */
+export type GetStandingsRequest = Message<"cribbly.v1.GetStandingsRequest"> & {
+};
+
+/**
+ * Describes the message cribbly.v1.GetStandingsRequest.
+ * Use `create(GetStandingsRequestSchema)` to create a new message.
+ */
+export const GetStandingsRequestSchema: GenMessage<GetStandingsRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_standings, 1);
+
+/**
+ * @generated from message cribbly.v1.GetStandingsResponse
+ */
+export type GetStandingsResponse = Message<"cribbly.v1.GetStandingsResponse"> & {
+  /**
+   * Ordered from first place to last.
+   *
+   * @generated from field: repeated cribbly.v1.Standing standings = 1;
+   */
+  standings: Standing[];
+};
+
+/**
+ * Describes the message cribbly.v1.GetStandingsResponse.
+ * Use `create(GetStandingsResponseSchema)` to create a new message.
+ */
+export const GetStandingsResponseSchema: GenMessage<GetStandingsResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_standings, 2);
+
+/**
+ * @generated from message cribbly.v1.WatchStandingsRequest
+ */
+export type WatchStandingsRequest = Message<"cribbly.v1.WatchStandingsRequest"> & {
+};
+
+/**
+ * Describes the message cribbly.v1.WatchStandingsRequest.
+ * Use `create(WatchStandingsRequestSchema)` to create a new message.
+ */
+export const WatchStandingsRequestSchema: GenMessage<WatchStandingsRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_standings, 3);
+
+/**
+ * @generated from message cribbly.v1.WatchStandingsResponse
+ */
+export type WatchStandingsResponse = Message<"cribbly.v1.WatchStandingsResponse"> & {
+  /**
+   * Ordered from first place to last.
+   *
+   * @generated from field: repeated cribbly.v1.Standing standings = 1;
+   */
+  standings: Standing[];
+};
+
+/**
+ * Describes the message cribbly.v1.WatchStandingsResponse.
+ * Use `create(WatchStandingsResponseSchema)` to create a new message.
+ */
+export const WatchStandingsResponseSchema: GenMessage<WatchStandingsResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_standings, 4);
+
+/**
+ * Public API for the prelim standings (same data as legacy /standings).
+ *
+ * @generated from service cribbly.v1.StandingsService
+ */
+export const StandingsService: GenService<{
+  /**
+   * @generated from rpc cribbly.v1.StandingsService.GetStandings
+   */
+  getStandings: {
+    methodKind: "unary";
+    input: typeof GetStandingsRequestSchema;
+    output: typeof GetStandingsResponseSchema;
+  },
+  /**
+   * Sends the current standings, then the full standings again every time a score changes.
+   *
+   * @generated from rpc cribbly.v1.StandingsService.WatchStandings
+   */
+  watchStandings: {
+    methodKind: "server_streaming";
+    input: typeof WatchStandingsRequestSchema;
+    output: typeof WatchStandingsResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_cribbly_v1_standings, 0);
+
@@ -0,0 +1,30 @@
+// @generated by protoc-gen-cribbly-sse with parameter "target=ts"
+// @generated from file cribbly/v1/standings.proto (package cribbly.v1, syntax proto3)
+/* eslint-disable */
+
+import type { MessageInitShape } from "@bufbuild/protobuf";
+import { openStream } from "@/lib/sse";
+import type { StreamHandlers, StreamOptions } from "@/lib/sse";
+import { WatchStandingsRequestSchema, WatchStandingsResponseSchema } from "./standings_pb";
+import type { WatchStandingsResponse } from "./standings_pb";
+
+/**
+ * Server-sent event streams of cribbly.v1.StandingsService.
+ */
+export const StandingsServiceSSE = {
+  /**
+   * Sends the current standings, then the full standings again every time a score changes.
+   *
+   * Served at /standings/stream. Returns a function that closes the stream.
+   *
+   * @generated from rpc cribbly.v1.StandingsService.WatchStandings
+   */
+  watchStandings(
+    request: MessageInitShape<typeof WatchStandingsRequestSchema>,
+    handlers: StreamHandlers<WatchStandingsResponse>,
+    options?: StreamOptions,
+  ): () => void {
+    return openStream("/standings/stream", WatchStandingsRequestSchema, request, WatchStandingsResponseSchema, handlers, options);
+  },
+};
+
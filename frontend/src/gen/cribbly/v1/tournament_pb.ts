@@ -0,0 +1,802 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file cribbly/v1/tournament.proto (package cribbly.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_cribbly_v1_sse } from "./sse_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file cribbly/v1/tournament.proto.
+ */
+export const file_cribbly_v1_tournament: GenFile = /*@__PURE__*/
+  fileDesc("ChtjcmliYmx5L3YxL3RvdXJuYW1lbnQucHJvdG8SCmNyaWJibHkudjEaFGNyaWJibHkvdjEvc3NlLnByb3RvIicKC0JyYWNrZXRUZWFtEgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkiuwIKC0JyYWNrZXRHYW1lEg0KBXJvdW5kGAEgASgFEgsKA2lkeBgCIAEoBRImCgV0ZWFtMRgDIAEoCzIXLmNyaWJibHkudjEuQnJhY2tldFRlYW0SJgoFdGVhbTIYBCABKAsyFy5jcmliYmx5LnYxLkJyYWNrZXRUZWFtEicKBndpbm5lchgFIAEoCzIXLmNyaWJibHkudjEuQnJhY2tldFRlYW0SCwoDYnllGAYgASgIEiUKBHNpZGUYByABKA4yFy5jcmliYmx5LnYxLkJyYWNrZXRTaWRlEg8KB2Jlc3Rfb2YYCCABKAUSJgoGc2VyaWVzGAkgAygLMhYuY3JpYmJseS52MS5TZXJpZXNHYW1lEioKB3JlcG9ydHMYCiADKAsyGS5jcmliYmx5LnYxLkJyYWNrZXRSZXBvcnQiWAoNQnJhY2tldFJlcG9ydBIlCgR0ZWFtGAEgASgLMhcuY3JpYmJseS52MS5CcmFja2V0VGVhbRILCgN3b24YAiABKAgSEwoLbG9zZXJfc2NvcmUYAyABKAUiNgoKU2VyaWVzR2FtZRITCgt0ZWFtMV9zY29yZRgBIAEoBRITCgt0ZWFtMl9zY29yZRgCIAEoBSI2CgxCcmFja2V0Um91bmQSJgoFZ2FtZXMYASADKAsyFy5jcmliYmx5LnYxLkJyYWNrZXRHYW1lIqwCCgdCcmFja2V0EigKBnJvdW5kcxgBIAMoCzIYLmNyaWJibHkudjEuQnJhY2tldFJvdW5kEhIKCnRlYW1fY291bnQYAiABKAUSKQoIY2hhbXBpb24YAyABKAsyFy5jcmliYmx5LnYxLkJyYWNrZXRUZWFtEikKBmZvcm1hdBgEIAEoDjIZLmNyaWJibHkudjEuQnJhY2tldEZvcm1hdBIvCg1sb3NlcnNfcm91bmRzGAUgAygLMhguY3JpYmJseS52MS5CcmFja2V0Um91bmQSLgoMZmluYWxfcm91bmRzGAYgAygLMhguY3JpYmJseS52MS5CcmFja2V0Um91bmQSLAoLdGhpcmRfcGxhY2UYByABKAsyFy5jcmliYmx5LnYxLkJyYWNrZXRHYW1lIhMKEUdldEJyYWNrZXRSZXF1ZXN0IjoKEkdldEJyYWNrZXRSZXNwb25zZRIkCgdicmFja2V0GAEgASgLMhMuY3JpYmJseS52MS5CcmFja2V0ImIKElNlZWRCcmFja2V0UmVxdWVzdBIMCgRzaXplGAEgASgFEikKBmZvcm1hdBgCIAEoDjIZLmNyaWJibHkudjEuQnJhY2tldEZvcm1hdBITCgt0aGlyZF9wbGFjZRgDIAEoCCI7ChNTZWVkQnJhY2tldFJlc3BvbnNlEiQKB2JyYWNrZXQYASABKAsyEy5jcmliYmx5LnYxLkJyYWNrZXQiaAoSQWR2YW5jZVRlYW1SZXF1ZXN0Eg0KBXJvdW5kGAEgASgFEgsKA2lkeBgCIAEoBRIPCgd0ZWFtX2lkGAMgASgJEiUKBHNpZGUYBCABKA4yFy5jcmliYmx5LnYxLkJyYWNrZXRTaWRlIjsKE0FkdmFuY2VUZWFtUmVzcG9uc2USJAoHYnJhY2tldBgBIAEoCzITLmNyaWJibHkudjEuQnJhY2tldCJqChRSZXZlcnRBZHZhbmNlUmVxdWVzdBINCgVyb3VuZBgBIAEoBRILCgNpZHgYAiABKAUSDwoHdGVhbV9pZBgDIAEoCRIlCgRzaWRlGAQgASgOMhcuY3JpYmJseS52MS5CcmFja2V0U2lkZSI9ChVSZXZlcnRBZHZhbmNlUmVzcG9uc2USJAoHYnJhY2tldBgBIAEoCzITLmNyaWJibHkudjEuQnJhY2tldCIWChREZWxldGVCcmFja2V0UmVxdWVzdCIXChVEZWxldGVCcmFja2V0UmVzcG9uc2UiFQoTV2F0Y2hCcmFja2V0UmVxdWVzdCI8ChRXYXRjaEJyYWNrZXRSZXNwb25zZRIkCgdicmFja2V0GAEgASgLMhMuY3JpYmJseS52MS5CcmFja2V0ImwKFlNldFNlcmllc0xlbmd0aFJlcXVlc3QSDQoFcm91bmQYASABKAUSCwoDaWR4GAIgASgFEiUKBHNpZGUYAyABKA4yFy5jcmliYmx5LnYxLkJyYWNrZXRTaWRlEg8KB2Jlc3Rfb2YYBCABKAUiPwoXU2V0U2VyaWVzTGVuZ3RoUmVzcG9uc2USJAoHYnJhY2tldBgBIAEoCzITLmNyaWJibHkudjEuQnJhY2tldCKJAQoXUmVjb3JkU2VyaWVzR2FtZVJlcXVlc3QSDQoFcm91bmQYASABKAUSCwoDaWR4GAIgASgFEiUKBHNpZGUYAyABKA4yFy5jcmliYmx5LnYxLkJyYWNrZXRTaWRlEhYKDndpbm5lcl90ZWFtX2lkGAQgASgJEhMKC2xvc2VyX3Njb3JlGAUgASgFIkAKGFJlY29yZFNlcmllc0dhbWVSZXNwb25zZRIkCgdicmFja2V0GAEgASgLMhMuY3JpYmJseS52MS5CcmFja2V0IloKFVVuZG9TZXJpZXNHYW1lUmVxdWVzdBINCgVyb3VuZBgBIAEoBRILCgNpZHgYAiABKAUSJQoEc2lkZRgDIAEoDjIXLmNyaWJibHkudjEuQnJhY2tldFNpZGUiPgoWVW5kb1Nlcmllc0dhbWVSZXNwb25zZRIkCgdicmFja2V0GAEgASgLMhMuY3JpYmJseS52MS5CcmFja2V0Kn0KDUJyYWNrZXRGb3JtYXQSHgoaQlJBQ0tFVF9GT1JNQVRfVU5TUEVDSUZJRUQQABIlCiFCUkFDS0VUX0ZPUk1BVF9TSU5HTEVfRUxJTUlOQVRJT04QARIlCiFCUkFDS0VUX0ZPUk1BVF9ET1VCTEVfRUxJTUlOQVRJT04QAiqUAQoLQnJhY2tldFNpZGUSHAoYQlJBQ0tFVF9TSURFX1VOU1BFQ0lGSUVEEAASGAoUQlJBQ0tFVF9TSURFX1dJTk5FUlMQARIXChNCUkFDS0VUX1NJREVfTE9TRVJTEAISFgoSQlJBQ0tFVF9TSURFX0ZJTkFMEAMSHAoYQlJBQ0tFVF9TSURFX1RISVJEX1BMQUNFEAQyvwYKEVRvdXJuYW1lbnRTZXJ2aWNlEk0KCkdldEJyYWNrZXQSHS5jcmliYmx5LnYxLkdldEJyYWNrZXRSZXF1ZXN0Gh4uY3JpYmJseS52MS5HZXRCcmFja2V0UmVzcG9uc2UiABJQCgtTZWVkQnJhY2tldBIeLmNyaWJibHkudjEuU2VlZEJyYWNrZXRSZXF1ZXN0Gh8uY3JpYmJseS52MS5TZWVkQnJhY2tldFJlc3BvbnNlIgASUAoLQWR2YW5jZVRlYW0SHi5jcmliYmx5LnYxLkFkdmFuY2VUZWFtUmVxdWVzdBofLmNyaWJibHkudjEuQWR2YW5jZVRlYW1SZXNwb25zZSIAElYKDVJldmVydEFkdmFuY2USIC5jcmliYmx5LnYxLlJldmVydEFkdmFuY2VSZXF1ZXN0GiEuY3JpYmJseS52MS5SZXZlcnRBZHZhbmNlUmVzcG9uc2UiABJWCg1EZWxldGVCcmFja2V0EiAuY3JpYmJseS52MS5EZWxldGVCcmFja2V0UmVxdWVzdBohLmNyaWJibHkudjEuRGVsZXRlQnJhY2tldFJlc3BvbnNlIgASXAoPU2V0U2VyaWVzTGVuZ3RoEiIuY3JpYmJseS52MS5TZXRTZXJpZXNMZW5ndGhSZXF1ZXN0GiMuY3JpYmJseS52MS5TZXRTZXJpZXNMZW5ndGhSZXNwb25zZSIAEl8KEFJlY29yZFNlcmllc0dhbWUSIy5jcmliYmx5LnYxLlJlY29yZFNlcmllc0dhbWVSZXF1ZXN0GiQuY3JpYmJseS52MS5SZWNvcmRTZXJpZXNHYW1lUmVzcG9uc2UiABJZCg5VbmRvU2VyaWVzR2FtZRIhLmNyaWJibHkudjEuVW5kb1Nlcmllc0dhbWVSZXF1ZXN0GiIuY3JpYmJseS52MS5VbmRvU2VyaWVzR2FtZVJlc3BvbnNlIgASbQoMV2F0Y2hCcmFja2V0Eh8uY3JpYmJseS52MS5XYXRjaEJyYWNrZXRSZXF1ZXN0GiAuY3JpYmJseS52MS5XYXRjaEJyYWNrZXRSZXNwb25zZSIYgs4YFAoSL3RvdXJuYW1lbnQvc3RyZWFtMAFCQ1pBZ2l0aHViLmNvbS9jc3pjemVwYW5pYWsvY3JpYmJseS9pbnRlcm5hbC9nZW4vY3JpYmJseS92MTtjcmliYmx5djFiBnByb3RvMw", [file_cribbly_v1_sse]);
+
+/**
+ * @generated from message cribbly.v1.BracketTeam
+ */
+export type BracketTeam = Message<"cribbly.v1.BracketTeam"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message cribbly.v1.BracketTeam.
+ * Use `create(BracketTeamSchema)` to create a new message.
+ */
+export const BracketTeamSchema: GenMessage<BracketTeam> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 0);
+
+/**
+ * @generated from message cribbly.v1.BracketGame
+ */
+export type BracketGame = Message<"cribbly.v1.BracketGame"> & {
+  /**
+   * @generated from field: int32 round = 1;
+   */
+  round: number;
+
+  /**
+   * @generated from field: int32 idx = 2;
+   */
+  idx: number;
+
+  /**
+   * Unset until the team for that slot has been decided.
+   *
+   * @generated from field: cribbly.v1.BracketTeam team1 = 3;
+   */
+  team1?: BracketTeam | undefined;
+
+  /**
+   * @generated from field: cribbly.v1.BracketTeam team2 = 4;
+   */
+  team2?: BracketTeam | undefined;
+
+  /**
+   * Unset until the game has been played.
+   *
+   * @generated from field: cribbly.v1.BracketTeam winner = 5;
+   */
+  winner?: BracketTeam | undefined;
+
+  /**
+   * True for a first-round game with only team1, who advanced without playing. The winner is
+   * already set.
+   *
+   * @generated from field: bool bye = 6;
+   */
+  bye: boolean;
+
+  /**
+   * @generated from field: cribbly.v1.BracketSide side = 7;
+   */
+  side: BracketSide;
+
+  /**
+   * The number of games in the series; 1 for a single game.
+   *
+   * @generated from field: int32 best_of = 8;
+   */
+  bestOf: number;
+
+  /**
+   * The games of the series played so far, if their scores have been recorded.
+   *
+   * @generated from field: repeated cribbly.v1.SeriesGame series = 9;
+   */
+  series: SeriesGame[];
+
+  /**
+   * Results reported by the game's teams that are waiting to be confirmed, in the order of the
+   * teams. The game is decided once both teams agree or an admin advances a team.
+   *
+   * @generated from field: repeated cribbly.v1.BracketReport reports = 10;
+   */
+  reports: BracketReport[];
+};
+
+/**
+ * Describes the message cribbly.v1.BracketGame.
+ * Use `create(BracketGameSchema)` to create a new message.
+ */
+export const BracketGameSchema: GenMessage<BracketGame> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 1);
+
+/**
+ * @generated from message cribbly.v1.BracketReport
+ */
+export type BracketReport = Message<"cribbly.v1.BracketReport"> & {
+  /**
+   * @generated from field: cribbly.v1.BracketTeam team = 1;
+   */
+  team?: BracketTeam | undefined;
+
+  /**
+   * Whether the team says they won.
+   *
+   * @generated from field: bool won = 2;
+   */
+  won: boolean;
+
+  /**
+   * The loser's score, which the team reports along with who won.
+   *
+   * @generated from field: int32 loser_score = 3;
+   */
+  loserScore: number;
+};
+
+/**
+ * Describes the message cribbly.v1.BracketReport.
+ * Use `create(BracketReportSchema)` to create a new message.
+ */
+export const BracketReportSchema: GenMessage<BracketReport> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 2);
+
+/**
+ * @generated from message cribbly.v1.SeriesGame
+ */
+export type SeriesGame = Message<"cribbly.v1.SeriesGame"> & {
+  /**
+   * @generated from field: int32 team1_score = 1;
+   */
+  team1Score: number;
+
+  /**
+   * @generated from field: int32 team2_score = 2;
+   */
+  team2Score: number;
+};
+
+/**
+ * Describes the message cribbly.v1.SeriesGame.
+ * Use `create(SeriesGameSchema)` to create a new message.
+ */
+export const SeriesGameSchema: GenMessage<SeriesGame> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 3);
+
+/**
+ * @generated from message cribbly.v1.BracketRound
+ */
+export type BracketRound = Message<"cribbly.v1.BracketRound"> & {
+  /**
+   * @generated from field: repeated cribbly.v1.BracketGame games = 1;
+   */
+  games: BracketGame[];
+};
+
+/**
+ * Describes the message cribbly.v1.BracketRound.
+ * Use `create(BracketRoundSchema)` to create a new message.
+ */
+export const BracketRoundSchema: GenMessage<BracketRound> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 4);
+
+/**
+ * @generated from message cribbly.v1.Bracket
+ */
+export type Bracket = Message<"cribbly.v1.Bracket"> & {
+  /**
+   * Empty when the bracket hasn't been seeded. The last round is the final.
+   *
+   * @generated from field: repeated cribbly.v1.BracketRound rounds = 1;
+   */
+  rounds: BracketRound[];
+
+  /**
+   * Number of teams in the event, i.e. the most that can be seeded.
+   *
+   * @generated from field: int32 team_count = 2;
+   */
+  teamCount: number;
+
+  /**
+   * Unset until the final has been played.
+   *
+   * @generated from field: cribbly.v1.BracketTeam champion = 3;
+   */
+  champion?: BracketTeam | undefined;
+
+  /**
+   * @generated from field: cribbly.v1.BracketFormat format = 4;
+   */
+  format: BracketFormat;
+
+  /**
+   * The losers side of a double-elimination bracket. The winners side is in rounds.
+   *
+   * @generated from field: repeated cribbly.v1.BracketRound losers_rounds = 5;
+   */
+  losersRounds: BracketRound[];
+
+  /**
+   * The grand final and reset game of a double-elimination bracket.
+   *
+   * @generated from field: repeated cribbly.v1.BracketRound final_rounds = 6;
+   */
+  finalRounds: BracketRound[];
+
+  /**
+   * The game between the semifinal losers. Unset if the bracket doesn't have one.
+   *
+   * @generated from field: cribbly.v1.BracketGame third_place = 7;
+   */
+  thirdPlace?: BracketGame | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.Bracket.
+ * Use `create(BracketSchema)` to create a new message.
+ */
+export const BracketSchema: GenMessage<Bracket> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 5);
+
+/**
+ * @generated from message cribbly.v1.GetBracketRequest
+ */
+export type GetBracketRequest = Message<"cribbly.v1.GetBracketRequest"> & {
+};
+
+/**
+ * Describes the message cribbly.v1.GetBracketRequest.
+ * Use `create(GetBracketRequestSchema)` to create a new message.
+ */
+export const GetBracketRequestSchema: GenMessage<GetBracketRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 6);
+
+/**
+ * @generated from message cribbly.v1.GetBracketResponse
+ */
+export type GetBracketResponse = Message<"cribbly.v1.GetBracketResponse"> & {
+  /**
+   * @generated from field: cribbly.v1.Bracket bracket = 1;
+   */
+  bracket?: Bracket | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.GetBracketResponse.
+ * Use `create(GetBracketResponseSchema)` to create a new message.
+ */
+export const GetBracketResponseSchema: GenMessage<GetBracketResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 7);
+
+/**
+ * @generated from message cribbly.v1.SeedBracketRequest
+ */
+export type SeedBracketRequest = Message<"cribbly.v1.SeedBracketRequest"> & {
+  /**
+   * At least two and no larger than the number of teams. If it isn't a power of two, the top seeds
+   * get first-round byes.
+   *
+   * @generated from field: int32 size = 1;
+   */
+  size: number;
+
+  /**
+   * Double elimination needs a power of two of at least four teams.
+   *
+   * @generated from field: cribbly.v1.BracketFormat format = 2;
+   */
+  format: BracketFormat;
+
+  /**
+   * Adds a game between the semifinal losers. Needs at least four teams; single elimination only.
+   *
+   * @generated from field: bool third_place = 3;
+   */
+  thirdPlace: boolean;
+};
+
+/**
+ * Describes the message cribbly.v1.SeedBracketRequest.
+ * Use `create(SeedBracketRequestSchema)` to create a new message.
+ */
+export const SeedBracketRequestSchema: GenMessage<SeedBracketRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 8);
+
+/**
+ * @generated from message cribbly.v1.SeedBracketResponse
+ */
+export type SeedBracketResponse = Message<"cribbly.v1.SeedBracketResponse"> & {
+  /**
+   * @generated from field: cribbly.v1.Bracket bracket = 1;
+   */
+  bracket?: Bracket | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.SeedBracketResponse.
+ * Use `create(SeedBracketResponseSchema)` to create a new message.
+ */
+export const SeedBracketResponseSchema: GenMessage<SeedBracketResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 9);
+
+/**
+ * @generated from message cribbly.v1.AdvanceTeamRequest
+ */
+export type AdvanceTeamRequest = Message<"cribbly.v1.AdvanceTeamRequest"> & {
+  /**
+   * The game the team won.
+   *
+   * @generated from field: int32 round = 1;
+   */
+  round: number;
+
+  /**
+   * @generated from field: int32 idx = 2;
+   */
+  idx: number;
+
+  /**
+   * @generated from field: string team_id = 3;
+   */
+  teamId: string;
+
+  /**
+   * @generated from field: cribbly.v1.BracketSide side = 4;
+   */
+  side: BracketSide;
+};
+
+/**
+ * Describes the message cribbly.v1.AdvanceTeamRequest.
+ * Use `create(AdvanceTeamRequestSchema)` to create a new message.
+ */
+export const AdvanceTeamRequestSchema: GenMessage<AdvanceTeamRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 10);
+
+/**
+ * @generated from message cribbly.v1.AdvanceTeamResponse
+ */
+export type AdvanceTeamResponse = Message<"cribbly.v1.AdvanceTeamResponse"> & {
+  /**
+   * @generated from field: cribbly.v1.Bracket bracket = 1;
+   */
+  bracket?: Bracket | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.AdvanceTeamResponse.
+ * Use `create(AdvanceTeamResponseSchema)` to create a new message.
+ */
+export const AdvanceTeamResponseSchema: GenMessage<AdvanceTeamResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 11);
+
+/**
+ * @generated from message cribbly.v1.RevertAdvanceRequest
+ */
+export type RevertAdvanceRequest = Message<"cribbly.v1.RevertAdvanceRequest"> & {
+  /**
+   * The game whose result should be undone.
+   *
+   * @generated from field: int32 round = 1;
+   */
+  round: number;
+
+  /**
+   * @generated from field: int32 idx = 2;
+   */
+  idx: number;
+
+  /**
+   * @generated from field: string team_id = 3;
+   */
+  teamId: string;
+
+  /**
+   * @generated from field: cribbly.v1.BracketSide side = 4;
+   */
+  side: BracketSide;
+};
+
+/**
+ * Describes the message cribbly.v1.RevertAdvanceRequest.
+ * Use `create(RevertAdvanceRequestSchema)` to create a new message.
+ */
+export const RevertAdvanceRequestSchema: GenMessage<RevertAdvanceRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 12);
+
+/**
+ * @generated from message cribbly.v1.RevertAdvanceResponse
+ */
+export type RevertAdvanceResponse = Message<"cribbly.v1.RevertAdvanceResponse"> & {
+  /**
+   * @generated from field: cribbly.v1.Bracket bracket = 1;
+   */
+  bracket?: Bracket | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.RevertAdvanceResponse.
+ * Use `create(RevertAdvanceResponseSchema)` to create a new message.
+ */
+export const RevertAdvanceResponseSchema: GenMessage<RevertAdvanceResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 13);
+
+/**
+ * @generated from message cribbly.v1.DeleteBracketRequest
+ */
+export type DeleteBracketRequest = Message<"cribbly.v1.DeleteBracketRequest"> & {
+};
+
+/**
+ * Describes the message cribbly.v1.DeleteBracketRequest.
+ * Use `create(DeleteBracketRequestSchema)` to create a new message.
+ */
+export const DeleteBracketRequestSchema: GenMessage<DeleteBracketRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 14);
+
+/**
+ * @generated from message cribbly.v1.DeleteBracketResponse
+ */
+export type DeleteBracketResponse = Message<"cribbly.v1.DeleteBracketResponse"> & {
+};
+
+/**
+ * Describes the message cribbly.v1.DeleteBracketResponse.
+ * Use `create(DeleteBracketResponseSchema)` to create a new message.
+ */
+export const DeleteBracketResponseSchema: GenMessage<DeleteBracketResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 15);
+
+/**
+ * @generated from message cribbly.v1.WatchBracketRequest
+ */
+export type WatchBracketRequest = Message<"cribbly.v1.WatchBracketRequest"> & {
+};
+
+/**
+ * Describes the message cribbly.v1.WatchBracketRequest.
+ * Use `create(WatchBracketRequestSchema)` to create a new message.
+ */
+export const WatchBracketRequestSchema: GenMessage<WatchBracketRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 16);
+
+/**
+ * @generated from message cribbly.v1.WatchBracketResponse
+ */
+export type WatchBracketResponse = Message<"cribbly.v1.WatchBracketResponse"> & {
+  /**
+   * @generated from field: cribbly.v1.Bracket bracket = 1;
+   */
+  bracket?: Bracket | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.WatchBracketResponse.
+ * Use `create(WatchBracketResponseSchema)` to create a new message.
+ */
+export const WatchBracketResponseSchema: GenMessage<WatchBracketResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 17);
+
+/**
+ * @generated from message cribbly.v1.SetSeriesLengthRequest
+ */
+export type SetSeriesLengthRequest = Message<"cribbly.v1.SetSeriesLengthRequest"> & {
+  /**
+   * @generated from field: int32 round = 1;
+   */
+  round: number;
+
+  /**
+   * @generated from field: int32 idx = 2;
+   */
+  idx: number;
+
+  /**
+   * @generated from field: cribbly.v1.BracketSide side = 3;
+   */
+  side: BracketSide;
+
+  /**
+   * An odd number of games.
+   *
+   * @generated from field: int32 best_of = 4;
+   */
+  bestOf: number;
+};
+
+/**
+ * Describes the message cribbly.v1.SetSeriesLengthRequest.
+ * Use `create(SetSeriesLengthRequestSchema)` to create a new message.
+ */
+export const SetSeriesLengthRequestSchema: GenMessage<SetSeriesLengthRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 18);
+
+/**
+ * @generated from message cribbly.v1.SetSeriesLengthResponse
+ */
+export type SetSeriesLengthResponse = Message<"cribbly.v1.SetSeriesLengthResponse"> & {
+  /**
+   * @generated from field: cribbly.v1.Bracket bracket = 1;
+   */
+  bracket?: Bracket | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.SetSeriesLengthResponse.
+ * Use `create(SetSeriesLengthResponseSchema)` to create a new message.
+ */
+export const SetSeriesLengthResponseSchema: GenMessage<SetSeriesLengthResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 19);
+
+/**
+ * @generated from message cribbly.v1.RecordSeriesGameRequest
+ */
+export type RecordSeriesGameRequest = Message<"cribbly.v1.RecordSeriesGameRequest"> & {
+  /**
+   * @generated from field: int32 round = 1;
+   */
+  round: number;
+
+  /**
+   * @generated from field: int32 idx = 2;
+   */
+  idx: number;
+
+  /**
+   * @generated from field: cribbly.v1.BracketSide side = 3;
+   */
+  side: BracketSide;
+
+  /**
+   * The team that reached 121.
+   *
+   * @generated from field: string winner_team_id = 4;
+   */
+  winnerTeamId: string;
+
+  /**
+   * Between 1 and 120.
+   *
+   * @generated from field: int32 loser_score = 5;
+   */
+  loserScore: number;
+};
+
+/**
+ * Describes the message cribbly.v1.RecordSeriesGameRequest.
+ * Use `create(RecordSeriesGameRequestSchema)` to create a new message.
+ */
+export const RecordSeriesGameRequestSchema: GenMessage<RecordSeriesGameRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 20);
+
+/**
+ * @generated from message cribbly.v1.RecordSeriesGameResponse
+ */
+export type RecordSeriesGameResponse = Message<"cribbly.v1.RecordSeriesGameResponse"> & {
+  /**
+   * @generated from field: cribbly.v1.Bracket bracket = 1;
+   */
+  bracket?: Bracket | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.RecordSeriesGameResponse.
+ * Use `create(RecordSeriesGameResponseSchema)` to create a new message.
+ */
+export const RecordSeriesGameResponseSchema: GenMessage<RecordSeriesGameResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 21);
+
+/**
+ * @generated from message cribbly.v1.UndoSeriesGameRequest
+ */
+export type UndoSeriesGameRequest = Message<"cribbly.v1.UndoSeriesGameRequest"> & {
+  /**
+   * @generated from field: int32 round = 1;
+   */
+  round: number;
+
+  /**
+   * @generated from field: int32 idx = 2;
+   */
+  idx: number;
+
+  /**
+   * @generated from field: cribbly.v1.BracketSide side = 3;
+   */
+  side: BracketSide;
+};
+
+/**
+ * Describes the message cribbly.v1.UndoSeriesGameRequest.
+ * Use `create(UndoSeriesGameRequestSchema)` to create a new message.
+ */
+export const UndoSeriesGameRequestSchema: GenMessage<UndoSeriesGameRequest> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 22);
+
+/**
+ * @generated from message cribbly.v1.UndoSeriesGameResponse
+ */
+export type UndoSeriesGameResponse = Message<"cribbly.v1.UndoSeriesGameResponse"> & {
+  /**
+   * @generated from field: cribbly.v1.Bracket bracket = 1;
+   */
+  bracket?: Bracket | undefined;
+};
+
+/**
+ * Describes the message cribbly.v1.UndoSeriesGameResponse.
+ * Use `create(UndoSeriesGameResponseSchema)` to create a new message.
+ */
+export const UndoSeriesGameResponseSchema: GenMessage<UndoSeriesGameResponse> = /*@__PURE__*/
+  messageDesc(file_cribbly_v1_tournament, 23);
+
+/**
+ * @generated from enum cribbly.v1.BracketFormat
+ */
+export enum BracketFormat {
+  /**
+   * Treated as single elimination.
+   *
+   * @generated from enum value: BRACKET_FORMAT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: BRACKET_FORMAT_SINGLE_ELIMINATION = 1;
+   */
+  SINGLE_ELIMINATION = 1,
+
+  /**
+   * @generated from enum value: BRACKET_FORMAT_DOUBLE_ELIMINATION = 2;
+   */
+  DOUBLE_ELIMINATION = 2,
+}
+
+/**
+ * Describes the enum cribbly.v1.BracketFormat.
+ */
+export const BracketFormatSchema: GenEnum<BracketFormat> = /*@__PURE__*/
+  enumDesc(file_cribbly_v1_tournament, 0);
+
+/**
+ * @generated from enum cribbly.v1.BracketSide
+ */
+export enum BracketSide {
+  /**
+   * Treated as the winners side.
+   *
+   * @generated from enum value: BRACKET_SIDE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * The whole bracket in single elimination.
+   *
+   * @generated from enum value: BRACKET_SIDE_WINNERS = 1;
+   */
+  WINNERS = 1,
+
+  /**
+   * @generated from enum value: BRACKET_SIDE_LOSERS = 2;
+   */
+  LOSERS = 2,
+
+  /**
+   * The grand final (round 0) and its reset game (round 1).
+   *
+   * @generated from enum value: BRACKET_SIDE_FINAL = 3;
+   */
+  FINAL = 3,
+
+  /**
+   * The third-place game of a single-elimination bracket (round 0, idx 0).
+   *
+   * @generated from enum value: BRACKET_SIDE_THIRD_PLACE = 4;
+   */
+  THIRD_PLACE = 4,
+}
+
+/**
+ * Describes the enum cribbly.v1.BracketSide.
+ */
+export const BracketSideSchema: GenEnum<BracketSide> = /*@__PURE__*/
+  enumDesc(file_cribbly_v1_tournament, 1);
+
+/**
+ * API for the playoff bracket (same data as legacy /tournament). Reading and watching the bracket is
+ * public; changing it requires an admin.
+ *
+ * @generated from service cribbly.v1.TournamentService
+ */
+export const TournamentService: GenService<{
+  /**
+   * @generated from rpc cribbly.v1.TournamentService.GetBracket
+   */
+  getBracket: {
+    methodKind: "unary";
+    input: typeof GetBracketRequestSchema;
+    output: typeof GetBracketResponseSchema;
+  },
+  /**
+   * Seeds a new bracket from the prelim standings.
+   *
+   * @generated from rpc cribbly.v1.TournamentService.SeedBracket
+   */
+  seedBracket: {
+    methodKind: "unary";
+    input: typeof SeedBracketRequestSchema;
+    output: typeof SeedBracketResponseSchema;
+  },
+  /**
+   * Records a team as the winner of a game and moves them into the next round.
+   *
+   * @generated from rpc cribbly.v1.TournamentService.AdvanceTeam
+   */
+  advanceTeam: {
+    methodKind: "unary";
+    input: typeof AdvanceTeamRequestSchema;
+    output: typeof AdvanceTeamResponseSchema;
+  },
+  /**
+   * Undoes AdvanceTeam. Only a team's furthest result can be reverted.
+   *
+   * @generated from rpc cribbly.v1.TournamentService.RevertAdvance
+   */
+  revertAdvance: {
+    methodKind: "unary";
+    input: typeof RevertAdvanceRequestSchema;
+    output: typeof RevertAdvanceResponseSchema;
+  },
+  /**
+   * @generated from rpc cribbly.v1.TournamentService.DeleteBracket
+   */
+  deleteBracket: {
+    methodKind: "unary";
+    input: typeof DeleteBracketRequestSchema;
+    output: typeof DeleteBracketResponseSchema;
+  },
+  /**
+   * Makes a game a best-of-N series. Can't be changed once a game of the series is recorded.
+   *
+   * @generated from rpc cribbly.v1.TournamentService.SetSeriesLength
+   */
+  setSeriesLength: {
+    methodKind: "unary";
+    input: typeof SetSeriesLengthRequestSchema;
+    output: typeof SetSeriesLengthResponseSchema;
+  },
+  /**
+   * Records the next game of a series. The team that wins a majority of the games advances.
+   *
+   * @generated from rpc cribbly.v1.TournamentService.RecordSeriesGame
+   */
+  recordSeriesGame: {
+    methodKind: "unary";
+    input: typeof RecordSeriesGameRequestSchema;
+    output: typeof RecordSeriesGameResponseSchema;
+  },
+  /**
+   * Removes the last recorded game of a series, reverting the result if that game decided it.
+   *
+   * @generated from rpc cribbly.v1.TournamentService.UndoSeriesGame
+   */
+  undoSeriesGame: {
+    methodKind: "unary";
+    input: typeof UndoSeriesGameRequestSchema;
+    output: typeof UndoSeriesGameResponseSchema;
+  },
+  /**
+   * Sends the current bracket, then the whole bracket again every time it changes.
+   *
+   * @generated from rpc cribbly.v1.TournamentService.WatchBracket
+   */
+  watchBracket: {
+    methodKind: "server_streaming";
+    input: typeof WatchBracketRequestSchema;
+    output: typeof WatchBracketResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_cribbly_v1_tournament, 0);
+
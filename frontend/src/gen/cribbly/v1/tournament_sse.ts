@@ -0,0 +1,30 @@
+// @generated by protoc-gen-cribbly-sse with parameter "target=ts"
+// @generated from file cribbly/v1/tournament.proto (package cribbly.v1, syntax proto3)
+/* eslint-disable */
+
+import type { MessageInitShape } from "@bufbuild/protobuf";
+import { openStream } from "@/lib/sse";
+import type { StreamHandlers, StreamOptions } from "@/lib/sse";
+import { WatchBracketRequestSchema, WatchBracketResponseSchema } from "./tournament_pb";
+import type { WatchBracketResponse } from "./tournament_pb";
+
+/**
+ * Server-sent event streams of cribbly.v1.TournamentService.
+ */
+export const TournamentServiceSSE = {
+  /**
+   * Sends the current bracket, then the whole bracket again every time it changes.
+   *
+   * Served at /tournament/stream. Returns a function that closes the stream.
+   *
+   * @generated from rpc cribbly.v1.TournamentService.WatchBracket
+   */
+  watchBracket(
+    request: MessageInitShape<typeof WatchBracketRequestSchema>,
+    handlers: StreamHandlers<WatchBracketResponse>,
+    options?: StreamOptions,
+  ): () => void {
+    return openStream("/tournament/stream", WatchBracketRequestSchema, request, WatchBracketResponseSchema, handlers, options);
+  },
+};
+
@@ -0,0 +1,79 @@
+import { afterEach, beforeEach, describe, expect, it, vi } from "vitest"
+import { StandingsServiceSSE } from "@/gen/cribbly/v1/standings_sse"
+import { STREAM_ERROR_EVENT } from "./sse"
+
+class FakeEventSource extends EventTarget {
+  static last: FakeEventSource | undefined
+  onmessage: ((e: MessageEvent<string>) => void) | null = null
+  closed = false
+
+  constructor(
+    readonly url: string,
+    readonly init?: EventSourceInit,
+  ) {
+    super()
+    FakeEventSource.last = this
+  }
+
+  emit(data: string) {
+    this.onmessage?.(new MessageEvent("message", { data }))
+  }
+
+  close() {
+    this.closed = true
+  }
+}
+
+describe("openStream", () => {
+  beforeEach(() => {
+    vi.stubGlobal("EventSource", FakeEventSource)
+  })
+
+  afterEach(() => {
+    vi.unstubAllGlobals()
+    FakeEventSource.last = undefined
+  })
+
+  it("omits the message parameter for an empty request", () => {
+    StandingsServiceSSE.watchStandings({}, { onMessage: () => {} })
+    expect(FakeEventSource.last?.url).toBe("/standings/stream")
+    expect(FakeEventSource.last?.init?.withCredentials).toBe(true)
+  })
+
+  it("appends extra query parameters", () => {
+    StandingsServiceSSE.watchStandings(
+      {},
+      { onMessage: () => {} },
+      { query: { event: "abc" } },
+    )
+    expect(FakeEventSource.last?.url).toBe("/standings/stream?event=abc")
+  })
+
+  it("decodes messages and reports stream errors", () => {
+    const onMessage = vi.fn()
+    const onError = vi.fn()
+    const close = StandingsServiceSSE.watchStandings({}, { onMessage, onError })
+    const source = FakeEventSource.last!
+
+    source.emit(
+      JSON.stringify({
+        standings: [{ teamId: "t1", teamName: "Team 1", wins: 2 }],
+      }),
+    )
+    expect(onMessage).toHaveBeenCalledTimes(1)
+    expect(onMessage.mock.calls[0][0].standings[0].teamName).toBe("Team 1")
+    expect(onMessage.mock.calls[0][0].standings[0].wins).toBe(2)
+
+    source.emit("not json")
+    expect(onError).toHaveBeenCalledTimes(1)
+
+    source.dispatchEvent(
+      new MessageEvent(STREAM_ERROR_EVENT, { data: "the stream failed" }),
+    )
+    expect(onError).toHaveBeenCalledTimes(2)
+    expect(onError.mock.calls[1][0].message).toBe("the stream failed")
+
+    close()
+    expect(source.closed).toBe(true)
+  })
+})
@@ -0,0 +1,62 @@
+import {
+  type DescMessage,
+  type MessageInitShape,
+  type MessageShape,
+  create,
+  fromJsonString,
+  toJsonString,
+} from "@bufbuild/protobuf"
+
+/** Event type the server sends if a stream fails after it has started (see internal/sse). */
+export const STREAM_ERROR_EVENT = "stream-error"
+
+export type StreamHandlers<T> = {
+  onMessage(message: T): void
+  /** Called when the server reports a failure or a message cannot be decoded. */
+  onError?(err: Error): void
+}
+
+export type StreamOptions = {
+  /** Prefix for the stream path; defaults to the current origin. */
+  baseUrl?: string
+  /** Extra query parameters, e.g. `{ event: "..." }` to watch a past event. */
+  query?: Record<string, string>
+}
+
+/**
+ * Opens a server-sent event stream generated by protoc-gen-cribbly-sse. The request is sent as
+ * proto JSON in the `message` query parameter and every event is decoded as `output`. The browser
+ * reconnects on its own if the connection drops. Returns a function that closes the stream.
+ */
+export function openStream<I extends DescMessage, O extends DescMessage>(
+  path: string,
+  input: I,
+  request: MessageInitShape<I>,
+  output: O,
+  handlers: StreamHandlers<MessageShape<O>>,
+  options?: StreamOptions,
+): () => void {
+  const params = new URLSearchParams(options?.query)
+  const message = toJsonString(input, create(input, request))
+  if (message !== "{}") {
+    params.set("message", message)
+  }
+  const qs = params.toString()
+  const url = `${options?.baseUrl ?? ""}${path}${qs ? `?${qs}` : ""}`
+
+  const source = new EventSource(url, { withCredentials: true })
+  source.onmessage = (e: MessageEvent<string>) => {
+    let msg: MessageShape<O>
+    try {
+      msg = fromJsonString(output, e.data)
+    } catch (err) {
+      handlers.onError?.(err instanceof Error ? err : new Error(String(err)))
+      return
+    }
+    handlers.onMessage(msg)
+  }
+  source.addEventListener(STREAM_ERROR_EVENT, (e) => {
+    handlers.onError?.(new Error((e as MessageEvent<string>).data))
+  })
+  return () => source.close()
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 
@@ -13,10 +14,12 @@ import (
 	"github.com/cszczepaniak/cribbly/internal/moreiter"
 	"github.com/cszczepaniak/cribbly/internal/persistence/players"
 	"github.com/cszczepaniak/cribbly/internal/server/middleware"
+	"github.com/cszczepaniak/cribbly/internal/service/history"
 )
 
 type Server struct {
-	PlayerRepo players.Repository
+	PlayerRepo     players.Repository
+	HistoryService history.Service
 }
 
 func requireAdmin(ctx context.Context) error {
@@ -34,6 +37,7 @@ func toProto(ps []players.Player) []*cribblyv1.Player {
 			FirstName: p.FirstName,
 			LastName:  p.LastName,
 			TeamId:    p.TeamID,
+			PersonId:  p.PersonID,
 		})
 	}
 	return out
@@ -213,3 +217,59 @@ func (s *Server) GenerateRandomPlayers(
 
 	return connect.NewResponse(&cribblyv1.GenerateRandomPlayersResponse{Players: toProto(ps)}), nil
 }
+
+// GetPlayerHistory is public, like the player pages it backs.
+func (s *Server) GetPlayerHistory(
+	ctx context.Context,
+	req *connect.Request[cribblyv1.GetPlayerHistoryRequest],
+) (*connect.Response[cribblyv1.GetPlayerHistoryResponse], error) {
+	personID := strings.TrimSpace(req.Msg.GetPersonId())
+	if personID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("person_id is required"))
+	}
+
+	h, err := s.HistoryService.GetPlayerHistory(ctx, personID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("player not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &cribblyv1.GetPlayerHistoryResponse{
+		PersonId:  h.Person.ID,
+		FirstName: h.Person.FirstName,
+		LastName:  h.Person.LastName,
+		Record:    recordToProto(h.Record),
+	}
+	for _, e := range h.Events {
+		resp.Events = append(resp.Events, &cribblyv1.PlayerEvent{
+			EventId:       e.Event.ID,
+			EventName:     e.Event.Name,
+			Date:          e.Event.Date.Format(time.DateOnly),
+			TeamId:        e.Team.ID,
+			TeamName:      e.Team.Name,
+			Partners:      toProto(e.Partners),
+			DivisionName:  e.Division.Name,
+			Finish:        int32(e.Finish),
+			FinishOf:      int32(e.FinishOf),
+			BracketResult: e.Bracket,
+			Record:        recordToProto(e.Record),
+			Rating:        int32(e.Rating),
+		})
+	}
+	for _, o := range h.Opponents {
+		resp.Opponents = append(resp.Opponents, &cribblyv1.HeadToHead{
+			PersonId:  o.Opponent.ID,
+			FirstName: o.Opponent.FirstName,
+			LastName:  o.Opponent.LastName,
+			Record:    recordToProto(o.Record),
+		})
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+func recordToProto(r history.Record) *cribblyv1.Record {
+	return &cribblyv1.Record{Wins: int32(r.Wins), Losses: int32(r.Losses)}
+}
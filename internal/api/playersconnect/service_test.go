@@ -10,16 +10,33 @@ import (
 
 	cribblyv1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/divisions"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
 	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/ratings"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
 	"github.com/cszczepaniak/cribbly/internal/server/middleware"
+	"github.com/cszczepaniak/cribbly/internal/service/history"
+	"github.com/cszczepaniak/cribbly/internal/service/tournament"
 )
 
 func newTestServer(t *testing.T) (*Server, players.Repository) {
 	t.Helper()
 	db := database.NewInMemory(t)
 	repo := players.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
-	return &Server{PlayerRepo: repo}, repo
+	tr := teams.NewRepository(db)
+	gr := games.NewRepository(db, &games.ScoreNotifier{})
+	hs := history.New(
+		events.NewRepository(db),
+		repo,
+		tr,
+		divisions.NewRepository(db),
+		gr,
+		ratings.NewRepository(db),
+		tournament.New(database.NewTransactor(db), gr, tr, &tournament.Notifier{}),
+	)
+	return &Server{PlayerRepo: repo, HistoryService: hs}, repo
 }
 
 func assertConnectCode(t *testing.T, err error, want connect.Code) {
@@ -260,3 +277,30 @@ func TestGenerateRandomPlayers_WithDevAdmin(t *testing.T) {
 	assert.NoError(t, err)
 	assert.SliceLen(t, resp.Msg.GetPlayers(), 4)
 }
+
+func TestGetPlayerHistory_NotFound(t *testing.T) {
+	svc, _ := newTestServer(t)
+	_, err := svc.GetPlayerHistory(
+		t.Context(),
+		connect.NewRequest(&cribblyv1.GetPlayerHistoryRequest{PersonId: uuid.NewString()}),
+	)
+	assertConnectCode(t, err, connect.CodeNotFound)
+}
+
+func TestGetPlayerHistory_NoAdminNeeded(t *testing.T) {
+	svc, repo := newTestServer(t)
+	id, err := repo.Create(t.Context(), "Ada", "Lovelace")
+	assert.NoError(t, err)
+	p, err := repo.Get(t.Context(), id)
+	assert.NoError(t, err)
+
+	resp, err := svc.GetPlayerHistory(
+		t.Context(),
+		connect.NewRequest(&cribblyv1.GetPlayerHistoryRequest{PersonId: p.PersonID}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ada", resp.Msg.GetFirstName())
+	assert.SliceLen(t, resp.Msg.GetEvents(), 1)
+	assert.Equal(t, events.LegacyID, resp.Msg.GetEvents()[0].GetEventId())
+	assert.Equal(t, "", resp.Msg.GetEvents()[0].GetTeamId())
+}
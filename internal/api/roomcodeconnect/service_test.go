@@ -21,7 +21,6 @@ import (
 func TestSetRoomCode_SetsCookieHeader(t *testing.T) {
 	db := database.NewInMemory(t)
 	repo := roomcodes.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 	assert.NoError(t, repo.Create(t.Context(), "GOODCODE", time.Now().Add(time.Hour)))
 
 	svc := &Server{Repo: repo}
@@ -46,7 +45,6 @@ func TestSetRoomCode_SetsCookieHeader(t *testing.T) {
 func TestSetRoomCode_InvalidCode(t *testing.T) {
 	db := database.NewInMemory(t)
 	repo := roomcodes.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 
 	svc := &Server{Repo: repo}
 	_, h := cribblyv1connect.NewRoomCodeServiceHandler(svc)
@@ -69,7 +67,6 @@ func TestSetRoomCode_InvalidCode(t *testing.T) {
 func TestCheckRoomAccess_NoCookie(t *testing.T) {
 	db := database.NewInMemory(t)
 	repo := roomcodes.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 
 	svc := &Server{Repo: repo}
 	_, h := cribblyv1connect.NewRoomCodeServiceHandler(svc)
@@ -88,7 +85,6 @@ func TestCheckRoomAccess_NoCookie(t *testing.T) {
 func TestCheckRoomAccess_ValidRoomCookie(t *testing.T) {
 	db := database.NewInMemory(t)
 	repo := roomcodes.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 	assert.NoError(t, repo.Create(t.Context(), "GOODCODE", time.Now().Add(time.Hour)))
 
 	svc := &Server{Repo: repo}
@@ -108,7 +104,6 @@ func TestCheckRoomAccess_ValidRoomCookie(t *testing.T) {
 func TestGenerateRoomCode_NotAdmin(t *testing.T) {
 	db := database.NewInMemory(t)
 	repo := roomcodes.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 
 	svc := &Server{Repo: repo}
 	_, err := svc.GenerateRoomCode(
@@ -126,7 +121,6 @@ func TestGenerateRoomCode_NotAdmin(t *testing.T) {
 func TestGenerateRoomCode_WithDevAdminContext(t *testing.T) {
 	db := database.NewInMemory(t)
 	repo := roomcodes.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 
 	svc := &Server{Repo: repo}
 	ctx := middleware.WithDevAdminContext(t.Context())
@@ -0,0 +1,181 @@
+package standingsconnect
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+
+	cribblyv1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
+	"github.com/cszczepaniak/cribbly/internal/notifier"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/sse"
+)
+
+type Server struct {
+	GameRepo            games.Repository
+	ScoreUpdateNotifier *games.ScoreNotifier
+}
+
+func standingToProto(st games.Standing) *cribblyv1.Standing {
+	res := &cribblyv1.Standing{
+		TeamId:            st.TeamID,
+		TeamName:          st.TeamName,
+		Wins:              int32(st.Wins),
+		Losses:            int32(st.Losses),
+		WinRate:           st.WinRate(),
+		PointsPerGame:     st.PointsPerGame(),
+		GamePoints:        int32(st.GamePoints),
+		SkunksGiven:       int32(st.SkunksGiven),
+		DoubleSkunksGiven: int32(st.DoubleSkunksGiven),
+		SkunksTaken:       int32(st.SkunksTaken),
+		DoubleSkunksTaken: int32(st.DoubleSkunksTaken),
+		PointsAllowed:     int32(st.PointsAllowed),
+		Tiebreak:          string(st.Tiebreak),
+		CoinFlipPending:   st.CoinFlipPending,
+	}
+	if !st.CoinFlippedAt.IsZero() {
+		res.CoinFlippedAt = st.CoinFlippedAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func standingsToProto(standings []games.Standing) []*cribblyv1.Standing {
+	res := make([]*cribblyv1.Standing, 0, len(standings))
+	for _, st := range standings {
+		res = append(res, standingToProto(st))
+	}
+	return res
+}
+
+func (s *Server) getStandings(ctx context.Context) ([]*cribblyv1.Standing, error) {
+	standings, err := s.GameRepo.GetStandings(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return standingsToProto(standings), nil
+}
+
+func (s *Server) GetStandings(
+	ctx context.Context,
+	_ *connect.Request[cribblyv1.GetStandingsRequest],
+) (*connect.Response[cribblyv1.GetStandingsResponse], error) {
+	standings, err := s.getStandings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&cribblyv1.GetStandingsResponse{Standings: standings}), nil
+}
+
+func (s *Server) WatchStandings(
+	ctx context.Context,
+	_ *connect.Request[cribblyv1.WatchStandingsRequest],
+	stream *connect.ServerStream[cribblyv1.WatchStandingsResponse],
+) error {
+	return s.Watch(ctx, notifier.ID{}, func(res *cribblyv1.WatchStandingsResponse, _ notifier.ID) error {
+		return stream.Send(res)
+	})
+}
+
+// SSE serves WatchStandings as a server-sent event stream for clients that can't use Connect
+// streaming, such as a plain EventSource. Each event carries the ID of the score change it
+// reflects, so a client that reconnects only gets the standings again if it missed one.
+type SSE struct {
+	*Server
+}
+
+func (s SSE) WatchStandings(
+	ctx context.Context,
+	_ *cribblyv1.WatchStandingsRequest,
+	stream *sse.Stream[*cribblyv1.WatchStandingsResponse],
+) error {
+	// A missing or foreign ID is the zero ID, which always gets the standings first.
+	lastID, _ := notifier.ParseID(stream.LastEventID())
+	return s.Watch(ctx, lastID, func(res *cribblyv1.WatchStandingsResponse, id notifier.ID) error {
+		return stream.SendWithID(id.String(), res)
+	})
+}
+
+// Watch sends the standings of the current event, then sends them again every time a score in the
+// event changes, until ctx is done. Each send carries the ID of the latest change it reflects.
+//
+// lastID is the last change a reconnecting client saw. The standings are sent first only if the
+// client missed a change since then, or if lastID is too old to tell (such as the zero ID).
+func (s *Server) Watch(
+	ctx context.Context,
+	lastID notifier.ID,
+	send func(*cribblyv1.WatchStandingsResponse, notifier.ID) error,
+) error {
+	eventID, err := s.GameRepo.CurrentEventID(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	topic := games.EventTopic(eventID)
+
+	// Subscribe before reading the standings so that no score change between the two is missed.
+	sub, done := s.ScoreUpdateNotifier.Subscribe(topic)
+	defer done()
+
+	// The standings are loaded once and then kept up to date from the changes themselves, loading
+	// them again only if a change can't be applied or some were dropped.
+	var live *games.LiveStandings
+	load := func() error {
+		var err error
+		live, err = s.GameRepo.LoadStandings(ctx)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return nil
+	}
+	sendStandings := func(id notifier.ID) error {
+		standings, err := live.Standings(ctx)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		return send(&cribblyv1.WatchStandingsResponse{Standings: standingsToProto(standings)}, id)
+	}
+
+	if err := load(); err != nil {
+		return err
+	}
+
+	// The standings are always sent in full, so any number of missed changes is a single send.
+	lastSent := lastID
+	missed, ok := s.ScoreUpdateNotifier.Since(lastID, topic)
+	switch {
+	case !ok:
+		lastSent = s.ScoreUpdateNotifier.LastID()
+		err = sendStandings(lastSent)
+	case len(missed) > 0:
+		lastSent = missed[len(missed)-1].ID
+		err = sendStandings(lastSent)
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-sub:
+			// Already covered by the first send.
+			if ev.ID.Seq <= lastSent.Seq {
+				continue
+			}
+			lastSent = ev.ID
+
+			// A change made between subscribing and loading may already be in the standings.
+			// Applying it again is harmless, since it carries the game's scores rather than a delta.
+			if ev.Dropped > 0 || !live.Apply(ev.Change) {
+				if err := load(); err != nil {
+					return err
+				}
+			}
+			if err := sendStandings(ev.ID); err != nil {
+				return err
+			}
+		}
+	}
+}
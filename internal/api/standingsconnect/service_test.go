@@ -0,0 +1,160 @@
+package standingsconnect
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/cszczepaniak/gotest/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	cribblyv1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
+	cribblyv1sse "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1/cribblyv1sse"
+	"github.com/cszczepaniak/cribbly/internal/notifier"
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+// newTestServer returns a server for an event where team A has beaten team B and a second game
+// between them hasn't been played.
+func newTestServer(t *testing.T) (*Server, string, teams.Team, teams.Team) {
+	t.Helper()
+
+	db := database.NewInMemory(t)
+	tr := teams.NewRepository(db)
+	n := &games.ScoreNotifier{}
+	gr := games.NewRepository(db, n)
+
+	a, err := tr.Create(t.Context(), "A")
+	assert.NoError(t, err)
+	b, err := tr.Create(t.Context(), "B")
+	assert.NoError(t, err)
+
+	g1, err := gr.Create(t.Context(), a.ID, b.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, gr.UpdateScores(t.Context(), g1, a.ID, 121, b.ID, 100))
+
+	g2, err := gr.Create(t.Context(), a.ID, b.ID)
+	assert.NoError(t, err)
+
+	return &Server{GameRepo: gr, ScoreUpdateNotifier: n}, g2, a, b
+}
+
+func TestGetStandings(t *testing.T) {
+	svc, _, a, b := newTestServer(t)
+
+	resp, err := svc.GetStandings(t.Context(), connect.NewRequest(&cribblyv1.GetStandingsRequest{}))
+	assert.NoError(t, err)
+
+	st := resp.Msg.GetStandings()
+	assert.SliceLen(t, st, 2)
+	assert.Equal(t, a.ID, st[0].GetTeamId())
+	assert.Equal(t, "A", st[0].GetTeamName())
+	assert.Equal(t, int32(1), st[0].GetWins())
+	assert.Equal(t, 1.0, st[0].GetWinRate())
+	assert.Equal(t, 121.0, st[0].GetPointsPerGame())
+	assert.Equal(t, int32(2), st[0].GetGamePoints())
+	assert.Equal(t, b.ID, st[1].GetTeamId())
+	assert.Equal(t, int32(1), st[1].GetLosses())
+}
+
+// watchSSE opens the standings stream, resuming from lastEventID if it isn't empty, and returns a
+// function that reads the next event's ID and message.
+func watchSSE(t *testing.T, svc *Server, lastEventID string) func() (string, *cribblyv1.WatchStandingsResponse) {
+	t.Helper()
+
+	path, h := cribblyv1sse.NewStandingsServiceWatchStandingsSSEHandler(SSE{svc})
+	assert.Equal(t, "/standings/stream", path)
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, ts.URL+path, nil)
+	assert.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := bufio.NewScanner(resp.Body)
+	return func() (string, *cribblyv1.WatchStandingsResponse) {
+		t.Helper()
+		var id string
+		for lines.Scan() {
+			if v, ok := strings.CutPrefix(lines.Text(), "id: "); ok {
+				id = v
+				continue
+			}
+			data, ok := strings.CutPrefix(lines.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			msg := &cribblyv1.WatchStandingsResponse{}
+			assert.NoError(t, protojson.Unmarshal([]byte(data), msg))
+			return id, msg
+		}
+		t.Fatal("stream ended")
+		return "", nil
+	}
+}
+
+func TestWatchStandings_SSE(t *testing.T) {
+	svc, g2, a, b := newTestServer(t)
+	next := watchSSE(t, svc, "")
+
+	// The current standings are sent immediately.
+	_, msg := next()
+	assert.SliceLen(t, msg.GetStandings(), 2)
+	assert.Equal(t, int32(1), msg.GetStandings()[0].GetWins())
+
+	assert.NoError(t, svc.GameRepo.UpdateScores(t.Context(), g2, a.ID, 121, b.ID, 90))
+
+	_, msg = next()
+	assert.Equal(t, a.ID, msg.GetStandings()[0].GetTeamId())
+	assert.Equal(t, int32(2), msg.GetStandings()[0].GetWins())
+}
+
+func TestWatchStandings_SSE_Resume(t *testing.T) {
+	svc, g2, a, b := newTestServer(t)
+
+	id, _ := watchSSE(t, svc, "")()
+	first, err := notifier.ParseID(id)
+	assert.NoError(t, err)
+
+	// A client that is up to date gets nothing until the next change, which carries a new ID.
+	next := watchSSE(t, svc, id)
+	assert.NoError(t, svc.GameRepo.UpdateScores(t.Context(), g2, a.ID, 121, b.ID, 90))
+
+	resumedID, msg := next()
+	resumed, err := notifier.ParseID(resumedID)
+	assert.NoError(t, err)
+	assert.Equal(t, first.Seq+1, resumed.Seq)
+	assert.Equal(t, int32(2), msg.GetStandings()[0].GetWins())
+
+	// A client that missed that change gets the standings straight away.
+	missedID, msg := watchSSE(t, svc, id)()
+	assert.Equal(t, resumedID, missedID)
+	assert.Equal(t, int32(2), msg.GetStandings()[0].GetWins())
+}
+
+func TestWatchStandings_SSE_InvalidMessage(t *testing.T) {
+	svc, _, _, _ := newTestServer(t)
+
+	path, h := cribblyv1sse.NewStandingsServiceWatchStandingsSSEHandler(SSE{svc})
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + path + "?message=nope")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,527 @@
+package tournamentconnect
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	cribblyv1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
+	"github.com/cszczepaniak/cribbly/internal/notifier"
+	"github.com/cszczepaniak/cribbly/internal/server/middleware"
+	tournamentservice "github.com/cszczepaniak/cribbly/internal/service/tournament"
+	"github.com/cszczepaniak/cribbly/internal/sse"
+)
+
+type Server struct {
+	TournamentService  tournamentservice.Service
+	TournamentNotifier *tournamentservice.Notifier
+}
+
+func requireAdmin(ctx context.Context) error {
+	if !middleware.IsAdmin(ctx) {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("must be an admin"))
+	}
+	return nil
+}
+
+// toConnectError maps the tournament service's errors onto Connect codes.
+func toConnectError(err error) error {
+	switch {
+	case errors.Is(err, tournamentservice.ErrInvalidSize),
+		errors.Is(err, tournamentservice.ErrInvalidDoubleEliminationSize),
+		errors.Is(err, tournamentservice.ErrThirdPlaceTooSmall),
+		errors.Is(err, tournamentservice.ErrInvalidSeriesLength),
+		errors.Is(err, tournamentservice.ErrInvalidScore),
+		errors.Is(err, tournamentservice.ErrTeamNotInGame),
+		errors.Is(err, tournamentservice.ErrTeamIsNotWinner):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, tournamentservice.ErrGameNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, tournamentservice.ErrNotEnoughTeams),
+		errors.Is(err, tournamentservice.ErrAlreadySeeded),
+		errors.Is(err, tournamentservice.ErrGameNotReady),
+		errors.Is(err, tournamentservice.ErrGameDecided),
+		errors.Is(err, tournamentservice.ErrNotFurthestGame),
+		errors.Is(err, tournamentservice.ErrByeGame),
+		errors.Is(err, tournamentservice.ErrSeriesGame),
+		errors.Is(err, tournamentservice.ErrSeriesStarted),
+		errors.Is(err, tournamentservice.ErrNoSeriesGames):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+func teamToProto(t tournamentservice.Team) *cribblyv1.BracketTeam {
+	if t.ID == "" {
+		return nil
+	}
+	return &cribblyv1.BracketTeam{
+		Id:   t.ID,
+		Name: t.Name,
+	}
+}
+
+func sideToProto(s tournamentservice.Side) cribblyv1.BracketSide {
+	switch s {
+	case tournamentservice.SideLosers:
+		return cribblyv1.BracketSide_BRACKET_SIDE_LOSERS
+	case tournamentservice.SideFinal:
+		return cribblyv1.BracketSide_BRACKET_SIDE_FINAL
+	case tournamentservice.SideThirdPlace:
+		return cribblyv1.BracketSide_BRACKET_SIDE_THIRD_PLACE
+	default:
+		return cribblyv1.BracketSide_BRACKET_SIDE_WINNERS
+	}
+}
+
+func sideFromProto(s cribblyv1.BracketSide) tournamentservice.Side {
+	switch s {
+	case cribblyv1.BracketSide_BRACKET_SIDE_LOSERS:
+		return tournamentservice.SideLosers
+	case cribblyv1.BracketSide_BRACKET_SIDE_FINAL:
+		return tournamentservice.SideFinal
+	case cribblyv1.BracketSide_BRACKET_SIDE_THIRD_PLACE:
+		return tournamentservice.SideThirdPlace
+	default:
+		return tournamentservice.SideWinners
+	}
+}
+
+func gameToProto(g tournamentservice.Game) *cribblyv1.BracketGame {
+	series := make([]*cribblyv1.SeriesGame, 0, len(g.Series))
+	for _, sg := range g.Series {
+		series = append(series, &cribblyv1.SeriesGame{
+			Team1Score: int32(sg.Scores[0]),
+			Team2Score: int32(sg.Scores[1]),
+		})
+	}
+
+	reports := make([]*cribblyv1.BracketReport, 0, len(g.Reports))
+	for _, r := range g.Reports {
+		reports = append(reports, &cribblyv1.BracketReport{
+			Team:       teamToProto(r.Team),
+			Won:        r.Won,
+			LoserScore: int32(r.LoserScore),
+		})
+	}
+
+	return &cribblyv1.BracketGame{
+		Round:   int32(g.Round),
+		Idx:     int32(g.Idx),
+		Team1:   teamToProto(g.Teams[0]),
+		Team2:   teamToProto(g.Teams[1]),
+		Winner:  teamToProto(g.Winner),
+		Bye:     g.Bye,
+		Side:    sideToProto(g.Side),
+		BestOf:  int32(g.BestOf),
+		Series:  series,
+		Reports: reports,
+	}
+}
+
+func roundsToProto(rs []tournamentservice.Round) []*cribblyv1.BracketRound {
+	rounds := make([]*cribblyv1.BracketRound, 0, len(rs))
+	for _, r := range rs {
+		gs := make([]*cribblyv1.BracketGame, 0, len(r.Games))
+		for _, g := range r.Games {
+			gs = append(gs, gameToProto(g))
+		}
+		rounds = append(rounds, &cribblyv1.BracketRound{Games: gs})
+	}
+	return rounds
+}
+
+func bracketToProto(b tournamentservice.Bracket) *cribblyv1.Bracket {
+	format := cribblyv1.BracketFormat_BRACKET_FORMAT_SINGLE_ELIMINATION
+	if b.Format == tournamentservice.FormatDoubleElimination {
+		format = cribblyv1.BracketFormat_BRACKET_FORMAT_DOUBLE_ELIMINATION
+	}
+
+	res := &cribblyv1.Bracket{
+		Rounds:       roundsToProto(b.Rounds),
+		TeamCount:    int32(b.TeamCount),
+		Champion:     teamToProto(b.Champion()),
+		Format:       format,
+		LosersRounds: roundsToProto(b.Losers),
+		FinalRounds:  roundsToProto(b.Final),
+	}
+	if b.ThirdPlace != nil {
+		res.ThirdPlace = gameToProto(*b.ThirdPlace)
+	}
+	return res
+}
+
+func teamFromProto(t *cribblyv1.BracketTeam) tournamentservice.Team {
+	return tournamentservice.Team{
+		ID:   t.GetId(),
+		Name: t.GetName(),
+	}
+}
+
+func gameFromProto(g *cribblyv1.BracketGame) tournamentservice.Game {
+	var series []tournamentservice.SeriesGame
+	for _, sg := range g.GetSeries() {
+		series = append(series, tournamentservice.SeriesGame{
+			Scores: [2]int{int(sg.GetTeam1Score()), int(sg.GetTeam2Score())},
+		})
+	}
+
+	var reports []tournamentservice.Report
+	for _, r := range g.GetReports() {
+		reports = append(reports, tournamentservice.Report{
+			Team:       teamFromProto(r.GetTeam()),
+			Won:        r.GetWon(),
+			LoserScore: int(r.GetLoserScore()),
+		})
+	}
+
+	return tournamentservice.Game{
+		Side:    sideFromProto(g.GetSide()),
+		Round:   int(g.GetRound()),
+		Idx:     int(g.GetIdx()),
+		Teams:   [2]tournamentservice.Team{teamFromProto(g.GetTeam1()), teamFromProto(g.GetTeam2())},
+		Winner:  teamFromProto(g.GetWinner()),
+		Bye:     g.GetBye(),
+		BestOf:  int(g.GetBestOf()),
+		Series:  series,
+		Reports: reports,
+	}
+}
+
+func roundsFromProto(rs []*cribblyv1.BracketRound) []tournamentservice.Round {
+	var rounds []tournamentservice.Round
+	for _, r := range rs {
+		var gs []tournamentservice.Game
+		for _, g := range r.GetGames() {
+			gs = append(gs, gameFromProto(g))
+		}
+		rounds = append(rounds, tournamentservice.Round{Games: gs})
+	}
+	return rounds
+}
+
+// BracketFromProto is the inverse of the mapping GetBracket uses, for pages that render the bracket
+// from the API's messages. Reports don't say who made them, so their Reporter is empty.
+func BracketFromProto(b *cribblyv1.Bracket) tournamentservice.Bracket {
+	format := tournamentservice.FormatSingleElimination
+	if b.GetFormat() == cribblyv1.BracketFormat_BRACKET_FORMAT_DOUBLE_ELIMINATION {
+		format = tournamentservice.FormatDoubleElimination
+	}
+
+	res := tournamentservice.Bracket{
+		Format:    format,
+		Rounds:    roundsFromProto(b.GetRounds()),
+		Losers:    roundsFromProto(b.GetLosersRounds()),
+		Final:     roundsFromProto(b.GetFinalRounds()),
+		TeamCount: int(b.GetTeamCount()),
+	}
+	if b.ThirdPlace != nil {
+		g := gameFromProto(b.ThirdPlace)
+		res.ThirdPlace = &g
+	}
+	return res
+}
+
+func (s *Server) getBracket(ctx context.Context) (*cribblyv1.Bracket, error) {
+	b, err := s.TournamentService.Get(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return bracketToProto(b), nil
+}
+
+func (s *Server) GetBracket(
+	ctx context.Context,
+	_ *connect.Request[cribblyv1.GetBracketRequest],
+) (*connect.Response[cribblyv1.GetBracketResponse], error) {
+	b, err := s.getBracket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&cribblyv1.GetBracketResponse{Bracket: b}), nil
+}
+
+func (s *Server) SeedBracket(
+	ctx context.Context,
+	req *connect.Request[cribblyv1.SeedBracketRequest],
+) (*connect.Response[cribblyv1.SeedBracketResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var err error
+	size := int(req.Msg.GetSize())
+	if req.Msg.GetFormat() == cribblyv1.BracketFormat_BRACKET_FORMAT_DOUBLE_ELIMINATION {
+		err = s.TournamentService.SeedDoubleElimination(ctx, size)
+	} else {
+		err = s.TournamentService.Seed(ctx, size, req.Msg.GetThirdPlace())
+	}
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	b, err := s.getBracket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&cribblyv1.SeedBracketResponse{Bracket: b}), nil
+}
+
+func (s *Server) AdvanceTeam(
+	ctx context.Context,
+	req *connect.Request[cribblyv1.AdvanceTeamRequest],
+) (*connect.Response[cribblyv1.AdvanceTeamResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	teamID := strings.TrimSpace(req.Msg.GetTeamId())
+	if teamID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("team_id is required"))
+	}
+
+	err := s.TournamentService.Advance(
+		ctx,
+		sideFromProto(req.Msg.GetSide()),
+		int(req.Msg.GetRound()),
+		int(req.Msg.GetIdx()),
+		teamID,
+	)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	b, err := s.getBracket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&cribblyv1.AdvanceTeamResponse{Bracket: b}), nil
+}
+
+func (s *Server) RevertAdvance(
+	ctx context.Context,
+	req *connect.Request[cribblyv1.RevertAdvanceRequest],
+) (*connect.Response[cribblyv1.RevertAdvanceResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	teamID := strings.TrimSpace(req.Msg.GetTeamId())
+	if teamID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("team_id is required"))
+	}
+
+	err := s.TournamentService.Revert(
+		ctx,
+		sideFromProto(req.Msg.GetSide()),
+		int(req.Msg.GetRound()),
+		int(req.Msg.GetIdx()),
+		teamID,
+	)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	b, err := s.getBracket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&cribblyv1.RevertAdvanceResponse{Bracket: b}), nil
+}
+
+func (s *Server) DeleteBracket(
+	ctx context.Context,
+	_ *connect.Request[cribblyv1.DeleteBracketRequest],
+) (*connect.Response[cribblyv1.DeleteBracketResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.TournamentService.Delete(ctx); err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&cribblyv1.DeleteBracketResponse{}), nil
+}
+
+func (s *Server) SetSeriesLength(
+	ctx context.Context,
+	req *connect.Request[cribblyv1.SetSeriesLengthRequest],
+) (*connect.Response[cribblyv1.SetSeriesLengthResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	err := s.TournamentService.SetSeriesLength(
+		ctx,
+		sideFromProto(req.Msg.GetSide()),
+		int(req.Msg.GetRound()),
+		int(req.Msg.GetIdx()),
+		int(req.Msg.GetBestOf()),
+	)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	b, err := s.getBracket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&cribblyv1.SetSeriesLengthResponse{Bracket: b}), nil
+}
+
+func (s *Server) RecordSeriesGame(
+	ctx context.Context,
+	req *connect.Request[cribblyv1.RecordSeriesGameRequest],
+) (*connect.Response[cribblyv1.RecordSeriesGameResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	winnerID := strings.TrimSpace(req.Msg.GetWinnerTeamId())
+	if winnerID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("winner_team_id is required"))
+	}
+
+	err := s.TournamentService.RecordSeriesGame(
+		ctx,
+		sideFromProto(req.Msg.GetSide()),
+		int(req.Msg.GetRound()),
+		int(req.Msg.GetIdx()),
+		winnerID,
+		int(req.Msg.GetLoserScore()),
+	)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	b, err := s.getBracket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&cribblyv1.RecordSeriesGameResponse{Bracket: b}), nil
+}
+
+func (s *Server) UndoSeriesGame(
+	ctx context.Context,
+	req *connect.Request[cribblyv1.UndoSeriesGameRequest],
+) (*connect.Response[cribblyv1.UndoSeriesGameResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	err := s.TournamentService.UndoSeriesGame(
+		ctx,
+		sideFromProto(req.Msg.GetSide()),
+		int(req.Msg.GetRound()),
+		int(req.Msg.GetIdx()),
+	)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	b, err := s.getBracket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&cribblyv1.UndoSeriesGameResponse{Bracket: b}), nil
+}
+
+func (s *Server) WatchBracket(
+	ctx context.Context,
+	_ *connect.Request[cribblyv1.WatchBracketRequest],
+	stream *connect.ServerStream[cribblyv1.WatchBracketResponse],
+) error {
+	return s.Watch(ctx, notifier.ID{}, func(res *cribblyv1.WatchBracketResponse, _ notifier.ID) error {
+		return stream.Send(res)
+	})
+}
+
+// SSE serves WatchBracket as a server-sent event stream for clients that can't use Connect
+// streaming, such as a plain EventSource. Each event carries the ID of the change it reflects, so
+// a client that reconnects only gets the bracket again if it missed one.
+type SSE struct {
+	*Server
+}
+
+func (s SSE) WatchBracket(
+	ctx context.Context,
+	_ *cribblyv1.WatchBracketRequest,
+	stream *sse.Stream[*cribblyv1.WatchBracketResponse],
+) error {
+	// A missing or foreign ID is the zero ID, which always gets the bracket first.
+	lastID, _ := notifier.ParseID(stream.LastEventID())
+	return s.Watch(ctx, lastID, func(res *cribblyv1.WatchBracketResponse, id notifier.ID) error {
+		return stream.SendWithID(id.String(), res)
+	})
+}
+
+// Watch sends the bracket, then sends it again every time it changes, until ctx is done. Each send
+// carries the ID of the latest change it reflects.
+//
+// lastID is the last change a reconnecting client saw. The bracket is sent first only if the
+// client missed a change since then, or if lastID is too old to tell (such as the zero ID).
+func (s *Server) Watch(
+	ctx context.Context,
+	lastID notifier.ID,
+	send func(*cribblyv1.WatchBracketResponse, notifier.ID) error,
+) error {
+	topic, err := s.TournamentService.Topic(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	// Subscribe before reading the bracket so that no change between the two is missed.
+	sub, done := s.TournamentNotifier.Subscribe(topic)
+	defer done()
+
+	sendBracket := func(id notifier.ID) error {
+		b, err := s.getBracket(ctx)
+		if err != nil {
+			return err
+		}
+		return send(&cribblyv1.WatchBracketResponse{Bracket: b}, id)
+	}
+
+	// The bracket is always sent whole, so any number of missed changes is a single send.
+	lastSent := lastID
+	missed, ok := s.TournamentNotifier.Since(lastID, topic)
+	switch {
+	case !ok:
+		lastSent = s.TournamentNotifier.LastID()
+		err = sendBracket(lastSent)
+	case len(missed) > 0:
+		lastSent = missed[len(missed)-1].ID
+		err = sendBracket(lastSent)
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-sub:
+			// Already covered by the first send.
+			if ev.ID.Seq <= lastSent.Seq {
+				continue
+			}
+			lastSent = ev.ID
+
+			if err := sendBracket(ev.ID); err != nil {
+				return err
+			}
+		}
+	}
+}
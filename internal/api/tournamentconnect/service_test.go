@@ -0,0 +1,247 @@
+package tournamentconnect
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/cszczepaniak/gotest/assert"
+
+	cribblyv1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
+	cribblyv1connect "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1/cribblyv1connect"
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	"github.com/cszczepaniak/cribbly/internal/server/middleware"
+	tournamentservice "github.com/cszczepaniak/cribbly/internal/service/tournament"
+)
+
+// newTestServer returns a server for an event with two teams; team A finished the prelims ahead of
+// team B.
+func newTestServer(t *testing.T) (*Server, teams.Team, teams.Team) {
+	t.Helper()
+
+	db := database.NewInMemory(t)
+	tr := teams.NewRepository(db)
+	gr := games.NewRepository(db, &games.ScoreNotifier{})
+
+	a, err := tr.Create(t.Context(), "A")
+	assert.NoError(t, err)
+	b, err := tr.Create(t.Context(), "B")
+	assert.NoError(t, err)
+
+	gameID, err := gr.Create(t.Context(), a.ID, b.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, gr.UpdateScores(t.Context(), gameID, a.ID, 121, b.ID, 100))
+
+	n := &tournamentservice.Notifier{}
+	return &Server{
+		TournamentService:  tournamentservice.New(database.NewTransactor(db), gr, tr, n),
+		TournamentNotifier: n,
+	}, a, b
+}
+
+func assertConnectCode(t *testing.T, err error, want connect.Code) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		t.Fatalf("expected *connect.Error, got %T: %v", err, err)
+	}
+	assert.Equal(t, want, connectErr.Code())
+}
+
+func TestGetBracket_NotSeeded(t *testing.T) {
+	svc, _, _ := newTestServer(t)
+
+	resp, err := svc.GetBracket(t.Context(), connect.NewRequest(&cribblyv1.GetBracketRequest{}))
+	assert.NoError(t, err)
+	assert.SliceLen(t, resp.Msg.GetBracket().GetRounds(), 0)
+	assert.Equal(t, int32(2), resp.Msg.GetBracket().GetTeamCount())
+}
+
+func TestMutations_NotAdmin(t *testing.T) {
+	svc, a, _ := newTestServer(t)
+
+	_, err := svc.SeedBracket(t.Context(), connect.NewRequest(&cribblyv1.SeedBracketRequest{Size: 2}))
+	assertConnectCode(t, err, connect.CodePermissionDenied)
+
+	_, err = svc.AdvanceTeam(t.Context(), connect.NewRequest(&cribblyv1.AdvanceTeamRequest{TeamId: a.ID}))
+	assertConnectCode(t, err, connect.CodePermissionDenied)
+
+	_, err = svc.RevertAdvance(t.Context(), connect.NewRequest(&cribblyv1.RevertAdvanceRequest{TeamId: a.ID}))
+	assertConnectCode(t, err, connect.CodePermissionDenied)
+
+	_, err = svc.DeleteBracket(t.Context(), connect.NewRequest(&cribblyv1.DeleteBracketRequest{}))
+	assertConnectCode(t, err, connect.CodePermissionDenied)
+}
+
+func TestSeedBracket_InvalidSize(t *testing.T) {
+	svc, _, _ := newTestServer(t)
+	ctx := middleware.WithDevAdminContext(t.Context())
+
+	_, err := svc.SeedBracket(ctx, connect.NewRequest(&cribblyv1.SeedBracketRequest{Size: 1}))
+	assertConnectCode(t, err, connect.CodeInvalidArgument)
+
+	_, err = svc.SeedBracket(ctx, connect.NewRequest(&cribblyv1.SeedBracketRequest{
+		Size:   2,
+		Format: cribblyv1.BracketFormat_BRACKET_FORMAT_DOUBLE_ELIMINATION,
+	}))
+	assertConnectCode(t, err, connect.CodeInvalidArgument)
+
+	_, err = svc.SeedBracket(ctx, connect.NewRequest(&cribblyv1.SeedBracketRequest{Size: 2, ThirdPlace: true}))
+	assertConnectCode(t, err, connect.CodeInvalidArgument)
+
+	_, err = svc.SeedBracket(ctx, connect.NewRequest(&cribblyv1.SeedBracketRequest{Size: 4}))
+	assertConnectCode(t, err, connect.CodeFailedPrecondition)
+}
+
+func TestAdvanceAndRevert(t *testing.T) {
+	svc, a, b := newTestServer(t)
+	ctx := middleware.WithDevAdminContext(t.Context())
+
+	seeded, err := svc.SeedBracket(ctx, connect.NewRequest(&cribblyv1.SeedBracketRequest{Size: 2}))
+	assert.NoError(t, err)
+	final := seeded.Msg.GetBracket().GetRounds()[0].GetGames()[0]
+	assert.Equal(t, a.ID, final.GetTeam1().GetId())
+	assert.Equal(t, "B", final.GetTeam2().GetName())
+	assert.Equal(t, (*cribblyv1.BracketTeam)(nil), final.GetWinner())
+
+	_, err = svc.AdvanceTeam(ctx, connect.NewRequest(&cribblyv1.AdvanceTeamRequest{Round: 0, Idx: 1, TeamId: b.ID}))
+	assertConnectCode(t, err, connect.CodeNotFound)
+
+	advanced, err := svc.AdvanceTeam(ctx, connect.NewRequest(&cribblyv1.AdvanceTeamRequest{Round: 0, Idx: 0, TeamId: b.ID}))
+	assert.NoError(t, err)
+	assert.Equal(t, "B", advanced.Msg.GetBracket().GetChampion().GetName())
+
+	_, err = svc.RevertAdvance(ctx, connect.NewRequest(&cribblyv1.RevertAdvanceRequest{Round: 0, Idx: 0, TeamId: a.ID}))
+	assertConnectCode(t, err, connect.CodeInvalidArgument)
+
+	reverted, err := svc.RevertAdvance(ctx, connect.NewRequest(&cribblyv1.RevertAdvanceRequest{Round: 0, Idx: 0, TeamId: b.ID}))
+	assert.NoError(t, err)
+	assert.Equal(t, (*cribblyv1.BracketTeam)(nil), reverted.Msg.GetBracket().GetChampion())
+
+	_, err = svc.DeleteBracket(ctx, connect.NewRequest(&cribblyv1.DeleteBracketRequest{}))
+	assert.NoError(t, err)
+
+	resp, err := svc.GetBracket(ctx, connect.NewRequest(&cribblyv1.GetBracketRequest{}))
+	assert.NoError(t, err)
+	assert.SliceLen(t, resp.Msg.GetBracket().GetRounds(), 0)
+}
+
+func TestWatchBracket_SendsUpdates(t *testing.T) {
+	svc, a, _ := newTestServer(t)
+
+	_, h := cribblyv1connect.NewTournamentServiceHandler(svc)
+	ts := httptest.NewServer(http.StripPrefix("/api", h))
+	defer ts.Close()
+
+	client := cribblyv1connect.NewTournamentServiceClient(http.DefaultClient, ts.URL+"/api")
+	stream, err := client.WatchBracket(t.Context(), connect.NewRequest(&cribblyv1.WatchBracketRequest{}))
+	assert.NoError(t, err)
+	defer stream.Close()
+
+	// The current bracket is sent immediately.
+	assert.Equal(t, true, stream.Receive())
+	assert.SliceLen(t, stream.Msg().GetBracket().GetRounds(), 0)
+
+	ctx := middleware.WithDevAdminContext(t.Context())
+	_, err = svc.SeedBracket(ctx, connect.NewRequest(&cribblyv1.SeedBracketRequest{Size: 2}))
+	assert.NoError(t, err)
+
+	assert.Equal(t, true, stream.Receive())
+	rounds := stream.Msg().GetBracket().GetRounds()
+	assert.SliceLen(t, rounds, 1)
+	assert.Equal(t, a.ID, rounds[0].GetGames()[0].GetTeam1().GetId())
+}
+
+func TestSeries(t *testing.T) {
+	svc, a, b := newTestServer(t)
+	ctx := middleware.WithDevAdminContext(t.Context())
+
+	_, err := svc.SeedBracket(ctx, connect.NewRequest(&cribblyv1.SeedBracketRequest{Size: 2}))
+	assert.NoError(t, err)
+
+	_, err = svc.SetSeriesLength(ctx, connect.NewRequest(&cribblyv1.SetSeriesLengthRequest{BestOf: 4}))
+	assertConnectCode(t, err, connect.CodeInvalidArgument)
+
+	set, err := svc.SetSeriesLength(ctx, connect.NewRequest(&cribblyv1.SetSeriesLengthRequest{BestOf: 3}))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), set.Msg.GetBracket().GetRounds()[0].GetGames()[0].GetBestOf())
+
+	_, err = svc.AdvanceTeam(ctx, connect.NewRequest(&cribblyv1.AdvanceTeamRequest{TeamId: a.ID}))
+	assertConnectCode(t, err, connect.CodeFailedPrecondition)
+
+	_, err = svc.RecordSeriesGame(ctx, connect.NewRequest(&cribblyv1.RecordSeriesGameRequest{
+		WinnerTeamId: b.ID,
+		LoserScore:   100,
+	}))
+	assert.NoError(t, err)
+	recorded, err := svc.RecordSeriesGame(ctx, connect.NewRequest(&cribblyv1.RecordSeriesGameRequest{
+		WinnerTeamId: b.ID,
+		LoserScore:   95,
+	}))
+	assert.NoError(t, err)
+
+	final := recorded.Msg.GetBracket().GetRounds()[0].GetGames()[0]
+	assert.Equal(t, "B", recorded.Msg.GetBracket().GetChampion().GetName())
+	assert.SliceLen(t, final.GetSeries(), 2)
+	assert.Equal(t, int32(95), final.GetSeries()[1].GetTeam1Score())
+	assert.Equal(t, int32(121), final.GetSeries()[1].GetTeam2Score())
+
+	undone, err := svc.UndoSeriesGame(ctx, connect.NewRequest(&cribblyv1.UndoSeriesGameRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, (*cribblyv1.BracketTeam)(nil), undone.Msg.GetBracket().GetChampion())
+	assert.SliceLen(t, undone.Msg.GetBracket().GetRounds()[0].GetGames()[0].GetSeries(), 1)
+}
+
+func TestBracketFromProto_RoundTrips(t *testing.T) {
+	a := tournamentservice.Team{ID: "a", Name: "A"}
+	b := tournamentservice.Team{ID: "b", Name: "B"}
+	c := tournamentservice.Team{ID: "c", Name: "C"}
+
+	brackets := []tournamentservice.Bracket{
+		{},
+		{
+			Format: tournamentservice.FormatSingleElimination,
+			Rounds: []tournamentservice.Round{
+				{Games: []tournamentservice.Game{
+					{Side: tournamentservice.SideWinners, Teams: [2]tournamentservice.Team{a, b}, Winner: a, BestOf: 3, Series: []tournamentservice.SeriesGame{
+						{Scores: [2]int{121, 90}},
+						{Scores: [2]int{121, 101}},
+					}},
+					{Side: tournamentservice.SideWinners, Idx: 1, Teams: [2]tournamentservice.Team{c}, Winner: c, Bye: true, BestOf: 1},
+				}},
+				{Games: []tournamentservice.Game{
+					{Side: tournamentservice.SideWinners, Round: 1, Teams: [2]tournamentservice.Team{a, c}, BestOf: 1, Reports: []tournamentservice.Report{
+						{Team: a, Won: true, LoserScore: 95},
+						{Team: c, Won: true, LoserScore: 110},
+					}},
+				}},
+			},
+			ThirdPlace: &tournamentservice.Game{Side: tournamentservice.SideThirdPlace, Teams: [2]tournamentservice.Team{b}, BestOf: 1},
+			TeamCount:  3,
+		},
+		{
+			Format: tournamentservice.FormatDoubleElimination,
+			Rounds: []tournamentservice.Round{
+				{Games: []tournamentservice.Game{{Side: tournamentservice.SideWinners, Teams: [2]tournamentservice.Team{a, b}, Winner: a, BestOf: 1}}},
+			},
+			Losers: []tournamentservice.Round{
+				{Games: []tournamentservice.Game{{Side: tournamentservice.SideLosers, Teams: [2]tournamentservice.Team{b}, BestOf: 1}}},
+			},
+			Final: []tournamentservice.Round{
+				{Games: []tournamentservice.Game{{Side: tournamentservice.SideFinal, Teams: [2]tournamentservice.Team{a}, BestOf: 1}}},
+				{Games: []tournamentservice.Game{{Side: tournamentservice.SideFinal, Round: 1, BestOf: 1}}},
+			},
+			TeamCount: 2,
+		},
+	}
+	for _, br := range brackets {
+		assert.Equal(t, br, BracketFromProto(bracketToProto(br)))
+	}
+}
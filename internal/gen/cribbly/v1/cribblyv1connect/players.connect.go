@@ -51,6 +51,9 @@ const (
 	// PlayerServiceGenerateRandomPlayersProcedure is the fully-qualified name of the PlayerService's
 	// GenerateRandomPlayers RPC.
 	PlayerServiceGenerateRandomPlayersProcedure = "/cribbly.v1.PlayerService/GenerateRandomPlayers"
+	// PlayerServiceGetPlayerHistoryProcedure is the fully-qualified name of the PlayerService's
+	// GetPlayerHistory RPC.
+	PlayerServiceGetPlayerHistoryProcedure = "/cribbly.v1.PlayerService/GetPlayerHistory"
 )
 
 // PlayerServiceClient is a client for the cribbly.v1.PlayerService service.
@@ -61,6 +64,8 @@ type PlayerServiceClient interface {
 	DeletePlayer(context.Context, *connect.Request[v1.DeletePlayerRequest]) (*connect.Response[v1.DeletePlayerResponse], error)
 	DeleteAllPlayers(context.Context, *connect.Request[v1.DeleteAllPlayersRequest]) (*connect.Response[v1.DeleteAllPlayersResponse], error)
 	GenerateRandomPlayers(context.Context, *connect.Request[v1.GenerateRandomPlayersRequest]) (*connect.Response[v1.GenerateRandomPlayersResponse], error)
+	// A person's teams, finishes and records in every event they've played in.
+	GetPlayerHistory(context.Context, *connect.Request[v1.GetPlayerHistoryRequest]) (*connect.Response[v1.GetPlayerHistoryResponse], error)
 }
 
 // NewPlayerServiceClient constructs a client for the cribbly.v1.PlayerService service. By default,
@@ -110,6 +115,12 @@ func NewPlayerServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(playerServiceMethods.ByName("GenerateRandomPlayers")),
 			connect.WithClientOptions(opts...),
 		),
+		getPlayerHistory: connect.NewClient[v1.GetPlayerHistoryRequest, v1.GetPlayerHistoryResponse](
+			httpClient,
+			baseURL+PlayerServiceGetPlayerHistoryProcedure,
+			connect.WithSchema(playerServiceMethods.ByName("GetPlayerHistory")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -121,6 +132,7 @@ type playerServiceClient struct {
 	deletePlayer          *connect.Client[v1.DeletePlayerRequest, v1.DeletePlayerResponse]
 	deleteAllPlayers      *connect.Client[v1.DeleteAllPlayersRequest, v1.DeleteAllPlayersResponse]
 	generateRandomPlayers *connect.Client[v1.GenerateRandomPlayersRequest, v1.GenerateRandomPlayersResponse]
+	getPlayerHistory      *connect.Client[v1.GetPlayerHistoryRequest, v1.GetPlayerHistoryResponse]
 }
 
 // ListPlayers calls cribbly.v1.PlayerService.ListPlayers.
@@ -153,6 +165,11 @@ func (c *playerServiceClient) GenerateRandomPlayers(ctx context.Context, req *co
 	return c.generateRandomPlayers.CallUnary(ctx, req)
 }
 
+// GetPlayerHistory calls cribbly.v1.PlayerService.GetPlayerHistory.
+func (c *playerServiceClient) GetPlayerHistory(ctx context.Context, req *connect.Request[v1.GetPlayerHistoryRequest]) (*connect.Response[v1.GetPlayerHistoryResponse], error) {
+	return c.getPlayerHistory.CallUnary(ctx, req)
+}
+
 // PlayerServiceHandler is an implementation of the cribbly.v1.PlayerService service.
 type PlayerServiceHandler interface {
 	ListPlayers(context.Context, *connect.Request[v1.ListPlayersRequest]) (*connect.Response[v1.ListPlayersResponse], error)
@@ -161,6 +178,8 @@ type PlayerServiceHandler interface {
 	DeletePlayer(context.Context, *connect.Request[v1.DeletePlayerRequest]) (*connect.Response[v1.DeletePlayerResponse], error)
 	DeleteAllPlayers(context.Context, *connect.Request[v1.DeleteAllPlayersRequest]) (*connect.Response[v1.DeleteAllPlayersResponse], error)
 	GenerateRandomPlayers(context.Context, *connect.Request[v1.GenerateRandomPlayersRequest]) (*connect.Response[v1.GenerateRandomPlayersResponse], error)
+	// A person's teams, finishes and records in every event they've played in.
+	GetPlayerHistory(context.Context, *connect.Request[v1.GetPlayerHistoryRequest]) (*connect.Response[v1.GetPlayerHistoryResponse], error)
 }
 
 // NewPlayerServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -206,6 +225,12 @@ func NewPlayerServiceHandler(svc PlayerServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(playerServiceMethods.ByName("GenerateRandomPlayers")),
 		connect.WithHandlerOptions(opts...),
 	)
+	playerServiceGetPlayerHistoryHandler := connect.NewUnaryHandler(
+		PlayerServiceGetPlayerHistoryProcedure,
+		svc.GetPlayerHistory,
+		connect.WithSchema(playerServiceMethods.ByName("GetPlayerHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/cribbly.v1.PlayerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case PlayerServiceListPlayersProcedure:
@@ -220,6 +245,8 @@ func NewPlayerServiceHandler(svc PlayerServiceHandler, opts ...connect.HandlerOp
 			playerServiceDeleteAllPlayersHandler.ServeHTTP(w, r)
 		case PlayerServiceGenerateRandomPlayersProcedure:
 			playerServiceGenerateRandomPlayersHandler.ServeHTTP(w, r)
+		case PlayerServiceGetPlayerHistoryProcedure:
+			playerServiceGetPlayerHistoryHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -252,3 +279,7 @@ func (UnimplementedPlayerServiceHandler) DeleteAllPlayers(context.Context, *conn
 func (UnimplementedPlayerServiceHandler) GenerateRandomPlayers(context.Context, *connect.Request[v1.GenerateRandomPlayersRequest]) (*connect.Response[v1.GenerateRandomPlayersResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.PlayerService.GenerateRandomPlayers is not implemented"))
 }
+
+func (UnimplementedPlayerServiceHandler) GetPlayerHistory(context.Context, *connect.Request[v1.GetPlayerHistoryRequest]) (*connect.Response[v1.GetPlayerHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.PlayerService.GetPlayerHistory is not implemented"))
+}
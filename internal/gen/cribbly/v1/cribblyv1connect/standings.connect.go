@@ -0,0 +1,140 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: cribbly/v1/standings.proto
+
+package cribblyv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// StandingsServiceName is the fully-qualified name of the StandingsService service.
+	StandingsServiceName = "cribbly.v1.StandingsService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// StandingsServiceGetStandingsProcedure is the fully-qualified name of the StandingsService's
+	// GetStandings RPC.
+	StandingsServiceGetStandingsProcedure = "/cribbly.v1.StandingsService/GetStandings"
+	// StandingsServiceWatchStandingsProcedure is the fully-qualified name of the StandingsService's
+	// WatchStandings RPC.
+	StandingsServiceWatchStandingsProcedure = "/cribbly.v1.StandingsService/WatchStandings"
+)
+
+// StandingsServiceClient is a client for the cribbly.v1.StandingsService service.
+type StandingsServiceClient interface {
+	GetStandings(context.Context, *connect.Request[v1.GetStandingsRequest]) (*connect.Response[v1.GetStandingsResponse], error)
+	// Sends the current standings, then the full standings again every time a score changes.
+	WatchStandings(context.Context, *connect.Request[v1.WatchStandingsRequest]) (*connect.ServerStreamForClient[v1.WatchStandingsResponse], error)
+}
+
+// NewStandingsServiceClient constructs a client for the cribbly.v1.StandingsService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewStandingsServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) StandingsServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	standingsServiceMethods := v1.File_cribbly_v1_standings_proto.Services().ByName("StandingsService").Methods()
+	return &standingsServiceClient{
+		getStandings: connect.NewClient[v1.GetStandingsRequest, v1.GetStandingsResponse](
+			httpClient,
+			baseURL+StandingsServiceGetStandingsProcedure,
+			connect.WithSchema(standingsServiceMethods.ByName("GetStandings")),
+			connect.WithClientOptions(opts...),
+		),
+		watchStandings: connect.NewClient[v1.WatchStandingsRequest, v1.WatchStandingsResponse](
+			httpClient,
+			baseURL+StandingsServiceWatchStandingsProcedure,
+			connect.WithSchema(standingsServiceMethods.ByName("WatchStandings")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// standingsServiceClient implements StandingsServiceClient.
+type standingsServiceClient struct {
+	getStandings   *connect.Client[v1.GetStandingsRequest, v1.GetStandingsResponse]
+	watchStandings *connect.Client[v1.WatchStandingsRequest, v1.WatchStandingsResponse]
+}
+
+// GetStandings calls cribbly.v1.StandingsService.GetStandings.
+func (c *standingsServiceClient) GetStandings(ctx context.Context, req *connect.Request[v1.GetStandingsRequest]) (*connect.Response[v1.GetStandingsResponse], error) {
+	return c.getStandings.CallUnary(ctx, req)
+}
+
+// WatchStandings calls cribbly.v1.StandingsService.WatchStandings.
+func (c *standingsServiceClient) WatchStandings(ctx context.Context, req *connect.Request[v1.WatchStandingsRequest]) (*connect.ServerStreamForClient[v1.WatchStandingsResponse], error) {
+	return c.watchStandings.CallServerStream(ctx, req)
+}
+
+// StandingsServiceHandler is an implementation of the cribbly.v1.StandingsService service.
+type StandingsServiceHandler interface {
+	GetStandings(context.Context, *connect.Request[v1.GetStandingsRequest]) (*connect.Response[v1.GetStandingsResponse], error)
+	// Sends the current standings, then the full standings again every time a score changes.
+	WatchStandings(context.Context, *connect.Request[v1.WatchStandingsRequest], *connect.ServerStream[v1.WatchStandingsResponse]) error
+}
+
+// NewStandingsServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewStandingsServiceHandler(svc StandingsServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	standingsServiceMethods := v1.File_cribbly_v1_standings_proto.Services().ByName("StandingsService").Methods()
+	standingsServiceGetStandingsHandler := connect.NewUnaryHandler(
+		StandingsServiceGetStandingsProcedure,
+		svc.GetStandings,
+		connect.WithSchema(standingsServiceMethods.ByName("GetStandings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	standingsServiceWatchStandingsHandler := connect.NewServerStreamHandler(
+		StandingsServiceWatchStandingsProcedure,
+		svc.WatchStandings,
+		connect.WithSchema(standingsServiceMethods.ByName("WatchStandings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/cribbly.v1.StandingsService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case StandingsServiceGetStandingsProcedure:
+			standingsServiceGetStandingsHandler.ServeHTTP(w, r)
+		case StandingsServiceWatchStandingsProcedure:
+			standingsServiceWatchStandingsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedStandingsServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedStandingsServiceHandler struct{}
+
+func (UnimplementedStandingsServiceHandler) GetStandings(context.Context, *connect.Request[v1.GetStandingsRequest]) (*connect.Response[v1.GetStandingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.StandingsService.GetStandings is not implemented"))
+}
+
+func (UnimplementedStandingsServiceHandler) WatchStandings(context.Context, *connect.Request[v1.WatchStandingsRequest], *connect.ServerStream[v1.WatchStandingsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.StandingsService.WatchStandings is not implemented"))
+}
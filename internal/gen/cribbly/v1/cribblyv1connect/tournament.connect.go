@@ -0,0 +1,355 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: cribbly/v1/tournament.proto
+
+package cribblyv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TournamentServiceName is the fully-qualified name of the TournamentService service.
+	TournamentServiceName = "cribbly.v1.TournamentService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TournamentServiceGetBracketProcedure is the fully-qualified name of the TournamentService's
+	// GetBracket RPC.
+	TournamentServiceGetBracketProcedure = "/cribbly.v1.TournamentService/GetBracket"
+	// TournamentServiceSeedBracketProcedure is the fully-qualified name of the TournamentService's
+	// SeedBracket RPC.
+	TournamentServiceSeedBracketProcedure = "/cribbly.v1.TournamentService/SeedBracket"
+	// TournamentServiceAdvanceTeamProcedure is the fully-qualified name of the TournamentService's
+	// AdvanceTeam RPC.
+	TournamentServiceAdvanceTeamProcedure = "/cribbly.v1.TournamentService/AdvanceTeam"
+	// TournamentServiceRevertAdvanceProcedure is the fully-qualified name of the TournamentService's
+	// RevertAdvance RPC.
+	TournamentServiceRevertAdvanceProcedure = "/cribbly.v1.TournamentService/RevertAdvance"
+	// TournamentServiceDeleteBracketProcedure is the fully-qualified name of the TournamentService's
+	// DeleteBracket RPC.
+	TournamentServiceDeleteBracketProcedure = "/cribbly.v1.TournamentService/DeleteBracket"
+	// TournamentServiceSetSeriesLengthProcedure is the fully-qualified name of the TournamentService's
+	// SetSeriesLength RPC.
+	TournamentServiceSetSeriesLengthProcedure = "/cribbly.v1.TournamentService/SetSeriesLength"
+	// TournamentServiceRecordSeriesGameProcedure is the fully-qualified name of the TournamentService's
+	// RecordSeriesGame RPC.
+	TournamentServiceRecordSeriesGameProcedure = "/cribbly.v1.TournamentService/RecordSeriesGame"
+	// TournamentServiceUndoSeriesGameProcedure is the fully-qualified name of the TournamentService's
+	// UndoSeriesGame RPC.
+	TournamentServiceUndoSeriesGameProcedure = "/cribbly.v1.TournamentService/UndoSeriesGame"
+	// TournamentServiceWatchBracketProcedure is the fully-qualified name of the TournamentService's
+	// WatchBracket RPC.
+	TournamentServiceWatchBracketProcedure = "/cribbly.v1.TournamentService/WatchBracket"
+)
+
+// TournamentServiceClient is a client for the cribbly.v1.TournamentService service.
+type TournamentServiceClient interface {
+	GetBracket(context.Context, *connect.Request[v1.GetBracketRequest]) (*connect.Response[v1.GetBracketResponse], error)
+	// Seeds a new bracket from the prelim standings.
+	SeedBracket(context.Context, *connect.Request[v1.SeedBracketRequest]) (*connect.Response[v1.SeedBracketResponse], error)
+	// Records a team as the winner of a game and moves them into the next round.
+	AdvanceTeam(context.Context, *connect.Request[v1.AdvanceTeamRequest]) (*connect.Response[v1.AdvanceTeamResponse], error)
+	// Undoes AdvanceTeam. Only a team's furthest result can be reverted.
+	RevertAdvance(context.Context, *connect.Request[v1.RevertAdvanceRequest]) (*connect.Response[v1.RevertAdvanceResponse], error)
+	DeleteBracket(context.Context, *connect.Request[v1.DeleteBracketRequest]) (*connect.Response[v1.DeleteBracketResponse], error)
+	// Makes a game a best-of-N series. Can't be changed once a game of the series is recorded.
+	SetSeriesLength(context.Context, *connect.Request[v1.SetSeriesLengthRequest]) (*connect.Response[v1.SetSeriesLengthResponse], error)
+	// Records the next game of a series. The team that wins a majority of the games advances.
+	RecordSeriesGame(context.Context, *connect.Request[v1.RecordSeriesGameRequest]) (*connect.Response[v1.RecordSeriesGameResponse], error)
+	// Removes the last recorded game of a series, reverting the result if that game decided it.
+	UndoSeriesGame(context.Context, *connect.Request[v1.UndoSeriesGameRequest]) (*connect.Response[v1.UndoSeriesGameResponse], error)
+	// Sends the current bracket, then the whole bracket again every time it changes.
+	WatchBracket(context.Context, *connect.Request[v1.WatchBracketRequest]) (*connect.ServerStreamForClient[v1.WatchBracketResponse], error)
+}
+
+// NewTournamentServiceClient constructs a client for the cribbly.v1.TournamentService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTournamentServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TournamentServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	tournamentServiceMethods := v1.File_cribbly_v1_tournament_proto.Services().ByName("TournamentService").Methods()
+	return &tournamentServiceClient{
+		getBracket: connect.NewClient[v1.GetBracketRequest, v1.GetBracketResponse](
+			httpClient,
+			baseURL+TournamentServiceGetBracketProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("GetBracket")),
+			connect.WithClientOptions(opts...),
+		),
+		seedBracket: connect.NewClient[v1.SeedBracketRequest, v1.SeedBracketResponse](
+			httpClient,
+			baseURL+TournamentServiceSeedBracketProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("SeedBracket")),
+			connect.WithClientOptions(opts...),
+		),
+		advanceTeam: connect.NewClient[v1.AdvanceTeamRequest, v1.AdvanceTeamResponse](
+			httpClient,
+			baseURL+TournamentServiceAdvanceTeamProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("AdvanceTeam")),
+			connect.WithClientOptions(opts...),
+		),
+		revertAdvance: connect.NewClient[v1.RevertAdvanceRequest, v1.RevertAdvanceResponse](
+			httpClient,
+			baseURL+TournamentServiceRevertAdvanceProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("RevertAdvance")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteBracket: connect.NewClient[v1.DeleteBracketRequest, v1.DeleteBracketResponse](
+			httpClient,
+			baseURL+TournamentServiceDeleteBracketProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("DeleteBracket")),
+			connect.WithClientOptions(opts...),
+		),
+		setSeriesLength: connect.NewClient[v1.SetSeriesLengthRequest, v1.SetSeriesLengthResponse](
+			httpClient,
+			baseURL+TournamentServiceSetSeriesLengthProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("SetSeriesLength")),
+			connect.WithClientOptions(opts...),
+		),
+		recordSeriesGame: connect.NewClient[v1.RecordSeriesGameRequest, v1.RecordSeriesGameResponse](
+			httpClient,
+			baseURL+TournamentServiceRecordSeriesGameProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("RecordSeriesGame")),
+			connect.WithClientOptions(opts...),
+		),
+		undoSeriesGame: connect.NewClient[v1.UndoSeriesGameRequest, v1.UndoSeriesGameResponse](
+			httpClient,
+			baseURL+TournamentServiceUndoSeriesGameProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("UndoSeriesGame")),
+			connect.WithClientOptions(opts...),
+		),
+		watchBracket: connect.NewClient[v1.WatchBracketRequest, v1.WatchBracketResponse](
+			httpClient,
+			baseURL+TournamentServiceWatchBracketProcedure,
+			connect.WithSchema(tournamentServiceMethods.ByName("WatchBracket")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// tournamentServiceClient implements TournamentServiceClient.
+type tournamentServiceClient struct {
+	getBracket       *connect.Client[v1.GetBracketRequest, v1.GetBracketResponse]
+	seedBracket      *connect.Client[v1.SeedBracketRequest, v1.SeedBracketResponse]
+	advanceTeam      *connect.Client[v1.AdvanceTeamRequest, v1.AdvanceTeamResponse]
+	revertAdvance    *connect.Client[v1.RevertAdvanceRequest, v1.RevertAdvanceResponse]
+	deleteBracket    *connect.Client[v1.DeleteBracketRequest, v1.DeleteBracketResponse]
+	setSeriesLength  *connect.Client[v1.SetSeriesLengthRequest, v1.SetSeriesLengthResponse]
+	recordSeriesGame *connect.Client[v1.RecordSeriesGameRequest, v1.RecordSeriesGameResponse]
+	undoSeriesGame   *connect.Client[v1.UndoSeriesGameRequest, v1.UndoSeriesGameResponse]
+	watchBracket     *connect.Client[v1.WatchBracketRequest, v1.WatchBracketResponse]
+}
+
+// GetBracket calls cribbly.v1.TournamentService.GetBracket.
+func (c *tournamentServiceClient) GetBracket(ctx context.Context, req *connect.Request[v1.GetBracketRequest]) (*connect.Response[v1.GetBracketResponse], error) {
+	return c.getBracket.CallUnary(ctx, req)
+}
+
+// SeedBracket calls cribbly.v1.TournamentService.SeedBracket.
+func (c *tournamentServiceClient) SeedBracket(ctx context.Context, req *connect.Request[v1.SeedBracketRequest]) (*connect.Response[v1.SeedBracketResponse], error) {
+	return c.seedBracket.CallUnary(ctx, req)
+}
+
+// AdvanceTeam calls cribbly.v1.TournamentService.AdvanceTeam.
+func (c *tournamentServiceClient) AdvanceTeam(ctx context.Context, req *connect.Request[v1.AdvanceTeamRequest]) (*connect.Response[v1.AdvanceTeamResponse], error) {
+	return c.advanceTeam.CallUnary(ctx, req)
+}
+
+// RevertAdvance calls cribbly.v1.TournamentService.RevertAdvance.
+func (c *tournamentServiceClient) RevertAdvance(ctx context.Context, req *connect.Request[v1.RevertAdvanceRequest]) (*connect.Response[v1.RevertAdvanceResponse], error) {
+	return c.revertAdvance.CallUnary(ctx, req)
+}
+
+// DeleteBracket calls cribbly.v1.TournamentService.DeleteBracket.
+func (c *tournamentServiceClient) DeleteBracket(ctx context.Context, req *connect.Request[v1.DeleteBracketRequest]) (*connect.Response[v1.DeleteBracketResponse], error) {
+	return c.deleteBracket.CallUnary(ctx, req)
+}
+
+// SetSeriesLength calls cribbly.v1.TournamentService.SetSeriesLength.
+func (c *tournamentServiceClient) SetSeriesLength(ctx context.Context, req *connect.Request[v1.SetSeriesLengthRequest]) (*connect.Response[v1.SetSeriesLengthResponse], error) {
+	return c.setSeriesLength.CallUnary(ctx, req)
+}
+
+// RecordSeriesGame calls cribbly.v1.TournamentService.RecordSeriesGame.
+func (c *tournamentServiceClient) RecordSeriesGame(ctx context.Context, req *connect.Request[v1.RecordSeriesGameRequest]) (*connect.Response[v1.RecordSeriesGameResponse], error) {
+	return c.recordSeriesGame.CallUnary(ctx, req)
+}
+
+// UndoSeriesGame calls cribbly.v1.TournamentService.UndoSeriesGame.
+func (c *tournamentServiceClient) UndoSeriesGame(ctx context.Context, req *connect.Request[v1.UndoSeriesGameRequest]) (*connect.Response[v1.UndoSeriesGameResponse], error) {
+	return c.undoSeriesGame.CallUnary(ctx, req)
+}
+
+// WatchBracket calls cribbly.v1.TournamentService.WatchBracket.
+func (c *tournamentServiceClient) WatchBracket(ctx context.Context, req *connect.Request[v1.WatchBracketRequest]) (*connect.ServerStreamForClient[v1.WatchBracketResponse], error) {
+	return c.watchBracket.CallServerStream(ctx, req)
+}
+
+// TournamentServiceHandler is an implementation of the cribbly.v1.TournamentService service.
+type TournamentServiceHandler interface {
+	GetBracket(context.Context, *connect.Request[v1.GetBracketRequest]) (*connect.Response[v1.GetBracketResponse], error)
+	// Seeds a new bracket from the prelim standings.
+	SeedBracket(context.Context, *connect.Request[v1.SeedBracketRequest]) (*connect.Response[v1.SeedBracketResponse], error)
+	// Records a team as the winner of a game and moves them into the next round.
+	AdvanceTeam(context.Context, *connect.Request[v1.AdvanceTeamRequest]) (*connect.Response[v1.AdvanceTeamResponse], error)
+	// Undoes AdvanceTeam. Only a team's furthest result can be reverted.
+	RevertAdvance(context.Context, *connect.Request[v1.RevertAdvanceRequest]) (*connect.Response[v1.RevertAdvanceResponse], error)
+	DeleteBracket(context.Context, *connect.Request[v1.DeleteBracketRequest]) (*connect.Response[v1.DeleteBracketResponse], error)
+	// Makes a game a best-of-N series. Can't be changed once a game of the series is recorded.
+	SetSeriesLength(context.Context, *connect.Request[v1.SetSeriesLengthRequest]) (*connect.Response[v1.SetSeriesLengthResponse], error)
+	// Records the next game of a series. The team that wins a majority of the games advances.
+	RecordSeriesGame(context.Context, *connect.Request[v1.RecordSeriesGameRequest]) (*connect.Response[v1.RecordSeriesGameResponse], error)
+	// Removes the last recorded game of a series, reverting the result if that game decided it.
+	UndoSeriesGame(context.Context, *connect.Request[v1.UndoSeriesGameRequest]) (*connect.Response[v1.UndoSeriesGameResponse], error)
+	// Sends the current bracket, then the whole bracket again every time it changes.
+	WatchBracket(context.Context, *connect.Request[v1.WatchBracketRequest], *connect.ServerStream[v1.WatchBracketResponse]) error
+}
+
+// NewTournamentServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTournamentServiceHandler(svc TournamentServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	tournamentServiceMethods := v1.File_cribbly_v1_tournament_proto.Services().ByName("TournamentService").Methods()
+	tournamentServiceGetBracketHandler := connect.NewUnaryHandler(
+		TournamentServiceGetBracketProcedure,
+		svc.GetBracket,
+		connect.WithSchema(tournamentServiceMethods.ByName("GetBracket")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tournamentServiceSeedBracketHandler := connect.NewUnaryHandler(
+		TournamentServiceSeedBracketProcedure,
+		svc.SeedBracket,
+		connect.WithSchema(tournamentServiceMethods.ByName("SeedBracket")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tournamentServiceAdvanceTeamHandler := connect.NewUnaryHandler(
+		TournamentServiceAdvanceTeamProcedure,
+		svc.AdvanceTeam,
+		connect.WithSchema(tournamentServiceMethods.ByName("AdvanceTeam")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tournamentServiceRevertAdvanceHandler := connect.NewUnaryHandler(
+		TournamentServiceRevertAdvanceProcedure,
+		svc.RevertAdvance,
+		connect.WithSchema(tournamentServiceMethods.ByName("RevertAdvance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tournamentServiceDeleteBracketHandler := connect.NewUnaryHandler(
+		TournamentServiceDeleteBracketProcedure,
+		svc.DeleteBracket,
+		connect.WithSchema(tournamentServiceMethods.ByName("DeleteBracket")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tournamentServiceSetSeriesLengthHandler := connect.NewUnaryHandler(
+		TournamentServiceSetSeriesLengthProcedure,
+		svc.SetSeriesLength,
+		connect.WithSchema(tournamentServiceMethods.ByName("SetSeriesLength")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tournamentServiceRecordSeriesGameHandler := connect.NewUnaryHandler(
+		TournamentServiceRecordSeriesGameProcedure,
+		svc.RecordSeriesGame,
+		connect.WithSchema(tournamentServiceMethods.ByName("RecordSeriesGame")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tournamentServiceUndoSeriesGameHandler := connect.NewUnaryHandler(
+		TournamentServiceUndoSeriesGameProcedure,
+		svc.UndoSeriesGame,
+		connect.WithSchema(tournamentServiceMethods.ByName("UndoSeriesGame")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tournamentServiceWatchBracketHandler := connect.NewServerStreamHandler(
+		TournamentServiceWatchBracketProcedure,
+		svc.WatchBracket,
+		connect.WithSchema(tournamentServiceMethods.ByName("WatchBracket")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/cribbly.v1.TournamentService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TournamentServiceGetBracketProcedure:
+			tournamentServiceGetBracketHandler.ServeHTTP(w, r)
+		case TournamentServiceSeedBracketProcedure:
+			tournamentServiceSeedBracketHandler.ServeHTTP(w, r)
+		case TournamentServiceAdvanceTeamProcedure:
+			tournamentServiceAdvanceTeamHandler.ServeHTTP(w, r)
+		case TournamentServiceRevertAdvanceProcedure:
+			tournamentServiceRevertAdvanceHandler.ServeHTTP(w, r)
+		case TournamentServiceDeleteBracketProcedure:
+			tournamentServiceDeleteBracketHandler.ServeHTTP(w, r)
+		case TournamentServiceSetSeriesLengthProcedure:
+			tournamentServiceSetSeriesLengthHandler.ServeHTTP(w, r)
+		case TournamentServiceRecordSeriesGameProcedure:
+			tournamentServiceRecordSeriesGameHandler.ServeHTTP(w, r)
+		case TournamentServiceUndoSeriesGameProcedure:
+			tournamentServiceUndoSeriesGameHandler.ServeHTTP(w, r)
+		case TournamentServiceWatchBracketProcedure:
+			tournamentServiceWatchBracketHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTournamentServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTournamentServiceHandler struct{}
+
+func (UnimplementedTournamentServiceHandler) GetBracket(context.Context, *connect.Request[v1.GetBracketRequest]) (*connect.Response[v1.GetBracketResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.GetBracket is not implemented"))
+}
+
+func (UnimplementedTournamentServiceHandler) SeedBracket(context.Context, *connect.Request[v1.SeedBracketRequest]) (*connect.Response[v1.SeedBracketResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.SeedBracket is not implemented"))
+}
+
+func (UnimplementedTournamentServiceHandler) AdvanceTeam(context.Context, *connect.Request[v1.AdvanceTeamRequest]) (*connect.Response[v1.AdvanceTeamResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.AdvanceTeam is not implemented"))
+}
+
+func (UnimplementedTournamentServiceHandler) RevertAdvance(context.Context, *connect.Request[v1.RevertAdvanceRequest]) (*connect.Response[v1.RevertAdvanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.RevertAdvance is not implemented"))
+}
+
+func (UnimplementedTournamentServiceHandler) DeleteBracket(context.Context, *connect.Request[v1.DeleteBracketRequest]) (*connect.Response[v1.DeleteBracketResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.DeleteBracket is not implemented"))
+}
+
+func (UnimplementedTournamentServiceHandler) SetSeriesLength(context.Context, *connect.Request[v1.SetSeriesLengthRequest]) (*connect.Response[v1.SetSeriesLengthResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.SetSeriesLength is not implemented"))
+}
+
+func (UnimplementedTournamentServiceHandler) RecordSeriesGame(context.Context, *connect.Request[v1.RecordSeriesGameRequest]) (*connect.Response[v1.RecordSeriesGameResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.RecordSeriesGame is not implemented"))
+}
+
+func (UnimplementedTournamentServiceHandler) UndoSeriesGame(context.Context, *connect.Request[v1.UndoSeriesGameRequest]) (*connect.Response[v1.UndoSeriesGameResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.UndoSeriesGame is not implemented"))
+}
+
+func (UnimplementedTournamentServiceHandler) WatchBracket(context.Context, *connect.Request[v1.WatchBracketRequest], *connect.ServerStream[v1.WatchBracketResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("cribbly.v1.TournamentService.WatchBracket is not implemented"))
+}
@@ -0,0 +1,29 @@
+// Code generated by protoc-gen-cribbly-sse. DO NOT EDIT.
+//
+// Source: cribbly/v1/standings.proto
+
+package cribblyv1sse
+
+import (
+	context "context"
+	v1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
+	sse "github.com/cszczepaniak/cribbly/internal/sse"
+	http "net/http"
+)
+
+const (
+	// StandingsServiceWatchStandingsPath is the path that cribbly.v1.StandingsService.WatchStandings is served at.
+	StandingsServiceWatchStandingsPath = "/standings/stream"
+)
+
+// StandingsServiceSSEHandler serves the server-sent event streams of cribbly.v1.StandingsService.
+type StandingsServiceSSEHandler interface {
+	// Sends the current standings, then the full standings again every time a score changes.
+	WatchStandings(context.Context, *v1.WatchStandingsRequest, *sse.Stream[*v1.WatchStandingsResponse]) error
+}
+
+// NewStandingsServiceWatchStandingsSSEHandler returns the path cribbly.v1.StandingsService.WatchStandings is served at and
+// a handler that serves it as a text/event-stream.
+func NewStandingsServiceWatchStandingsSSEHandler(svc StandingsServiceSSEHandler) (string, http.Handler) {
+	return StandingsServiceWatchStandingsPath, sse.NewHandler(svc.WatchStandings)
+}
@@ -0,0 +1,29 @@
+// Code generated by protoc-gen-cribbly-sse. DO NOT EDIT.
+//
+// Source: cribbly/v1/tournament.proto
+
+package cribblyv1sse
+
+import (
+	context "context"
+	v1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
+	sse "github.com/cszczepaniak/cribbly/internal/sse"
+	http "net/http"
+)
+
+const (
+	// TournamentServiceWatchBracketPath is the path that cribbly.v1.TournamentService.WatchBracket is served at.
+	TournamentServiceWatchBracketPath = "/tournament/stream"
+)
+
+// TournamentServiceSSEHandler serves the server-sent event streams of cribbly.v1.TournamentService.
+type TournamentServiceSSEHandler interface {
+	// Sends the current bracket, then the whole bracket again every time it changes.
+	WatchBracket(context.Context, *v1.WatchBracketRequest, *sse.Stream[*v1.WatchBracketResponse]) error
+}
+
+// NewTournamentServiceWatchBracketSSEHandler returns the path cribbly.v1.TournamentService.WatchBracket is served at and
+// a handler that serves it as a text/event-stream.
+func NewTournamentServiceWatchBracketSSEHandler(svc TournamentServiceSSEHandler) (string, http.Handler) {
+	return TournamentServiceWatchBracketPath, sse.NewHandler(svc.WatchBracket)
+}
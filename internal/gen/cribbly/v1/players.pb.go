@@ -27,7 +27,9 @@ type Player struct {
 	FirstName string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
 	LastName  string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
 	// Empty when the player is not on a team.
-	TeamId        string `protobuf:"bytes,4,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	TeamId string `protobuf:"bytes,4,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// The person this player is. Unlike id, it's the same in every event they play in.
+	PersonId      string `protobuf:"bytes,5,opt,name=person_id,json=personId,proto3" json:"person_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,6 +92,13 @@ func (x *Player) GetTeamId() string {
 	return ""
 }
 
+func (x *Player) GetPersonId() string {
+	if x != nil {
+		return x.PersonId
+	}
+	return ""
+}
+
 type ListPlayersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -618,18 +627,413 @@ func (x *GenerateRandomPlayersResponse) GetPlayers() []*Player {
 	return nil
 }
 
+type GetPlayerHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PersonId      string                 `protobuf:"bytes,1,opt,name=person_id,json=personId,proto3" json:"person_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerHistoryRequest) Reset() {
+	*x = GetPlayerHistoryRequest{}
+	mi := &file_cribbly_v1_players_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayerHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerHistoryRequest) ProtoMessage() {}
+
+func (x *GetPlayerHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_players_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayerHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_players_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetPlayerHistoryRequest) GetPersonId() string {
+	if x != nil {
+		return x.PersonId
+	}
+	return ""
+}
+
+type GetPlayerHistoryResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PersonId  string                 `protobuf:"bytes,1,opt,name=person_id,json=personId,proto3" json:"person_id,omitempty"`
+	FirstName string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// Across every event.
+	Record *Record `protobuf:"bytes,4,opt,name=record,proto3" json:"record,omitempty"`
+	// Most recent first.
+	Events []*PlayerEvent `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"`
+	// The people the player has played most, most games first.
+	Opponents     []*HeadToHead `protobuf:"bytes,6,rep,name=opponents,proto3" json:"opponents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerHistoryResponse) Reset() {
+	*x = GetPlayerHistoryResponse{}
+	mi := &file_cribbly_v1_players_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayerHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerHistoryResponse) ProtoMessage() {}
+
+func (x *GetPlayerHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_players_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPlayerHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_players_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetPlayerHistoryResponse) GetPersonId() string {
+	if x != nil {
+		return x.PersonId
+	}
+	return ""
+}
+
+func (x *GetPlayerHistoryResponse) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *GetPlayerHistoryResponse) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *GetPlayerHistoryResponse) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *GetPlayerHistoryResponse) GetEvents() []*PlayerEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetPlayerHistoryResponse) GetOpponents() []*HeadToHead {
+	if x != nil {
+		return x.Opponents
+	}
+	return nil
+}
+
+type Record struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wins          int32                  `protobuf:"varint,1,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int32                  `protobuf:"varint,2,opt,name=losses,proto3" json:"losses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_cribbly_v1_players_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_players_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_players_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Record) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *Record) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+// How a player did at one event.
+type PlayerEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	EventId   string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventName string                 `protobuf:"bytes,2,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	// YYYY-MM-DD.
+	Date string `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	// Empty when the player was not on a team.
+	TeamId   string    `protobuf:"bytes,4,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	TeamName string    `protobuf:"bytes,5,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Partners []*Player `protobuf:"bytes,6,rep,name=partners,proto3" json:"partners,omitempty"`
+	// Empty when the team was not in a division.
+	DivisionName string `protobuf:"bytes,7,opt,name=division_name,json=divisionName,proto3" json:"division_name,omitempty"`
+	// The team's place in its division's prelim standings (or the whole event's, without divisions),
+	// starting at 1, out of finish_of teams. 0 until the team has played.
+	Finish   int32 `protobuf:"varint,8,opt,name=finish,proto3" json:"finish,omitempty"`
+	FinishOf int32 `protobuf:"varint,9,opt,name=finish_of,json=finishOf,proto3" json:"finish_of,omitempty"`
+	// How far the team got in the brackets, like "Champion" or "Quarterfinals". Empty when the team
+	// was not in a bracket.
+	BracketResult string  `protobuf:"bytes,10,opt,name=bracket_result,json=bracketResult,proto3" json:"bracket_result,omitempty"`
+	Record        *Record `protobuf:"bytes,11,opt,name=record,proto3" json:"record,omitempty"`
+	// The player's rating after the event; 0 if ratings haven't been computed.
+	Rating        int32 `protobuf:"varint,12,opt,name=rating,proto3" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerEvent) Reset() {
+	*x = PlayerEvent{}
+	mi := &file_cribbly_v1_players_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerEvent) ProtoMessage() {}
+
+func (x *PlayerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_players_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerEvent.ProtoReflect.Descriptor instead.
+func (*PlayerEvent) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_players_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PlayerEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetEventName() string {
+	if x != nil {
+		return x.EventName
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetPartners() []*Player {
+	if x != nil {
+		return x.Partners
+	}
+	return nil
+}
+
+func (x *PlayerEvent) GetDivisionName() string {
+	if x != nil {
+		return x.DivisionName
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetFinish() int32 {
+	if x != nil {
+		return x.Finish
+	}
+	return 0
+}
+
+func (x *PlayerEvent) GetFinishOf() int32 {
+	if x != nil {
+		return x.FinishOf
+	}
+	return 0
+}
+
+func (x *PlayerEvent) GetBracketResult() string {
+	if x != nil {
+		return x.BracketResult
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *PlayerEvent) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+// How a player has done against one opponent, across every event.
+type HeadToHead struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PersonId  string                 `protobuf:"bytes,1,opt,name=person_id,json=personId,proto3" json:"person_id,omitempty"`
+	FirstName string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// Games the player won and lost against this opponent.
+	Record        *Record `protobuf:"bytes,4,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeadToHead) Reset() {
+	*x = HeadToHead{}
+	mi := &file_cribbly_v1_players_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadToHead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadToHead) ProtoMessage() {}
+
+func (x *HeadToHead) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_players_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadToHead.ProtoReflect.Descriptor instead.
+func (*HeadToHead) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_players_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HeadToHead) GetPersonId() string {
+	if x != nil {
+		return x.PersonId
+	}
+	return ""
+}
+
+func (x *HeadToHead) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *HeadToHead) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *HeadToHead) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
 var File_cribbly_v1_players_proto protoreflect.FileDescriptor
 
 const file_cribbly_v1_players_proto_rawDesc = "" +
 	"\n" +
 	"\x18cribbly/v1/players.proto\x12\n" +
-	"cribbly.v1\"m\n" +
+	"cribbly.v1\"\x8a\x01\n" +
 	"\x06Player\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
 	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
 	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x17\n" +
-	"\ateam_id\x18\x04 \x01(\tR\x06teamId\"\x14\n" +
+	"\ateam_id\x18\x04 \x01(\tR\x06teamId\x12\x1b\n" +
+	"\tperson_id\x18\x05 \x01(\tR\bpersonId\"\x14\n" +
 	"\x12ListPlayersRequest\"C\n" +
 	"\x13ListPlayersResponse\x12,\n" +
 	"\aplayers\x18\x01 \x03(\v2\x12.cribbly.v1.PlayerR\aplayers\"Q\n" +
@@ -655,14 +1059,50 @@ const file_cribbly_v1_players_proto_rawDesc = "" +
 	"\x1cGenerateRandomPlayersRequest\x12\x14\n" +
 	"\x05count\x18\x01 \x01(\x05R\x05count\"M\n" +
 	"\x1dGenerateRandomPlayersResponse\x12,\n" +
-	"\aplayers\x18\x01 \x03(\v2\x12.cribbly.v1.PlayerR\aplayers2\xb1\x04\n" +
+	"\aplayers\x18\x01 \x03(\v2\x12.cribbly.v1.PlayerR\aplayers\"6\n" +
+	"\x17GetPlayerHistoryRequest\x12\x1b\n" +
+	"\tperson_id\x18\x01 \x01(\tR\bpersonId\"\x86\x02\n" +
+	"\x18GetPlayerHistoryResponse\x12\x1b\n" +
+	"\tperson_id\x18\x01 \x01(\tR\bpersonId\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12*\n" +
+	"\x06record\x18\x04 \x01(\v2\x12.cribbly.v1.RecordR\x06record\x12/\n" +
+	"\x06events\x18\x05 \x03(\v2\x17.cribbly.v1.PlayerEventR\x06events\x124\n" +
+	"\topponents\x18\x06 \x03(\v2\x16.cribbly.v1.HeadToHeadR\topponents\"4\n" +
+	"\x06Record\x12\x12\n" +
+	"\x04wins\x18\x01 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x02 \x01(\x05R\x06losses\"\x86\x03\n" +
+	"\vPlayerEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_name\x18\x02 \x01(\tR\teventName\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x17\n" +
+	"\ateam_id\x18\x04 \x01(\tR\x06teamId\x12\x1b\n" +
+	"\tteam_name\x18\x05 \x01(\tR\bteamName\x12.\n" +
+	"\bpartners\x18\x06 \x03(\v2\x12.cribbly.v1.PlayerR\bpartners\x12#\n" +
+	"\rdivision_name\x18\a \x01(\tR\fdivisionName\x12\x16\n" +
+	"\x06finish\x18\b \x01(\x05R\x06finish\x12\x1b\n" +
+	"\tfinish_of\x18\t \x01(\x05R\bfinishOf\x12%\n" +
+	"\x0ebracket_result\x18\n" +
+	" \x01(\tR\rbracketResult\x12*\n" +
+	"\x06record\x18\v \x01(\v2\x12.cribbly.v1.RecordR\x06record\x12\x16\n" +
+	"\x06rating\x18\f \x01(\x05R\x06rating\"\x91\x01\n" +
+	"\n" +
+	"HeadToHead\x12\x1b\n" +
+	"\tperson_id\x18\x01 \x01(\tR\bpersonId\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12*\n" +
+	"\x06record\x18\x04 \x01(\v2\x12.cribbly.v1.RecordR\x06record2\x92\x05\n" +
 	"\rPlayerService\x12P\n" +
 	"\vListPlayers\x12\x1e.cribbly.v1.ListPlayersRequest\x1a\x1f.cribbly.v1.ListPlayersResponse\"\x00\x12S\n" +
 	"\fCreatePlayer\x12\x1f.cribbly.v1.CreatePlayerRequest\x1a .cribbly.v1.CreatePlayerResponse\"\x00\x12S\n" +
 	"\fUpdatePlayer\x12\x1f.cribbly.v1.UpdatePlayerRequest\x1a .cribbly.v1.UpdatePlayerResponse\"\x00\x12S\n" +
 	"\fDeletePlayer\x12\x1f.cribbly.v1.DeletePlayerRequest\x1a .cribbly.v1.DeletePlayerResponse\"\x00\x12_\n" +
 	"\x10DeleteAllPlayers\x12#.cribbly.v1.DeleteAllPlayersRequest\x1a$.cribbly.v1.DeleteAllPlayersResponse\"\x00\x12n\n" +
-	"\x15GenerateRandomPlayers\x12(.cribbly.v1.GenerateRandomPlayersRequest\x1a).cribbly.v1.GenerateRandomPlayersResponse\"\x00BCZAgithub.com/cszczepaniak/cribbly/internal/gen/cribbly/v1;cribblyv1b\x06proto3"
+	"\x15GenerateRandomPlayers\x12(.cribbly.v1.GenerateRandomPlayersRequest\x1a).cribbly.v1.GenerateRandomPlayersResponse\"\x00\x12_\n" +
+	"\x10GetPlayerHistory\x12#.cribbly.v1.GetPlayerHistoryRequest\x1a$.cribbly.v1.GetPlayerHistoryResponse\"\x00BCZAgithub.com/cszczepaniak/cribbly/internal/gen/cribbly/v1;cribblyv1b\x06proto3"
 
 var (
 	file_cribbly_v1_players_proto_rawDescOnce sync.Once
@@ -676,7 +1116,7 @@ func file_cribbly_v1_players_proto_rawDescGZIP() []byte {
 	return file_cribbly_v1_players_proto_rawDescData
 }
 
-var file_cribbly_v1_players_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_cribbly_v1_players_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_cribbly_v1_players_proto_goTypes = []any{
 	(*Player)(nil),                        // 0: cribbly.v1.Player
 	(*ListPlayersRequest)(nil),            // 1: cribbly.v1.ListPlayersRequest
@@ -691,6 +1131,11 @@ var file_cribbly_v1_players_proto_goTypes = []any{
 	(*DeleteAllPlayersResponse)(nil),      // 10: cribbly.v1.DeleteAllPlayersResponse
 	(*GenerateRandomPlayersRequest)(nil),  // 11: cribbly.v1.GenerateRandomPlayersRequest
 	(*GenerateRandomPlayersResponse)(nil), // 12: cribbly.v1.GenerateRandomPlayersResponse
+	(*GetPlayerHistoryRequest)(nil),       // 13: cribbly.v1.GetPlayerHistoryRequest
+	(*GetPlayerHistoryResponse)(nil),      // 14: cribbly.v1.GetPlayerHistoryResponse
+	(*Record)(nil),                        // 15: cribbly.v1.Record
+	(*PlayerEvent)(nil),                   // 16: cribbly.v1.PlayerEvent
+	(*HeadToHead)(nil),                    // 17: cribbly.v1.HeadToHead
 }
 var file_cribbly_v1_players_proto_depIdxs = []int32{
 	0,  // 0: cribbly.v1.ListPlayersResponse.players:type_name -> cribbly.v1.Player
@@ -698,23 +1143,31 @@ var file_cribbly_v1_players_proto_depIdxs = []int32{
 	0,  // 2: cribbly.v1.UpdatePlayerResponse.players:type_name -> cribbly.v1.Player
 	0,  // 3: cribbly.v1.DeletePlayerResponse.players:type_name -> cribbly.v1.Player
 	0,  // 4: cribbly.v1.GenerateRandomPlayersResponse.players:type_name -> cribbly.v1.Player
-	1,  // 5: cribbly.v1.PlayerService.ListPlayers:input_type -> cribbly.v1.ListPlayersRequest
-	3,  // 6: cribbly.v1.PlayerService.CreatePlayer:input_type -> cribbly.v1.CreatePlayerRequest
-	5,  // 7: cribbly.v1.PlayerService.UpdatePlayer:input_type -> cribbly.v1.UpdatePlayerRequest
-	7,  // 8: cribbly.v1.PlayerService.DeletePlayer:input_type -> cribbly.v1.DeletePlayerRequest
-	9,  // 9: cribbly.v1.PlayerService.DeleteAllPlayers:input_type -> cribbly.v1.DeleteAllPlayersRequest
-	11, // 10: cribbly.v1.PlayerService.GenerateRandomPlayers:input_type -> cribbly.v1.GenerateRandomPlayersRequest
-	2,  // 11: cribbly.v1.PlayerService.ListPlayers:output_type -> cribbly.v1.ListPlayersResponse
-	4,  // 12: cribbly.v1.PlayerService.CreatePlayer:output_type -> cribbly.v1.CreatePlayerResponse
-	6,  // 13: cribbly.v1.PlayerService.UpdatePlayer:output_type -> cribbly.v1.UpdatePlayerResponse
-	8,  // 14: cribbly.v1.PlayerService.DeletePlayer:output_type -> cribbly.v1.DeletePlayerResponse
-	10, // 15: cribbly.v1.PlayerService.DeleteAllPlayers:output_type -> cribbly.v1.DeleteAllPlayersResponse
-	12, // 16: cribbly.v1.PlayerService.GenerateRandomPlayers:output_type -> cribbly.v1.GenerateRandomPlayersResponse
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	15, // 5: cribbly.v1.GetPlayerHistoryResponse.record:type_name -> cribbly.v1.Record
+	16, // 6: cribbly.v1.GetPlayerHistoryResponse.events:type_name -> cribbly.v1.PlayerEvent
+	17, // 7: cribbly.v1.GetPlayerHistoryResponse.opponents:type_name -> cribbly.v1.HeadToHead
+	0,  // 8: cribbly.v1.PlayerEvent.partners:type_name -> cribbly.v1.Player
+	15, // 9: cribbly.v1.PlayerEvent.record:type_name -> cribbly.v1.Record
+	15, // 10: cribbly.v1.HeadToHead.record:type_name -> cribbly.v1.Record
+	1,  // 11: cribbly.v1.PlayerService.ListPlayers:input_type -> cribbly.v1.ListPlayersRequest
+	3,  // 12: cribbly.v1.PlayerService.CreatePlayer:input_type -> cribbly.v1.CreatePlayerRequest
+	5,  // 13: cribbly.v1.PlayerService.UpdatePlayer:input_type -> cribbly.v1.UpdatePlayerRequest
+	7,  // 14: cribbly.v1.PlayerService.DeletePlayer:input_type -> cribbly.v1.DeletePlayerRequest
+	9,  // 15: cribbly.v1.PlayerService.DeleteAllPlayers:input_type -> cribbly.v1.DeleteAllPlayersRequest
+	11, // 16: cribbly.v1.PlayerService.GenerateRandomPlayers:input_type -> cribbly.v1.GenerateRandomPlayersRequest
+	13, // 17: cribbly.v1.PlayerService.GetPlayerHistory:input_type -> cribbly.v1.GetPlayerHistoryRequest
+	2,  // 18: cribbly.v1.PlayerService.ListPlayers:output_type -> cribbly.v1.ListPlayersResponse
+	4,  // 19: cribbly.v1.PlayerService.CreatePlayer:output_type -> cribbly.v1.CreatePlayerResponse
+	6,  // 20: cribbly.v1.PlayerService.UpdatePlayer:output_type -> cribbly.v1.UpdatePlayerResponse
+	8,  // 21: cribbly.v1.PlayerService.DeletePlayer:output_type -> cribbly.v1.DeletePlayerResponse
+	10, // 22: cribbly.v1.PlayerService.DeleteAllPlayers:output_type -> cribbly.v1.DeleteAllPlayersResponse
+	12, // 23: cribbly.v1.PlayerService.GenerateRandomPlayers:output_type -> cribbly.v1.GenerateRandomPlayersResponse
+	14, // 24: cribbly.v1.PlayerService.GetPlayerHistory:output_type -> cribbly.v1.GetPlayerHistoryResponse
+	18, // [18:25] is the sub-list for method output_type
+	11, // [11:18] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_cribbly_v1_players_proto_init() }
@@ -728,7 +1181,7 @@ func file_cribbly_v1_players_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cribbly_v1_players_proto_rawDesc), len(file_cribbly_v1_players_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
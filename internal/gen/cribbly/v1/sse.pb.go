@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: cribbly/v1/sse.proto
+
+package cribblyv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SSEOptions exposes a server-streaming RPC as a plain text/event-stream endpoint, which browsers
+// can consume with EventSource. protoc-gen-cribbly-sse generates a Go handler and a TypeScript
+// client for every method that sets it.
+type SSEOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The path the stream is served at, e.g. "/standings/stream". The request message is read from
+	// the "message" query parameter as JSON; each response message is sent as one event.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SSEOptions) Reset() {
+	*x = SSEOptions{}
+	mi := &file_cribbly_v1_sse_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SSEOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SSEOptions) ProtoMessage() {}
+
+func (x *SSEOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_sse_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SSEOptions.ProtoReflect.Descriptor instead.
+func (*SSEOptions) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_sse_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SSEOptions) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+var file_cribbly_v1_sse_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*SSEOptions)(nil),
+		Field:         50400,
+		Name:          "cribbly.v1.sse",
+		Tag:           "bytes,50400,opt,name=sse",
+		Filename:      "cribbly/v1/sse.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional cribbly.v1.SSEOptions sse = 50400;
+	E_Sse = &file_cribbly_v1_sse_proto_extTypes[0]
+)
+
+var File_cribbly_v1_sse_proto protoreflect.FileDescriptor
+
+const file_cribbly_v1_sse_proto_rawDesc = "" +
+	"\n" +
+	"\x14cribbly/v1/sse.proto\x12\n" +
+	"cribbly.v1\x1a google/protobuf/descriptor.proto\" \n" +
+	"\n" +
+	"SSEOptions\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path:J\n" +
+	"\x03sse\x12\x1e.google.protobuf.MethodOptions\x18\xe0\x89\x03 \x01(\v2\x16.cribbly.v1.SSEOptionsR\x03sseBCZAgithub.com/cszczepaniak/cribbly/internal/gen/cribbly/v1;cribblyv1b\x06proto3"
+
+var (
+	file_cribbly_v1_sse_proto_rawDescOnce sync.Once
+	file_cribbly_v1_sse_proto_rawDescData []byte
+)
+
+func file_cribbly_v1_sse_proto_rawDescGZIP() []byte {
+	file_cribbly_v1_sse_proto_rawDescOnce.Do(func() {
+		file_cribbly_v1_sse_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cribbly_v1_sse_proto_rawDesc), len(file_cribbly_v1_sse_proto_rawDesc)))
+	})
+	return file_cribbly_v1_sse_proto_rawDescData
+}
+
+var file_cribbly_v1_sse_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_cribbly_v1_sse_proto_goTypes = []any{
+	(*SSEOptions)(nil),                 // 0: cribbly.v1.SSEOptions
+	(*descriptorpb.MethodOptions)(nil), // 1: google.protobuf.MethodOptions
+}
+var file_cribbly_v1_sse_proto_depIdxs = []int32{
+	1, // 0: cribbly.v1.sse:extendee -> google.protobuf.MethodOptions
+	0, // 1: cribbly.v1.sse:type_name -> cribbly.v1.SSEOptions
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_cribbly_v1_sse_proto_init() }
+func file_cribbly_v1_sse_proto_init() {
+	if File_cribbly_v1_sse_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cribbly_v1_sse_proto_rawDesc), len(file_cribbly_v1_sse_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_cribbly_v1_sse_proto_goTypes,
+		DependencyIndexes: file_cribbly_v1_sse_proto_depIdxs,
+		MessageInfos:      file_cribbly_v1_sse_proto_msgTypes,
+		ExtensionInfos:    file_cribbly_v1_sse_proto_extTypes,
+	}.Build()
+	File_cribbly_v1_sse_proto = out.File
+	file_cribbly_v1_sse_proto_goTypes = nil
+	file_cribbly_v1_sse_proto_depIdxs = nil
+}
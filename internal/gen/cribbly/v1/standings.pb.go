@@ -0,0 +1,444 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: cribbly/v1/standings.proto
+
+package cribblyv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Standing struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamId   string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	TeamName string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Wins     int32                  `protobuf:"varint,3,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses   int32                  `protobuf:"varint,4,opt,name=losses,proto3" json:"losses,omitempty"`
+	// Wins per game played, from 0 to 1. Zero when the team hasn't played.
+	WinRate float64 `protobuf:"fixed64,5,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`
+	// Zero when the team hasn't played.
+	PointsPerGame float64 `protobuf:"fixed64,6,opt,name=points_per_game,json=pointsPerGame,proto3" json:"points_per_game,omitempty"`
+	// Total under the event's game point scheme; standings are ranked by it.
+	GamePoints int32 `protobuf:"varint,7,opt,name=game_points,json=gamePoints,proto3" json:"game_points,omitempty"`
+	// Wins where the loser finished under 91, including double skunks.
+	SkunksGiven int32 `protobuf:"varint,8,opt,name=skunks_given,json=skunksGiven,proto3" json:"skunks_given,omitempty"`
+	// Wins where the loser finished under 61.
+	DoubleSkunksGiven int32 `protobuf:"varint,9,opt,name=double_skunks_given,json=doubleSkunksGiven,proto3" json:"double_skunks_given,omitempty"`
+	SkunksTaken       int32 `protobuf:"varint,10,opt,name=skunks_taken,json=skunksTaken,proto3" json:"skunks_taken,omitempty"`
+	DoubleSkunksTaken int32 `protobuf:"varint,11,opt,name=double_skunks_taken,json=doubleSkunksTaken,proto3" json:"double_skunks_taken,omitempty"`
+	PointsAllowed     int32 `protobuf:"varint,12,opt,name=points_allowed,json=pointsAllowed,proto3" json:"points_allowed,omitempty"`
+	// The tiebreaker that put this team ahead of the next one (e.g. "head-to-head" or "coin-flip").
+	// Empty unless the two were level on games played and game points.
+	Tiebreak string `protobuf:"bytes,13,opt,name=tiebreak,proto3" json:"tiebreak,omitempty"`
+	// When the coin flip was recorded, as RFC 3339, if tiebreak is "coin-flip".
+	CoinFlippedAt string `protobuf:"bytes,14,opt,name=coin_flipped_at,json=coinFlippedAt,proto3" json:"coin_flipped_at,omitempty"`
+	// True if this team is level with the next one on everything but a coin flip that hasn't been
+	// made yet. Coins are flipped when the standings are final.
+	CoinFlipPending bool `protobuf:"varint,15,opt,name=coin_flip_pending,json=coinFlipPending,proto3" json:"coin_flip_pending,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Standing) Reset() {
+	*x = Standing{}
+	mi := &file_cribbly_v1_standings_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Standing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Standing) ProtoMessage() {}
+
+func (x *Standing) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_standings_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Standing.ProtoReflect.Descriptor instead.
+func (*Standing) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_standings_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Standing) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *Standing) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Standing) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *Standing) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *Standing) GetWinRate() float64 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
+}
+
+func (x *Standing) GetPointsPerGame() float64 {
+	if x != nil {
+		return x.PointsPerGame
+	}
+	return 0
+}
+
+func (x *Standing) GetGamePoints() int32 {
+	if x != nil {
+		return x.GamePoints
+	}
+	return 0
+}
+
+func (x *Standing) GetSkunksGiven() int32 {
+	if x != nil {
+		return x.SkunksGiven
+	}
+	return 0
+}
+
+func (x *Standing) GetDoubleSkunksGiven() int32 {
+	if x != nil {
+		return x.DoubleSkunksGiven
+	}
+	return 0
+}
+
+func (x *Standing) GetSkunksTaken() int32 {
+	if x != nil {
+		return x.SkunksTaken
+	}
+	return 0
+}
+
+func (x *Standing) GetDoubleSkunksTaken() int32 {
+	if x != nil {
+		return x.DoubleSkunksTaken
+	}
+	return 0
+}
+
+func (x *Standing) GetPointsAllowed() int32 {
+	if x != nil {
+		return x.PointsAllowed
+	}
+	return 0
+}
+
+func (x *Standing) GetTiebreak() string {
+	if x != nil {
+		return x.Tiebreak
+	}
+	return ""
+}
+
+func (x *Standing) GetCoinFlippedAt() string {
+	if x != nil {
+		return x.CoinFlippedAt
+	}
+	return ""
+}
+
+func (x *Standing) GetCoinFlipPending() bool {
+	if x != nil {
+		return x.CoinFlipPending
+	}
+	return false
+}
+
+type GetStandingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStandingsRequest) Reset() {
+	*x = GetStandingsRequest{}
+	mi := &file_cribbly_v1_standings_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStandingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStandingsRequest) ProtoMessage() {}
+
+func (x *GetStandingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_standings_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStandingsRequest.ProtoReflect.Descriptor instead.
+func (*GetStandingsRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_standings_proto_rawDescGZIP(), []int{1}
+}
+
+type GetStandingsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered from first place to last.
+	Standings     []*Standing `protobuf:"bytes,1,rep,name=standings,proto3" json:"standings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStandingsResponse) Reset() {
+	*x = GetStandingsResponse{}
+	mi := &file_cribbly_v1_standings_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStandingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStandingsResponse) ProtoMessage() {}
+
+func (x *GetStandingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_standings_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStandingsResponse.ProtoReflect.Descriptor instead.
+func (*GetStandingsResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_standings_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetStandingsResponse) GetStandings() []*Standing {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+type WatchStandingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStandingsRequest) Reset() {
+	*x = WatchStandingsRequest{}
+	mi := &file_cribbly_v1_standings_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStandingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStandingsRequest) ProtoMessage() {}
+
+func (x *WatchStandingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_standings_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStandingsRequest.ProtoReflect.Descriptor instead.
+func (*WatchStandingsRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_standings_proto_rawDescGZIP(), []int{3}
+}
+
+type WatchStandingsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered from first place to last.
+	Standings     []*Standing `protobuf:"bytes,1,rep,name=standings,proto3" json:"standings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStandingsResponse) Reset() {
+	*x = WatchStandingsResponse{}
+	mi := &file_cribbly_v1_standings_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStandingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStandingsResponse) ProtoMessage() {}
+
+func (x *WatchStandingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_standings_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStandingsResponse.ProtoReflect.Descriptor instead.
+func (*WatchStandingsResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_standings_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WatchStandingsResponse) GetStandings() []*Standing {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+var File_cribbly_v1_standings_proto protoreflect.FileDescriptor
+
+const file_cribbly_v1_standings_proto_rawDesc = "" +
+	"\n" +
+	"\x1acribbly/v1/standings.proto\x12\n" +
+	"cribbly.v1\x1a\x14cribbly/v1/sse.proto\"\x8d\x04\n" +
+	"\bStanding\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\tR\x06teamId\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\x12\x12\n" +
+	"\x04wins\x18\x03 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x04 \x01(\x05R\x06losses\x12\x19\n" +
+	"\bwin_rate\x18\x05 \x01(\x01R\awinRate\x12&\n" +
+	"\x0fpoints_per_game\x18\x06 \x01(\x01R\rpointsPerGame\x12\x1f\n" +
+	"\vgame_points\x18\a \x01(\x05R\n" +
+	"gamePoints\x12!\n" +
+	"\fskunks_given\x18\b \x01(\x05R\vskunksGiven\x12.\n" +
+	"\x13double_skunks_given\x18\t \x01(\x05R\x11doubleSkunksGiven\x12!\n" +
+	"\fskunks_taken\x18\n" +
+	" \x01(\x05R\vskunksTaken\x12.\n" +
+	"\x13double_skunks_taken\x18\v \x01(\x05R\x11doubleSkunksTaken\x12%\n" +
+	"\x0epoints_allowed\x18\f \x01(\x05R\rpointsAllowed\x12\x1a\n" +
+	"\btiebreak\x18\r \x01(\tR\btiebreak\x12&\n" +
+	"\x0fcoin_flipped_at\x18\x0e \x01(\tR\rcoinFlippedAt\x12*\n" +
+	"\x11coin_flip_pending\x18\x0f \x01(\bR\x0fcoinFlipPending\"\x15\n" +
+	"\x13GetStandingsRequest\"J\n" +
+	"\x14GetStandingsResponse\x122\n" +
+	"\tstandings\x18\x01 \x03(\v2\x14.cribbly.v1.StandingR\tstandings\"\x17\n" +
+	"\x15WatchStandingsRequest\"L\n" +
+	"\x16WatchStandingsResponse\x122\n" +
+	"\tstandings\x18\x01 \x03(\v2\x14.cribbly.v1.StandingR\tstandings2\xdb\x01\n" +
+	"\x10StandingsService\x12S\n" +
+	"\fGetStandings\x12\x1f.cribbly.v1.GetStandingsRequest\x1a .cribbly.v1.GetStandingsResponse\"\x00\x12r\n" +
+	"\x0eWatchStandings\x12!.cribbly.v1.WatchStandingsRequest\x1a\".cribbly.v1.WatchStandingsResponse\"\x17\x82\xce\x18\x13\n" +
+	"\x11/standings/stream0\x01BCZAgithub.com/cszczepaniak/cribbly/internal/gen/cribbly/v1;cribblyv1b\x06proto3"
+
+var (
+	file_cribbly_v1_standings_proto_rawDescOnce sync.Once
+	file_cribbly_v1_standings_proto_rawDescData []byte
+)
+
+func file_cribbly_v1_standings_proto_rawDescGZIP() []byte {
+	file_cribbly_v1_standings_proto_rawDescOnce.Do(func() {
+		file_cribbly_v1_standings_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cribbly_v1_standings_proto_rawDesc), len(file_cribbly_v1_standings_proto_rawDesc)))
+	})
+	return file_cribbly_v1_standings_proto_rawDescData
+}
+
+var file_cribbly_v1_standings_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_cribbly_v1_standings_proto_goTypes = []any{
+	(*Standing)(nil),               // 0: cribbly.v1.Standing
+	(*GetStandingsRequest)(nil),    // 1: cribbly.v1.GetStandingsRequest
+	(*GetStandingsResponse)(nil),   // 2: cribbly.v1.GetStandingsResponse
+	(*WatchStandingsRequest)(nil),  // 3: cribbly.v1.WatchStandingsRequest
+	(*WatchStandingsResponse)(nil), // 4: cribbly.v1.WatchStandingsResponse
+}
+var file_cribbly_v1_standings_proto_depIdxs = []int32{
+	0, // 0: cribbly.v1.GetStandingsResponse.standings:type_name -> cribbly.v1.Standing
+	0, // 1: cribbly.v1.WatchStandingsResponse.standings:type_name -> cribbly.v1.Standing
+	1, // 2: cribbly.v1.StandingsService.GetStandings:input_type -> cribbly.v1.GetStandingsRequest
+	3, // 3: cribbly.v1.StandingsService.WatchStandings:input_type -> cribbly.v1.WatchStandingsRequest
+	2, // 4: cribbly.v1.StandingsService.GetStandings:output_type -> cribbly.v1.GetStandingsResponse
+	4, // 5: cribbly.v1.StandingsService.WatchStandings:output_type -> cribbly.v1.WatchStandingsResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_cribbly_v1_standings_proto_init() }
+func file_cribbly_v1_standings_proto_init() {
+	if File_cribbly_v1_standings_proto != nil {
+		return
+	}
+	file_cribbly_v1_sse_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cribbly_v1_standings_proto_rawDesc), len(file_cribbly_v1_standings_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cribbly_v1_standings_proto_goTypes,
+		DependencyIndexes: file_cribbly_v1_standings_proto_depIdxs,
+		MessageInfos:      file_cribbly_v1_standings_proto_msgTypes,
+	}.Build()
+	File_cribbly_v1_standings_proto = out.File
+	file_cribbly_v1_standings_proto_goTypes = nil
+	file_cribbly_v1_standings_proto_depIdxs = nil
+}
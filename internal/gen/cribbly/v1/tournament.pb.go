@@ -0,0 +1,1708 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: cribbly/v1/tournament.proto
+
+package cribblyv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BracketFormat int32
+
+const (
+	// Treated as single elimination.
+	BracketFormat_BRACKET_FORMAT_UNSPECIFIED        BracketFormat = 0
+	BracketFormat_BRACKET_FORMAT_SINGLE_ELIMINATION BracketFormat = 1
+	BracketFormat_BRACKET_FORMAT_DOUBLE_ELIMINATION BracketFormat = 2
+)
+
+// Enum value maps for BracketFormat.
+var (
+	BracketFormat_name = map[int32]string{
+		0: "BRACKET_FORMAT_UNSPECIFIED",
+		1: "BRACKET_FORMAT_SINGLE_ELIMINATION",
+		2: "BRACKET_FORMAT_DOUBLE_ELIMINATION",
+	}
+	BracketFormat_value = map[string]int32{
+		"BRACKET_FORMAT_UNSPECIFIED":        0,
+		"BRACKET_FORMAT_SINGLE_ELIMINATION": 1,
+		"BRACKET_FORMAT_DOUBLE_ELIMINATION": 2,
+	}
+)
+
+func (x BracketFormat) Enum() *BracketFormat {
+	p := new(BracketFormat)
+	*p = x
+	return p
+}
+
+func (x BracketFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BracketFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_cribbly_v1_tournament_proto_enumTypes[0].Descriptor()
+}
+
+func (BracketFormat) Type() protoreflect.EnumType {
+	return &file_cribbly_v1_tournament_proto_enumTypes[0]
+}
+
+func (x BracketFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BracketFormat.Descriptor instead.
+func (BracketFormat) EnumDescriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{0}
+}
+
+type BracketSide int32
+
+const (
+	// Treated as the winners side.
+	BracketSide_BRACKET_SIDE_UNSPECIFIED BracketSide = 0
+	// The whole bracket in single elimination.
+	BracketSide_BRACKET_SIDE_WINNERS BracketSide = 1
+	BracketSide_BRACKET_SIDE_LOSERS  BracketSide = 2
+	// The grand final (round 0) and its reset game (round 1).
+	BracketSide_BRACKET_SIDE_FINAL BracketSide = 3
+	// The third-place game of a single-elimination bracket (round 0, idx 0).
+	BracketSide_BRACKET_SIDE_THIRD_PLACE BracketSide = 4
+)
+
+// Enum value maps for BracketSide.
+var (
+	BracketSide_name = map[int32]string{
+		0: "BRACKET_SIDE_UNSPECIFIED",
+		1: "BRACKET_SIDE_WINNERS",
+		2: "BRACKET_SIDE_LOSERS",
+		3: "BRACKET_SIDE_FINAL",
+		4: "BRACKET_SIDE_THIRD_PLACE",
+	}
+	BracketSide_value = map[string]int32{
+		"BRACKET_SIDE_UNSPECIFIED": 0,
+		"BRACKET_SIDE_WINNERS":     1,
+		"BRACKET_SIDE_LOSERS":      2,
+		"BRACKET_SIDE_FINAL":       3,
+		"BRACKET_SIDE_THIRD_PLACE": 4,
+	}
+)
+
+func (x BracketSide) Enum() *BracketSide {
+	p := new(BracketSide)
+	*p = x
+	return p
+}
+
+func (x BracketSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BracketSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_cribbly_v1_tournament_proto_enumTypes[1].Descriptor()
+}
+
+func (BracketSide) Type() protoreflect.EnumType {
+	return &file_cribbly_v1_tournament_proto_enumTypes[1]
+}
+
+func (x BracketSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BracketSide.Descriptor instead.
+func (BracketSide) EnumDescriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{1}
+}
+
+type BracketTeam struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BracketTeam) Reset() {
+	*x = BracketTeam{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BracketTeam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BracketTeam) ProtoMessage() {}
+
+func (x *BracketTeam) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BracketTeam.ProtoReflect.Descriptor instead.
+func (*BracketTeam) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BracketTeam) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BracketTeam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type BracketGame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Round int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Idx   int32                  `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
+	// Unset until the team for that slot has been decided.
+	Team1 *BracketTeam `protobuf:"bytes,3,opt,name=team1,proto3" json:"team1,omitempty"`
+	Team2 *BracketTeam `protobuf:"bytes,4,opt,name=team2,proto3" json:"team2,omitempty"`
+	// Unset until the game has been played.
+	Winner *BracketTeam `protobuf:"bytes,5,opt,name=winner,proto3" json:"winner,omitempty"`
+	// True for a first-round game with only team1, who advanced without playing. The winner is
+	// already set.
+	Bye  bool        `protobuf:"varint,6,opt,name=bye,proto3" json:"bye,omitempty"`
+	Side BracketSide `protobuf:"varint,7,opt,name=side,proto3,enum=cribbly.v1.BracketSide" json:"side,omitempty"`
+	// The number of games in the series; 1 for a single game.
+	BestOf int32 `protobuf:"varint,8,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	// The games of the series played so far, if their scores have been recorded.
+	Series []*SeriesGame `protobuf:"bytes,9,rep,name=series,proto3" json:"series,omitempty"`
+	// Results reported by the game's teams that are waiting to be confirmed, in the order of the
+	// teams. The game is decided once both teams agree or an admin advances a team.
+	Reports       []*BracketReport `protobuf:"bytes,10,rep,name=reports,proto3" json:"reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BracketGame) Reset() {
+	*x = BracketGame{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BracketGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BracketGame) ProtoMessage() {}
+
+func (x *BracketGame) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BracketGame.ProtoReflect.Descriptor instead.
+func (*BracketGame) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BracketGame) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BracketGame) GetIdx() int32 {
+	if x != nil {
+		return x.Idx
+	}
+	return 0
+}
+
+func (x *BracketGame) GetTeam1() *BracketTeam {
+	if x != nil {
+		return x.Team1
+	}
+	return nil
+}
+
+func (x *BracketGame) GetTeam2() *BracketTeam {
+	if x != nil {
+		return x.Team2
+	}
+	return nil
+}
+
+func (x *BracketGame) GetWinner() *BracketTeam {
+	if x != nil {
+		return x.Winner
+	}
+	return nil
+}
+
+func (x *BracketGame) GetBye() bool {
+	if x != nil {
+		return x.Bye
+	}
+	return false
+}
+
+func (x *BracketGame) GetSide() BracketSide {
+	if x != nil {
+		return x.Side
+	}
+	return BracketSide_BRACKET_SIDE_UNSPECIFIED
+}
+
+func (x *BracketGame) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+func (x *BracketGame) GetSeries() []*SeriesGame {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *BracketGame) GetReports() []*BracketReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type BracketReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Team  *BracketTeam           `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	// Whether the team says they won.
+	Won bool `protobuf:"varint,2,opt,name=won,proto3" json:"won,omitempty"`
+	// The loser's score, which the team reports along with who won.
+	LoserScore    int32 `protobuf:"varint,3,opt,name=loser_score,json=loserScore,proto3" json:"loser_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BracketReport) Reset() {
+	*x = BracketReport{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BracketReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BracketReport) ProtoMessage() {}
+
+func (x *BracketReport) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BracketReport.ProtoReflect.Descriptor instead.
+func (*BracketReport) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BracketReport) GetTeam() *BracketTeam {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+func (x *BracketReport) GetWon() bool {
+	if x != nil {
+		return x.Won
+	}
+	return false
+}
+
+func (x *BracketReport) GetLoserScore() int32 {
+	if x != nil {
+		return x.LoserScore
+	}
+	return 0
+}
+
+type SeriesGame struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team1Score    int32                  `protobuf:"varint,1,opt,name=team1_score,json=team1Score,proto3" json:"team1_score,omitempty"`
+	Team2Score    int32                  `protobuf:"varint,2,opt,name=team2_score,json=team2Score,proto3" json:"team2_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesGame) Reset() {
+	*x = SeriesGame{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesGame) ProtoMessage() {}
+
+func (x *SeriesGame) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesGame.ProtoReflect.Descriptor instead.
+func (*SeriesGame) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SeriesGame) GetTeam1Score() int32 {
+	if x != nil {
+		return x.Team1Score
+	}
+	return 0
+}
+
+func (x *SeriesGame) GetTeam2Score() int32 {
+	if x != nil {
+		return x.Team2Score
+	}
+	return 0
+}
+
+type BracketRound struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Games         []*BracketGame         `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BracketRound) Reset() {
+	*x = BracketRound{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BracketRound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BracketRound) ProtoMessage() {}
+
+func (x *BracketRound) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BracketRound.ProtoReflect.Descriptor instead.
+func (*BracketRound) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BracketRound) GetGames() []*BracketGame {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+type Bracket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty when the bracket hasn't been seeded. The last round is the final.
+	Rounds []*BracketRound `protobuf:"bytes,1,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	// Number of teams in the event, i.e. the most that can be seeded.
+	TeamCount int32 `protobuf:"varint,2,opt,name=team_count,json=teamCount,proto3" json:"team_count,omitempty"`
+	// Unset until the final has been played.
+	Champion *BracketTeam  `protobuf:"bytes,3,opt,name=champion,proto3" json:"champion,omitempty"`
+	Format   BracketFormat `protobuf:"varint,4,opt,name=format,proto3,enum=cribbly.v1.BracketFormat" json:"format,omitempty"`
+	// The losers side of a double-elimination bracket. The winners side is in rounds.
+	LosersRounds []*BracketRound `protobuf:"bytes,5,rep,name=losers_rounds,json=losersRounds,proto3" json:"losers_rounds,omitempty"`
+	// The grand final and reset game of a double-elimination bracket.
+	FinalRounds []*BracketRound `protobuf:"bytes,6,rep,name=final_rounds,json=finalRounds,proto3" json:"final_rounds,omitempty"`
+	// The game between the semifinal losers. Unset if the bracket doesn't have one.
+	ThirdPlace    *BracketGame `protobuf:"bytes,7,opt,name=third_place,json=thirdPlace,proto3" json:"third_place,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bracket) Reset() {
+	*x = Bracket{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bracket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bracket) ProtoMessage() {}
+
+func (x *Bracket) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bracket.ProtoReflect.Descriptor instead.
+func (*Bracket) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Bracket) GetRounds() []*BracketRound {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *Bracket) GetTeamCount() int32 {
+	if x != nil {
+		return x.TeamCount
+	}
+	return 0
+}
+
+func (x *Bracket) GetChampion() *BracketTeam {
+	if x != nil {
+		return x.Champion
+	}
+	return nil
+}
+
+func (x *Bracket) GetFormat() BracketFormat {
+	if x != nil {
+		return x.Format
+	}
+	return BracketFormat_BRACKET_FORMAT_UNSPECIFIED
+}
+
+func (x *Bracket) GetLosersRounds() []*BracketRound {
+	if x != nil {
+		return x.LosersRounds
+	}
+	return nil
+}
+
+func (x *Bracket) GetFinalRounds() []*BracketRound {
+	if x != nil {
+		return x.FinalRounds
+	}
+	return nil
+}
+
+func (x *Bracket) GetThirdPlace() *BracketGame {
+	if x != nil {
+		return x.ThirdPlace
+	}
+	return nil
+}
+
+type GetBracketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBracketRequest) Reset() {
+	*x = GetBracketRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBracketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBracketRequest) ProtoMessage() {}
+
+func (x *GetBracketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBracketRequest.ProtoReflect.Descriptor instead.
+func (*GetBracketRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{6}
+}
+
+type GetBracketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bracket       *Bracket               `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBracketResponse) Reset() {
+	*x = GetBracketResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBracketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBracketResponse) ProtoMessage() {}
+
+func (x *GetBracketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBracketResponse.ProtoReflect.Descriptor instead.
+func (*GetBracketResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBracketResponse) GetBracket() *Bracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+type SeedBracketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At least two and no larger than the number of teams. If it isn't a power of two, the top seeds
+	// get first-round byes.
+	Size int32 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	// Double elimination needs a power of two of at least four teams.
+	Format BracketFormat `protobuf:"varint,2,opt,name=format,proto3,enum=cribbly.v1.BracketFormat" json:"format,omitempty"`
+	// Adds a game between the semifinal losers. Needs at least four teams; single elimination only.
+	ThirdPlace    bool `protobuf:"varint,3,opt,name=third_place,json=thirdPlace,proto3" json:"third_place,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedBracketRequest) Reset() {
+	*x = SeedBracketRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedBracketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedBracketRequest) ProtoMessage() {}
+
+func (x *SeedBracketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedBracketRequest.ProtoReflect.Descriptor instead.
+func (*SeedBracketRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SeedBracketRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SeedBracketRequest) GetFormat() BracketFormat {
+	if x != nil {
+		return x.Format
+	}
+	return BracketFormat_BRACKET_FORMAT_UNSPECIFIED
+}
+
+func (x *SeedBracketRequest) GetThirdPlace() bool {
+	if x != nil {
+		return x.ThirdPlace
+	}
+	return false
+}
+
+type SeedBracketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bracket       *Bracket               `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedBracketResponse) Reset() {
+	*x = SeedBracketResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedBracketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedBracketResponse) ProtoMessage() {}
+
+func (x *SeedBracketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedBracketResponse.ProtoReflect.Descriptor instead.
+func (*SeedBracketResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SeedBracketResponse) GetBracket() *Bracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+type AdvanceTeamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The game the team won.
+	Round         int32       `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Idx           int32       `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
+	TeamId        string      `protobuf:"bytes,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Side          BracketSide `protobuf:"varint,4,opt,name=side,proto3,enum=cribbly.v1.BracketSide" json:"side,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdvanceTeamRequest) Reset() {
+	*x = AdvanceTeamRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdvanceTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvanceTeamRequest) ProtoMessage() {}
+
+func (x *AdvanceTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvanceTeamRequest.ProtoReflect.Descriptor instead.
+func (*AdvanceTeamRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AdvanceTeamRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *AdvanceTeamRequest) GetIdx() int32 {
+	if x != nil {
+		return x.Idx
+	}
+	return 0
+}
+
+func (x *AdvanceTeamRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *AdvanceTeamRequest) GetSide() BracketSide {
+	if x != nil {
+		return x.Side
+	}
+	return BracketSide_BRACKET_SIDE_UNSPECIFIED
+}
+
+type AdvanceTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bracket       *Bracket               `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdvanceTeamResponse) Reset() {
+	*x = AdvanceTeamResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdvanceTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvanceTeamResponse) ProtoMessage() {}
+
+func (x *AdvanceTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvanceTeamResponse.ProtoReflect.Descriptor instead.
+func (*AdvanceTeamResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AdvanceTeamResponse) GetBracket() *Bracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+type RevertAdvanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The game whose result should be undone.
+	Round         int32       `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Idx           int32       `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
+	TeamId        string      `protobuf:"bytes,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Side          BracketSide `protobuf:"varint,4,opt,name=side,proto3,enum=cribbly.v1.BracketSide" json:"side,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertAdvanceRequest) Reset() {
+	*x = RevertAdvanceRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertAdvanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertAdvanceRequest) ProtoMessage() {}
+
+func (x *RevertAdvanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertAdvanceRequest.ProtoReflect.Descriptor instead.
+func (*RevertAdvanceRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RevertAdvanceRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *RevertAdvanceRequest) GetIdx() int32 {
+	if x != nil {
+		return x.Idx
+	}
+	return 0
+}
+
+func (x *RevertAdvanceRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *RevertAdvanceRequest) GetSide() BracketSide {
+	if x != nil {
+		return x.Side
+	}
+	return BracketSide_BRACKET_SIDE_UNSPECIFIED
+}
+
+type RevertAdvanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bracket       *Bracket               `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertAdvanceResponse) Reset() {
+	*x = RevertAdvanceResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertAdvanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertAdvanceResponse) ProtoMessage() {}
+
+func (x *RevertAdvanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertAdvanceResponse.ProtoReflect.Descriptor instead.
+func (*RevertAdvanceResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RevertAdvanceResponse) GetBracket() *Bracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+type DeleteBracketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBracketRequest) Reset() {
+	*x = DeleteBracketRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBracketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBracketRequest) ProtoMessage() {}
+
+func (x *DeleteBracketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBracketRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBracketRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{14}
+}
+
+type DeleteBracketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBracketResponse) Reset() {
+	*x = DeleteBracketResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBracketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBracketResponse) ProtoMessage() {}
+
+func (x *DeleteBracketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBracketResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBracketResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{15}
+}
+
+type WatchBracketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBracketRequest) Reset() {
+	*x = WatchBracketRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBracketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBracketRequest) ProtoMessage() {}
+
+func (x *WatchBracketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBracketRequest.ProtoReflect.Descriptor instead.
+func (*WatchBracketRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{16}
+}
+
+type WatchBracketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bracket       *Bracket               `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBracketResponse) Reset() {
+	*x = WatchBracketResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBracketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBracketResponse) ProtoMessage() {}
+
+func (x *WatchBracketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBracketResponse.ProtoReflect.Descriptor instead.
+func (*WatchBracketResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchBracketResponse) GetBracket() *Bracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+type SetSeriesLengthRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Round int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Idx   int32                  `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
+	Side  BracketSide            `protobuf:"varint,3,opt,name=side,proto3,enum=cribbly.v1.BracketSide" json:"side,omitempty"`
+	// An odd number of games.
+	BestOf        int32 `protobuf:"varint,4,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSeriesLengthRequest) Reset() {
+	*x = SetSeriesLengthRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSeriesLengthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSeriesLengthRequest) ProtoMessage() {}
+
+func (x *SetSeriesLengthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSeriesLengthRequest.ProtoReflect.Descriptor instead.
+func (*SetSeriesLengthRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetSeriesLengthRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *SetSeriesLengthRequest) GetIdx() int32 {
+	if x != nil {
+		return x.Idx
+	}
+	return 0
+}
+
+func (x *SetSeriesLengthRequest) GetSide() BracketSide {
+	if x != nil {
+		return x.Side
+	}
+	return BracketSide_BRACKET_SIDE_UNSPECIFIED
+}
+
+func (x *SetSeriesLengthRequest) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+type SetSeriesLengthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bracket       *Bracket               `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSeriesLengthResponse) Reset() {
+	*x = SetSeriesLengthResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSeriesLengthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSeriesLengthResponse) ProtoMessage() {}
+
+func (x *SetSeriesLengthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSeriesLengthResponse.ProtoReflect.Descriptor instead.
+func (*SetSeriesLengthResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetSeriesLengthResponse) GetBracket() *Bracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+type RecordSeriesGameRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Round int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Idx   int32                  `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
+	Side  BracketSide            `protobuf:"varint,3,opt,name=side,proto3,enum=cribbly.v1.BracketSide" json:"side,omitempty"`
+	// The team that reached 121.
+	WinnerTeamId string `protobuf:"bytes,4,opt,name=winner_team_id,json=winnerTeamId,proto3" json:"winner_team_id,omitempty"`
+	// Between 1 and 120.
+	LoserScore    int32 `protobuf:"varint,5,opt,name=loser_score,json=loserScore,proto3" json:"loser_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordSeriesGameRequest) Reset() {
+	*x = RecordSeriesGameRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordSeriesGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSeriesGameRequest) ProtoMessage() {}
+
+func (x *RecordSeriesGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSeriesGameRequest.ProtoReflect.Descriptor instead.
+func (*RecordSeriesGameRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RecordSeriesGameRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *RecordSeriesGameRequest) GetIdx() int32 {
+	if x != nil {
+		return x.Idx
+	}
+	return 0
+}
+
+func (x *RecordSeriesGameRequest) GetSide() BracketSide {
+	if x != nil {
+		return x.Side
+	}
+	return BracketSide_BRACKET_SIDE_UNSPECIFIED
+}
+
+func (x *RecordSeriesGameRequest) GetWinnerTeamId() string {
+	if x != nil {
+		return x.WinnerTeamId
+	}
+	return ""
+}
+
+func (x *RecordSeriesGameRequest) GetLoserScore() int32 {
+	if x != nil {
+		return x.LoserScore
+	}
+	return 0
+}
+
+type RecordSeriesGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bracket       *Bracket               `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordSeriesGameResponse) Reset() {
+	*x = RecordSeriesGameResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordSeriesGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSeriesGameResponse) ProtoMessage() {}
+
+func (x *RecordSeriesGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSeriesGameResponse.ProtoReflect.Descriptor instead.
+func (*RecordSeriesGameResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RecordSeriesGameResponse) GetBracket() *Bracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+type UndoSeriesGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Round         int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Idx           int32                  `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
+	Side          BracketSide            `protobuf:"varint,3,opt,name=side,proto3,enum=cribbly.v1.BracketSide" json:"side,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndoSeriesGameRequest) Reset() {
+	*x = UndoSeriesGameRequest{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoSeriesGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoSeriesGameRequest) ProtoMessage() {}
+
+func (x *UndoSeriesGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoSeriesGameRequest.ProtoReflect.Descriptor instead.
+func (*UndoSeriesGameRequest) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UndoSeriesGameRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *UndoSeriesGameRequest) GetIdx() int32 {
+	if x != nil {
+		return x.Idx
+	}
+	return 0
+}
+
+func (x *UndoSeriesGameRequest) GetSide() BracketSide {
+	if x != nil {
+		return x.Side
+	}
+	return BracketSide_BRACKET_SIDE_UNSPECIFIED
+}
+
+type UndoSeriesGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bracket       *Bracket               `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndoSeriesGameResponse) Reset() {
+	*x = UndoSeriesGameResponse{}
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoSeriesGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoSeriesGameResponse) ProtoMessage() {}
+
+func (x *UndoSeriesGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cribbly_v1_tournament_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoSeriesGameResponse.ProtoReflect.Descriptor instead.
+func (*UndoSeriesGameResponse) Descriptor() ([]byte, []int) {
+	return file_cribbly_v1_tournament_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UndoSeriesGameResponse) GetBracket() *Bracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+var File_cribbly_v1_tournament_proto protoreflect.FileDescriptor
+
+const file_cribbly_v1_tournament_proto_rawDesc = "" +
+	"\n" +
+	"\x1bcribbly/v1/tournament.proto\x12\n" +
+	"cribbly.v1\x1a\x14cribbly/v1/sse.proto\"1\n" +
+	"\vBracketTeam\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x81\x03\n" +
+	"\vBracketGame\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12\x10\n" +
+	"\x03idx\x18\x02 \x01(\x05R\x03idx\x12-\n" +
+	"\x05team1\x18\x03 \x01(\v2\x17.cribbly.v1.BracketTeamR\x05team1\x12-\n" +
+	"\x05team2\x18\x04 \x01(\v2\x17.cribbly.v1.BracketTeamR\x05team2\x12/\n" +
+	"\x06winner\x18\x05 \x01(\v2\x17.cribbly.v1.BracketTeamR\x06winner\x12\x10\n" +
+	"\x03bye\x18\x06 \x01(\bR\x03bye\x12+\n" +
+	"\x04side\x18\a \x01(\x0e2\x17.cribbly.v1.BracketSideR\x04side\x12\x17\n" +
+	"\abest_of\x18\b \x01(\x05R\x06bestOf\x12.\n" +
+	"\x06series\x18\t \x03(\v2\x16.cribbly.v1.SeriesGameR\x06series\x123\n" +
+	"\areports\x18\n" +
+	" \x03(\v2\x19.cribbly.v1.BracketReportR\areports\"o\n" +
+	"\rBracketReport\x12+\n" +
+	"\x04team\x18\x01 \x01(\v2\x17.cribbly.v1.BracketTeamR\x04team\x12\x10\n" +
+	"\x03won\x18\x02 \x01(\bR\x03won\x12\x1f\n" +
+	"\vloser_score\x18\x03 \x01(\x05R\n" +
+	"loserScore\"N\n" +
+	"\n" +
+	"SeriesGame\x12\x1f\n" +
+	"\vteam1_score\x18\x01 \x01(\x05R\n" +
+	"team1Score\x12\x1f\n" +
+	"\vteam2_score\x18\x02 \x01(\x05R\n" +
+	"team2Score\"=\n" +
+	"\fBracketRound\x12-\n" +
+	"\x05games\x18\x01 \x03(\v2\x17.cribbly.v1.BracketGameR\x05games\"\xf8\x02\n" +
+	"\aBracket\x120\n" +
+	"\x06rounds\x18\x01 \x03(\v2\x18.cribbly.v1.BracketRoundR\x06rounds\x12\x1d\n" +
+	"\n" +
+	"team_count\x18\x02 \x01(\x05R\tteamCount\x123\n" +
+	"\bchampion\x18\x03 \x01(\v2\x17.cribbly.v1.BracketTeamR\bchampion\x121\n" +
+	"\x06format\x18\x04 \x01(\x0e2\x19.cribbly.v1.BracketFormatR\x06format\x12=\n" +
+	"\rlosers_rounds\x18\x05 \x03(\v2\x18.cribbly.v1.BracketRoundR\flosersRounds\x12;\n" +
+	"\ffinal_rounds\x18\x06 \x03(\v2\x18.cribbly.v1.BracketRoundR\vfinalRounds\x128\n" +
+	"\vthird_place\x18\a \x01(\v2\x17.cribbly.v1.BracketGameR\n" +
+	"thirdPlace\"\x13\n" +
+	"\x11GetBracketRequest\"C\n" +
+	"\x12GetBracketResponse\x12-\n" +
+	"\abracket\x18\x01 \x01(\v2\x13.cribbly.v1.BracketR\abracket\"|\n" +
+	"\x12SeedBracketRequest\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x05R\x04size\x121\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x19.cribbly.v1.BracketFormatR\x06format\x12\x1f\n" +
+	"\vthird_place\x18\x03 \x01(\bR\n" +
+	"thirdPlace\"D\n" +
+	"\x13SeedBracketResponse\x12-\n" +
+	"\abracket\x18\x01 \x01(\v2\x13.cribbly.v1.BracketR\abracket\"\x82\x01\n" +
+	"\x12AdvanceTeamRequest\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12\x10\n" +
+	"\x03idx\x18\x02 \x01(\x05R\x03idx\x12\x17\n" +
+	"\ateam_id\x18\x03 \x01(\tR\x06teamId\x12+\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x17.cribbly.v1.BracketSideR\x04side\"D\n" +
+	"\x13AdvanceTeamResponse\x12-\n" +
+	"\abracket\x18\x01 \x01(\v2\x13.cribbly.v1.BracketR\abracket\"\x84\x01\n" +
+	"\x14RevertAdvanceRequest\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12\x10\n" +
+	"\x03idx\x18\x02 \x01(\x05R\x03idx\x12\x17\n" +
+	"\ateam_id\x18\x03 \x01(\tR\x06teamId\x12+\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x17.cribbly.v1.BracketSideR\x04side\"F\n" +
+	"\x15RevertAdvanceResponse\x12-\n" +
+	"\abracket\x18\x01 \x01(\v2\x13.cribbly.v1.BracketR\abracket\"\x16\n" +
+	"\x14DeleteBracketRequest\"\x17\n" +
+	"\x15DeleteBracketResponse\"\x15\n" +
+	"\x13WatchBracketRequest\"E\n" +
+	"\x14WatchBracketResponse\x12-\n" +
+	"\abracket\x18\x01 \x01(\v2\x13.cribbly.v1.BracketR\abracket\"\x86\x01\n" +
+	"\x16SetSeriesLengthRequest\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12\x10\n" +
+	"\x03idx\x18\x02 \x01(\x05R\x03idx\x12+\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x17.cribbly.v1.BracketSideR\x04side\x12\x17\n" +
+	"\abest_of\x18\x04 \x01(\x05R\x06bestOf\"H\n" +
+	"\x17SetSeriesLengthResponse\x12-\n" +
+	"\abracket\x18\x01 \x01(\v2\x13.cribbly.v1.BracketR\abracket\"\xb5\x01\n" +
+	"\x17RecordSeriesGameRequest\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12\x10\n" +
+	"\x03idx\x18\x02 \x01(\x05R\x03idx\x12+\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x17.cribbly.v1.BracketSideR\x04side\x12$\n" +
+	"\x0ewinner_team_id\x18\x04 \x01(\tR\fwinnerTeamId\x12\x1f\n" +
+	"\vloser_score\x18\x05 \x01(\x05R\n" +
+	"loserScore\"I\n" +
+	"\x18RecordSeriesGameResponse\x12-\n" +
+	"\abracket\x18\x01 \x01(\v2\x13.cribbly.v1.BracketR\abracket\"l\n" +
+	"\x15UndoSeriesGameRequest\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12\x10\n" +
+	"\x03idx\x18\x02 \x01(\x05R\x03idx\x12+\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x17.cribbly.v1.BracketSideR\x04side\"G\n" +
+	"\x16UndoSeriesGameResponse\x12-\n" +
+	"\abracket\x18\x01 \x01(\v2\x13.cribbly.v1.BracketR\abracket*}\n" +
+	"\rBracketFormat\x12\x1e\n" +
+	"\x1aBRACKET_FORMAT_UNSPECIFIED\x10\x00\x12%\n" +
+	"!BRACKET_FORMAT_SINGLE_ELIMINATION\x10\x01\x12%\n" +
+	"!BRACKET_FORMAT_DOUBLE_ELIMINATION\x10\x02*\x94\x01\n" +
+	"\vBracketSide\x12\x1c\n" +
+	"\x18BRACKET_SIDE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14BRACKET_SIDE_WINNERS\x10\x01\x12\x17\n" +
+	"\x13BRACKET_SIDE_LOSERS\x10\x02\x12\x16\n" +
+	"\x12BRACKET_SIDE_FINAL\x10\x03\x12\x1c\n" +
+	"\x18BRACKET_SIDE_THIRD_PLACE\x10\x042\xbf\x06\n" +
+	"\x11TournamentService\x12M\n" +
+	"\n" +
+	"GetBracket\x12\x1d.cribbly.v1.GetBracketRequest\x1a\x1e.cribbly.v1.GetBracketResponse\"\x00\x12P\n" +
+	"\vSeedBracket\x12\x1e.cribbly.v1.SeedBracketRequest\x1a\x1f.cribbly.v1.SeedBracketResponse\"\x00\x12P\n" +
+	"\vAdvanceTeam\x12\x1e.cribbly.v1.AdvanceTeamRequest\x1a\x1f.cribbly.v1.AdvanceTeamResponse\"\x00\x12V\n" +
+	"\rRevertAdvance\x12 .cribbly.v1.RevertAdvanceRequest\x1a!.cribbly.v1.RevertAdvanceResponse\"\x00\x12V\n" +
+	"\rDeleteBracket\x12 .cribbly.v1.DeleteBracketRequest\x1a!.cribbly.v1.DeleteBracketResponse\"\x00\x12\\\n" +
+	"\x0fSetSeriesLength\x12\".cribbly.v1.SetSeriesLengthRequest\x1a#.cribbly.v1.SetSeriesLengthResponse\"\x00\x12_\n" +
+	"\x10RecordSeriesGame\x12#.cribbly.v1.RecordSeriesGameRequest\x1a$.cribbly.v1.RecordSeriesGameResponse\"\x00\x12Y\n" +
+	"\x0eUndoSeriesGame\x12!.cribbly.v1.UndoSeriesGameRequest\x1a\".cribbly.v1.UndoSeriesGameResponse\"\x00\x12m\n" +
+	"\fWatchBracket\x12\x1f.cribbly.v1.WatchBracketRequest\x1a .cribbly.v1.WatchBracketResponse\"\x18\x82\xce\x18\x14\n" +
+	"\x12/tournament/stream0\x01BCZAgithub.com/cszczepaniak/cribbly/internal/gen/cribbly/v1;cribblyv1b\x06proto3"
+
+var (
+	file_cribbly_v1_tournament_proto_rawDescOnce sync.Once
+	file_cribbly_v1_tournament_proto_rawDescData []byte
+)
+
+func file_cribbly_v1_tournament_proto_rawDescGZIP() []byte {
+	file_cribbly_v1_tournament_proto_rawDescOnce.Do(func() {
+		file_cribbly_v1_tournament_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cribbly_v1_tournament_proto_rawDesc), len(file_cribbly_v1_tournament_proto_rawDesc)))
+	})
+	return file_cribbly_v1_tournament_proto_rawDescData
+}
+
+var file_cribbly_v1_tournament_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_cribbly_v1_tournament_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_cribbly_v1_tournament_proto_goTypes = []any{
+	(BracketFormat)(0),               // 0: cribbly.v1.BracketFormat
+	(BracketSide)(0),                 // 1: cribbly.v1.BracketSide
+	(*BracketTeam)(nil),              // 2: cribbly.v1.BracketTeam
+	(*BracketGame)(nil),              // 3: cribbly.v1.BracketGame
+	(*BracketReport)(nil),            // 4: cribbly.v1.BracketReport
+	(*SeriesGame)(nil),               // 5: cribbly.v1.SeriesGame
+	(*BracketRound)(nil),             // 6: cribbly.v1.BracketRound
+	(*Bracket)(nil),                  // 7: cribbly.v1.Bracket
+	(*GetBracketRequest)(nil),        // 8: cribbly.v1.GetBracketRequest
+	(*GetBracketResponse)(nil),       // 9: cribbly.v1.GetBracketResponse
+	(*SeedBracketRequest)(nil),       // 10: cribbly.v1.SeedBracketRequest
+	(*SeedBracketResponse)(nil),      // 11: cribbly.v1.SeedBracketResponse
+	(*AdvanceTeamRequest)(nil),       // 12: cribbly.v1.AdvanceTeamRequest
+	(*AdvanceTeamResponse)(nil),      // 13: cribbly.v1.AdvanceTeamResponse
+	(*RevertAdvanceRequest)(nil),     // 14: cribbly.v1.RevertAdvanceRequest
+	(*RevertAdvanceResponse)(nil),    // 15: cribbly.v1.RevertAdvanceResponse
+	(*DeleteBracketRequest)(nil),     // 16: cribbly.v1.DeleteBracketRequest
+	(*DeleteBracketResponse)(nil),    // 17: cribbly.v1.DeleteBracketResponse
+	(*WatchBracketRequest)(nil),      // 18: cribbly.v1.WatchBracketRequest
+	(*WatchBracketResponse)(nil),     // 19: cribbly.v1.WatchBracketResponse
+	(*SetSeriesLengthRequest)(nil),   // 20: cribbly.v1.SetSeriesLengthRequest
+	(*SetSeriesLengthResponse)(nil),  // 21: cribbly.v1.SetSeriesLengthResponse
+	(*RecordSeriesGameRequest)(nil),  // 22: cribbly.v1.RecordSeriesGameRequest
+	(*RecordSeriesGameResponse)(nil), // 23: cribbly.v1.RecordSeriesGameResponse
+	(*UndoSeriesGameRequest)(nil),    // 24: cribbly.v1.UndoSeriesGameRequest
+	(*UndoSeriesGameResponse)(nil),   // 25: cribbly.v1.UndoSeriesGameResponse
+}
+var file_cribbly_v1_tournament_proto_depIdxs = []int32{
+	2,  // 0: cribbly.v1.BracketGame.team1:type_name -> cribbly.v1.BracketTeam
+	2,  // 1: cribbly.v1.BracketGame.team2:type_name -> cribbly.v1.BracketTeam
+	2,  // 2: cribbly.v1.BracketGame.winner:type_name -> cribbly.v1.BracketTeam
+	1,  // 3: cribbly.v1.BracketGame.side:type_name -> cribbly.v1.BracketSide
+	5,  // 4: cribbly.v1.BracketGame.series:type_name -> cribbly.v1.SeriesGame
+	4,  // 5: cribbly.v1.BracketGame.reports:type_name -> cribbly.v1.BracketReport
+	2,  // 6: cribbly.v1.BracketReport.team:type_name -> cribbly.v1.BracketTeam
+	3,  // 7: cribbly.v1.BracketRound.games:type_name -> cribbly.v1.BracketGame
+	6,  // 8: cribbly.v1.Bracket.rounds:type_name -> cribbly.v1.BracketRound
+	2,  // 9: cribbly.v1.Bracket.champion:type_name -> cribbly.v1.BracketTeam
+	0,  // 10: cribbly.v1.Bracket.format:type_name -> cribbly.v1.BracketFormat
+	6,  // 11: cribbly.v1.Bracket.losers_rounds:type_name -> cribbly.v1.BracketRound
+	6,  // 12: cribbly.v1.Bracket.final_rounds:type_name -> cribbly.v1.BracketRound
+	3,  // 13: cribbly.v1.Bracket.third_place:type_name -> cribbly.v1.BracketGame
+	7,  // 14: cribbly.v1.GetBracketResponse.bracket:type_name -> cribbly.v1.Bracket
+	0,  // 15: cribbly.v1.SeedBracketRequest.format:type_name -> cribbly.v1.BracketFormat
+	7,  // 16: cribbly.v1.SeedBracketResponse.bracket:type_name -> cribbly.v1.Bracket
+	1,  // 17: cribbly.v1.AdvanceTeamRequest.side:type_name -> cribbly.v1.BracketSide
+	7,  // 18: cribbly.v1.AdvanceTeamResponse.bracket:type_name -> cribbly.v1.Bracket
+	1,  // 19: cribbly.v1.RevertAdvanceRequest.side:type_name -> cribbly.v1.BracketSide
+	7,  // 20: cribbly.v1.RevertAdvanceResponse.bracket:type_name -> cribbly.v1.Bracket
+	7,  // 21: cribbly.v1.WatchBracketResponse.bracket:type_name -> cribbly.v1.Bracket
+	1,  // 22: cribbly.v1.SetSeriesLengthRequest.side:type_name -> cribbly.v1.BracketSide
+	7,  // 23: cribbly.v1.SetSeriesLengthResponse.bracket:type_name -> cribbly.v1.Bracket
+	1,  // 24: cribbly.v1.RecordSeriesGameRequest.side:type_name -> cribbly.v1.BracketSide
+	7,  // 25: cribbly.v1.RecordSeriesGameResponse.bracket:type_name -> cribbly.v1.Bracket
+	1,  // 26: cribbly.v1.UndoSeriesGameRequest.side:type_name -> cribbly.v1.BracketSide
+	7,  // 27: cribbly.v1.UndoSeriesGameResponse.bracket:type_name -> cribbly.v1.Bracket
+	8,  // 28: cribbly.v1.TournamentService.GetBracket:input_type -> cribbly.v1.GetBracketRequest
+	10, // 29: cribbly.v1.TournamentService.SeedBracket:input_type -> cribbly.v1.SeedBracketRequest
+	12, // 30: cribbly.v1.TournamentService.AdvanceTeam:input_type -> cribbly.v1.AdvanceTeamRequest
+	14, // 31: cribbly.v1.TournamentService.RevertAdvance:input_type -> cribbly.v1.RevertAdvanceRequest
+	16, // 32: cribbly.v1.TournamentService.DeleteBracket:input_type -> cribbly.v1.DeleteBracketRequest
+	20, // 33: cribbly.v1.TournamentService.SetSeriesLength:input_type -> cribbly.v1.SetSeriesLengthRequest
+	22, // 34: cribbly.v1.TournamentService.RecordSeriesGame:input_type -> cribbly.v1.RecordSeriesGameRequest
+	24, // 35: cribbly.v1.TournamentService.UndoSeriesGame:input_type -> cribbly.v1.UndoSeriesGameRequest
+	18, // 36: cribbly.v1.TournamentService.WatchBracket:input_type -> cribbly.v1.WatchBracketRequest
+	9,  // 37: cribbly.v1.TournamentService.GetBracket:output_type -> cribbly.v1.GetBracketResponse
+	11, // 38: cribbly.v1.TournamentService.SeedBracket:output_type -> cribbly.v1.SeedBracketResponse
+	13, // 39: cribbly.v1.TournamentService.AdvanceTeam:output_type -> cribbly.v1.AdvanceTeamResponse
+	15, // 40: cribbly.v1.TournamentService.RevertAdvance:output_type -> cribbly.v1.RevertAdvanceResponse
+	17, // 41: cribbly.v1.TournamentService.DeleteBracket:output_type -> cribbly.v1.DeleteBracketResponse
+	21, // 42: cribbly.v1.TournamentService.SetSeriesLength:output_type -> cribbly.v1.SetSeriesLengthResponse
+	23, // 43: cribbly.v1.TournamentService.RecordSeriesGame:output_type -> cribbly.v1.RecordSeriesGameResponse
+	25, // 44: cribbly.v1.TournamentService.UndoSeriesGame:output_type -> cribbly.v1.UndoSeriesGameResponse
+	19, // 45: cribbly.v1.TournamentService.WatchBracket:output_type -> cribbly.v1.WatchBracketResponse
+	37, // [37:46] is the sub-list for method output_type
+	28, // [28:37] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_cribbly_v1_tournament_proto_init() }
+func file_cribbly_v1_tournament_proto_init() {
+	if File_cribbly_v1_tournament_proto != nil {
+		return
+	}
+	file_cribbly_v1_sse_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cribbly_v1_tournament_proto_rawDesc), len(file_cribbly_v1_tournament_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cribbly_v1_tournament_proto_goTypes,
+		DependencyIndexes: file_cribbly_v1_tournament_proto_depIdxs,
+		EnumInfos:         file_cribbly_v1_tournament_proto_enumTypes,
+		MessageInfos:      file_cribbly_v1_tournament_proto_msgTypes,
+	}.Build()
+	File_cribbly_v1_tournament_proto = out.File
+	file_cribbly_v1_tournament_proto_goTypes = nil
+	file_cribbly_v1_tournament_proto_depIdxs = nil
+}
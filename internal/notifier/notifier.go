@@ -1,53 +1,215 @@
 package notifier
 
 import (
+	"fmt"
+	"slices"
 	"sync"
-
-	"github.com/google/uuid"
+	"time"
 )
 
-type subscription struct {
-	ch chan struct{}
-	wg sync.WaitGroup
+// DefaultBufferSize is the number of undelivered events a subscription holds when the notifier's
+// BufferSize is zero.
+const DefaultBufferSize = 16
+
+// DefaultHistorySize is the number of past events a notifier keeps for Since when its HistorySize
+// is zero.
+const DefaultHistorySize = 256
+
+// epoch tells this process's event IDs apart from those of earlier ones, whose counters also
+// started from 1.
+var epoch = time.Now().UnixNano()
+
+// ID identifies an event. IDs are only unique within one notifier.
+type ID struct {
+	// Epoch is when the process that published the event started.
+	Epoch int64
+	// Seq increases by one with every call to Notify, starting from 1.
+	Seq uint64
+}
+
+// String formats the ID as "<epoch>-<seq>"; see ParseID.
+func (id ID) String() string {
+	return fmt.Sprintf("%d-%d", id.Epoch, id.Seq)
+}
+
+// ParseID parses an ID formatted by ID.String.
+func ParseID(s string) (ID, error) {
+	var id ID
+	_, err := fmt.Sscanf(s, "%d-%d", &id.Epoch, &id.Seq)
+	if err != nil {
+		return ID{}, fmt.Errorf("invalid event ID %q: %w", s, err)
+	}
+	if s != id.String() {
+		return ID{}, fmt.Errorf("invalid event ID %q", s)
+	}
+	return id, nil
+}
+
+// Event is a single notification delivered to a subscriber.
+type Event[T any] struct {
+	ID ID
+	// Topics are the topics the change was published to.
+	Topics []string
+	// Change describes what changed.
+	Change T
+	// Dropped is the number of earlier events this subscriber missed because it fell behind. When
+	// it is non-zero, Change is only the latest of several changes and the subscriber should
+	// reload whatever it is watching instead of applying Change on its own.
+	Dropped int
 }
 
-type Notifier struct {
+type subscription[T any] struct {
+	ch chan Event[T]
+	// topics is empty for subscriptions to every topic.
+	topics []string
+}
+
+func (s *subscription[T]) matches(topics []string) bool {
+	if len(s.topics) == 0 {
+		return true
+	}
+	for _, t := range topics {
+		if slices.Contains(s.topics, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Notifier fans changes out to subscribers. Each change is published to one or more topics (the
+// score notifier, for example, publishes a game's new scores to the game, its teams' divisions, and
+// its event) and a subscriber only hears about the topics it asked for.
+//
+// Subscribers are expected to apply each Change to what they're watching. Notify never blocks,
+// though: each subscription has a buffer of BufferSize events, and if a subscriber lets it fill up,
+// the pending events are discarded and replaced by the newest one, with Event.Dropped saying how
+// many were lost. A slow subscriber therefore reloads once rather than working through a backlog of
+// stale changes.
+//
+// The notifier also keeps the last HistorySize events so that a client that reconnects can ask
+// for what it missed with Since.
+//
+// The zero value is ready to use.
+type Notifier[T any] struct {
+	// BufferSize is the per-subscription buffer; DefaultBufferSize if zero.
+	BufferSize int
+	// HistorySize is the number of past events kept for Since; DefaultHistorySize if zero.
+	HistorySize int
+
 	mu            sync.Mutex
-	subscriptions map[string]*subscription
+	subscriptions map[*subscription[T]]struct{}
+	lastID        uint64
+	// history holds the most recent events in ID order.
+	history []Event[T]
+}
+
+// LastID returns the ID of the most recent event. Its Seq is 0 if nothing has been published yet.
+func (n *Notifier[T]) LastID() ID {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return ID{Epoch: epoch, Seq: n.lastID}
 }
 
-func (n *Notifier) Subscribe() (<-chan struct{}, func()) {
-	id := uuid.NewString()
-	ch := make(chan struct{})
+// Since returns the events published after the one with the given ID to any of the given topics
+// (or to every topic if none are given). It returns false if it can't tell what was missed: id is
+// from another process, such as the one before a restart, or the events after it have already
+// been dropped from the history. The caller should then resync from a full snapshot.
+//
+// To resume a stream without a gap, Subscribe first, then call Since, and skip events from the
+// subscription whose Seq is not newer than that of the last one Since returned.
+func (n *Notifier[T]) Since(id ID, topics ...string) ([]Event[T], bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if id.Epoch != epoch || id.Seq > n.lastID {
+		return nil, false
+	}
+	oldest := n.lastID + 1
+	if len(n.history) > 0 {
+		oldest = n.history[0].ID.Seq
+	}
+	if id.Seq+1 < oldest {
+		return nil, false
+	}
+
+	sub := subscription[T]{topics: topics}
+	var res []Event[T]
+	for _, ev := range n.history {
+		if ev.ID.Seq > id.Seq && sub.matches(ev.Topics) {
+			res = append(res, ev)
+		}
+	}
+	return res, true
+}
+
+// Subscribe returns a channel of the events published to any of the given topics, or to every
+// topic if none are given, and a function that ends the subscription and closes the channel.
+func (n *Notifier[T]) Subscribe(topics ...string) (<-chan Event[T], func()) {
+	size := n.BufferSize
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	sub := &subscription[T]{ch: make(chan Event[T], size), topics: slices.Clone(topics)}
 
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
 	if n.subscriptions == nil {
-		n.subscriptions = make(map[string]*subscription)
+		n.subscriptions = make(map[*subscription[T]]struct{})
 	}
-	n.subscriptions[id] = &subscription{ch: ch}
+	n.subscriptions[sub] = struct{}{}
 
-	return ch, func() {
+	return sub.ch, func() {
 		n.mu.Lock()
 		defer n.mu.Unlock()
 
-		sub, ok := n.subscriptions[id]
-		if ok {
-			// wait for any in-flight notifications to finish
-			sub.wg.Wait()
+		if _, ok := n.subscriptions[sub]; ok {
 			close(sub.ch)
-			delete(n.subscriptions, id)
+			delete(n.subscriptions, sub)
 		}
 	}
 }
 
-func (n *Notifier) Notify() {
+// Notify publishes change to the given topics. Subscribers to more than one of them still get it
+// only once.
+func (n *Notifier[T]) Notify(change T, topics ...string) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	for _, sub := range n.subscriptions {
-		sub.wg.Go(func() {
-			sub.ch <- struct{}{}
-		})
+
+	n.lastID++
+	published := Event[T]{ID: ID{Epoch: epoch, Seq: n.lastID}, Topics: topics, Change: change}
+
+	size := n.HistorySize
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	if len(n.history) >= size {
+		n.history = slices.Delete(n.history, 0, len(n.history)-size+1)
+	}
+	n.history = append(n.history, published)
+
+	for sub := range n.subscriptions {
+		if !sub.matches(topics) {
+			continue
+		}
+
+		ev := published
+		select {
+		case sub.ch <- ev:
+			continue
+		default:
+		}
+
+		// The buffer is full. Only Notify sends on the channel and we hold the lock, so once
+		// it's drained there is room for the new event.
+		for drained := false; !drained; {
+			select {
+			case old := <-sub.ch:
+				ev.Dropped += 1 + old.Dropped
+			default:
+				drained = true
+			}
+		}
+		sub.ch <- ev
 	}
 }
@@ -10,7 +10,7 @@ import (
 
 func TestNotifier(t *testing.T) {
 	synctest.Test(t, func(t *testing.T) {
-		n := &Notifier{}
+		n := &Notifier[struct{}]{}
 
 		var x []int
 		go func() {
@@ -32,14 +32,14 @@ func TestNotifier(t *testing.T) {
 		// Make sure the goroutines are durable blocked on their channel receives first
 		synctest.Wait()
 
-		n.Notify()
+		n.Notify(struct{}{})
 		synctest.Wait()
 
 		slices.Sort(x)
 		assert.Equal(t, []int{1, 2}, x)
 
 		// Should be able to notify after the subscriptions are done listening, nothing happens
-		n.Notify()
+		n.Notify(struct{}{})
 
 		// Test receiving multiple notifications
 		x = x[:0]
@@ -59,7 +59,7 @@ func TestNotifier(t *testing.T) {
 
 		synctest.Wait()
 
-		n.Notify()
+		n.Notify(struct{}{})
 		synctest.Wait()
 
 		slices.Sort(x)
@@ -81,16 +81,149 @@ func TestNotifier(t *testing.T) {
 
 		synctest.Wait()
 
-		n.Notify()
+		n.Notify(struct{}{})
 		synctest.Wait()
 
 		slices.Sort(x)
 		assert.Equal(t, []int{1, 1, 2}, x)
 
-		n.Notify()
+		n.Notify(struct{}{})
 		synctest.Wait()
 
 		slices.Sort(x)
 		assert.Equal(t, []int{1, 1, 1, 2, 2}, x)
 	})
 }
+
+func TestNotifier_Topics(t *testing.T) {
+	n := &Notifier[int]{}
+
+	all, cancelAll := n.Subscribe()
+	t.Cleanup(cancelAll)
+	a, cancelA := n.Subscribe("a")
+	t.Cleanup(cancelA)
+	ab, cancelAB := n.Subscribe("a", "b")
+	t.Cleanup(cancelAB)
+
+	n.Notify(1, "a")
+	n.Notify(2, "b")
+	n.Notify(3, "a", "b")
+	n.Notify(4, "c")
+
+	receive := func(ch <-chan Event[int]) []int {
+		var res []int
+		for {
+			select {
+			case ev := <-ch:
+				res = append(res, ev.Change)
+			default:
+				return res
+			}
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, receive(all))
+	assert.Equal(t, []int{1, 3}, receive(a))
+	// Delivered once even though it was published to both subscribed topics.
+	assert.Equal(t, []int{1, 2, 3}, receive(ab))
+}
+
+func TestNotifier_FullBufferCoalesces(t *testing.T) {
+	n := &Notifier[int]{BufferSize: 2}
+
+	ch, cancel := n.Subscribe()
+
+	n.Notify(1, "x")
+	n.Notify(2, "x")
+	// The buffer is full; 1 and 2 are replaced by 3.
+	n.Notify(3, "x")
+
+	ev := <-ch
+	assert.Equal(t, Event[int]{ID: ID{Epoch: epoch, Seq: 3}, Topics: []string{"x"}, Change: 3, Dropped: 2}, ev)
+
+	n.Notify(4, "x")
+	n.Notify(5, "x")
+	n.Notify(6, "x")
+	n.Notify(7, "x")
+	// 4 and 5 were replaced by 6, so the buffer holds 6 and 7. 8 replaces both, and the drop count
+	// includes the changes 6 stood in for.
+	n.Notify(8, "x")
+
+	ev = <-ch
+	assert.Equal(t, 8, ev.Change)
+	assert.Equal(t, 4, ev.Dropped)
+
+	cancel()
+	_, ok := <-ch
+	assert.Equal(t, false, ok)
+}
+
+func TestNotifier_Since(t *testing.T) {
+	n := &Notifier[int]{HistorySize: 3}
+
+	start := n.LastID()
+	assert.Equal(t, uint64(0), start.Seq)
+
+	evs, ok := n.Since(start)
+	assert.Equal(t, true, ok)
+	assert.SliceLen(t, evs, 0)
+
+	n.Notify(1, "a")
+	n.Notify(2, "b")
+	n.Notify(3, "a")
+	assert.Equal(t, ID{Epoch: start.Epoch, Seq: 3}, n.LastID())
+
+	changes := func(evs []Event[int]) []int {
+		var res []int
+		for _, ev := range evs {
+			res = append(res, ev.Change)
+		}
+		return res
+	}
+	seq := func(seq uint64) ID {
+		return ID{Epoch: start.Epoch, Seq: seq}
+	}
+
+	evs, ok = n.Since(start)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, []int{1, 2, 3}, changes(evs))
+
+	evs, ok = n.Since(seq(1), "a")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, []int{3}, changes(evs))
+
+	// Up to date.
+	evs, ok = n.Since(seq(3))
+	assert.Equal(t, true, ok)
+	assert.SliceLen(t, evs, 0)
+
+	// Newer than anything published.
+	_, ok = n.Since(seq(4))
+	assert.Equal(t, false, ok)
+
+	// From before a restart, even though the counter has since passed it.
+	_, ok = n.Since(ID{Epoch: start.Epoch - 1, Seq: 1})
+	assert.Equal(t, false, ok)
+
+	// Event 1 falls out of the history, so resuming from before it can't be done.
+	n.Notify(4, "a")
+	_, ok = n.Since(start)
+	assert.Equal(t, false, ok)
+
+	evs, ok = n.Since(seq(1))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, []int{2, 3, 4}, changes(evs))
+	assert.Equal(t, seq(4), evs[2].ID)
+}
+
+func TestParseID(t *testing.T) {
+	id := ID{Epoch: 1760000000000000000, Seq: 42}
+	got, err := ParseID(id.String())
+	assert.NoError(t, err)
+	assert.Equal(t, id, got)
+
+	for _, bad := range []string{"", "42", "abc", "1-", "-1", "1-2-3", "1--2", " 1-2"} {
+		_, err := ParseID(bad)
+		assert.Error(t, err)
+	}
+}
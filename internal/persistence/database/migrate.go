@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+)
+
+// Migration is a single, numbered change to the database schema. Versions must start at 1 and
+// increase by exactly one; a migration must never be edited once it has shipped. Add a new one
+// instead.
+type Migration struct {
+	Version int
+	Name    string
+	// SQL may contain multiple statements. It is executed in the same transaction that records the
+	// migration as applied.
+	SQL string
+}
+
+// Migrate applies every migration that hasn't yet been applied to the database, in order. It
+// returns ErrSchemaTooNew if the database has migrations applied that this binary doesn't know
+// about (e.g. after rolling back a deploy), since running against an unknown schema could corrupt
+// data.
+func (db Database) Migrate(ctx context.Context) error {
+	return db.migrate(ctx, migrations)
+}
+
+// SchemaVersion returns the version of the latest migration applied to the database, or 0 if none
+// have been applied.
+func (db Database) SchemaVersion(ctx context.Context) (int, error) {
+	var version sql.Null[int]
+	err := db.QueryRowContext(ctx, `SELECT MAX(Version) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+	return version.V, nil
+}
+
+// LatestSchemaVersion returns the version of the newest migration this binary knows about.
+func LatestSchemaVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+func (db Database) migrate(ctx context.Context, ms []Migration) error {
+	for i, m := range ms {
+		if m.Version != i+1 {
+			return fmt.Errorf("dev error: migration %q has version %d, expected %d", m.Name, m.Version, i+1)
+		}
+	}
+
+	err := db.ExecVoid(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			Version INTEGER,
+			Name TEXT,
+			AppliedAt DATETIME,
+
+			PRIMARY KEY (Version)
+		)`)
+	if err != nil {
+		return err
+	}
+
+	current, err := db.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current > len(ms) {
+		return fmt.Errorf(
+			"%w: database is at version %d but the latest known migration is %d",
+			ErrSchemaTooNew, current, len(ms),
+		)
+	}
+
+	for _, m := range ms[current:] {
+		err := db.WithTx(ctx, func(ctx context.Context) error {
+			err := db.ExecVoid(ctx, m.SQL)
+			if err != nil {
+				return err
+			}
+
+			return db.ExecVoid(
+				ctx,
+				`INSERT INTO schema_migrations (Version, Name, AppliedAt) VALUES (?, ?, ?)`,
+				m.Version, m.Name, time.Now(),
+			)
+		})
+		if err != nil {
+			return fmt.Errorf("applying migration %d (%s): %w", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+	"github.com/ncruces/go-sqlite3/vfs/memdb"
+)
+
+func newEmptyInMemory(t *testing.T) Database {
+	memdb.Create("migrate_test.db", nil)
+	db, err := sql.Open("sqlite3", "file:/migrate_test.db?vfs=memdb")
+	assert.NoError(t, err)
+	return New(db)
+}
+
+func TestMigrate(t *testing.T) {
+	db := newEmptyInMemory(t)
+
+	assert.NoError(t, db.Migrate(t.Context()))
+
+	version, err := db.SchemaVersion(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, LatestSchemaVersion(), version)
+
+	// Migrating again is a no-op.
+	assert.NoError(t, db.Migrate(t.Context()))
+
+	var n int
+	err = db.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM schema_migrations`).Scan(&n)
+	assert.NoError(t, err)
+	assert.Equal(t, LatestSchemaVersion(), n)
+}
+
+func TestMigrate_AdoptsLegacyDatabase(t *testing.T) {
+	db := newEmptyInMemory(t)
+
+	// Databases created before migrations existed already have tables (and data) in them.
+	assert.NoError(t, db.ExecVoid(t.Context(), `CREATE TABLE Teams (
+		ID VARCHAR(36) PRIMARY KEY,
+		Name VARCHAR(255),
+		DivisionID VARCHAR(36)
+	)`))
+	assert.NoError(t, db.ExecVoid(t.Context(), `INSERT INTO Teams (ID, Name) VALUES ('a', 'A')`))
+
+	assert.NoError(t, db.Migrate(t.Context()))
+
+	var name string
+	err := db.QueryRowContext(t.Context(), `SELECT Name FROM Teams WHERE ID = 'a'`).Scan(&name)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", name)
+}
+
+func TestMigrate_PeopleFromExistingPlayers(t *testing.T) {
+	db := newEmptyInMemory(t)
+
+	// Migrate to just before people were added.
+	assert.NoError(t, db.migrate(t.Context(), migrations[:18]))
+	assert.NoError(t, db.ExecVoid(t.Context(), `INSERT INTO Players (ID, FirstName, LastName) VALUES
+		('a', 'Alice', 'Smith'),
+		('b', ' alice ', 'SMITH'),
+		('c', 'Bob', 'Smith')`))
+
+	assert.NoError(t, db.migrate(t.Context(), migrations))
+
+	personIDs := make(map[string]string)
+	for _, id := range []string{"a", "b", "c"} {
+		var personID string
+		err := db.QueryRowContext(t.Context(), `SELECT PersonID FROM Players WHERE ID = ?`, id).Scan(&personID)
+		assert.NoError(t, err)
+		personIDs[id] = personID
+	}
+	// Players who share a name aren't merged; that's up to an admin.
+	assert.Equal(t, map[string]string{"a": "a", "b": "b", "c": "c"}, personIDs)
+
+	var first, last string
+	err := db.QueryRowContext(t.Context(), `SELECT FirstName, LastName FROM People WHERE ID = 'b'`).Scan(&first, &last)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", first)
+	assert.Equal(t, "SMITH", last)
+
+	var n int
+	err = db.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM People`).Scan(&n)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
+
+func TestMigrate_FinalistsFromFinishedBrackets(t *testing.T) {
+	db := newEmptyInMemory(t)
+
+	// Migrate to just before finalists were added.
+	assert.NoError(t, db.migrate(t.Context(), migrations[:19]))
+	assert.NoError(t, db.ExecVoid(t.Context(), `INSERT INTO Teams (ID, Name, EventID) VALUES
+		('a', 'A', 'single'), ('b', 'B', 'single'), ('c', 'C', 'single'),
+		('d', 'D', 'double'), ('e', 'E', 'double'),
+		('f', 'F', 'unfinished'), ('g', 'G', 'unfinished')`))
+	assert.NoError(t, db.ExecVoid(t.Context(), `INSERT INTO Players (ID, FirstName, LastName, TeamID, PersonID) VALUES
+		('pa', 'Alice', 'Smith', 'a', 'alice')`))
+	assert.NoError(t, db.ExecVoid(t.Context(), `INSERT INTO TournamentGames (EventID, Bracket, Round, Idx, TeamID1, TeamID2, Winner) VALUES
+		('single', 'main', 0, 0, 'a', 'c', 'a'),
+		('single', 'main', 0, 1, 'b', NULL, 'b'),
+		('single', 'main', 1, 0, 'a', 'b', 'a'),
+		('unfinished', 'main', 0, 0, 'f', 'g', NULL)`))
+	// The losers-side team won the grand final and then the reset.
+	assert.NoError(t, db.ExecVoid(t.Context(), `INSERT INTO DoubleEliminationGames (EventID, Bracket, Side, Round, Idx, TeamID1, TeamID2, Winner) VALUES
+		('double', 'main', 'final', 0, 0, 'd', 'e', 'e'),
+		('double', 'main', 'final', 1, 0, 'd', 'e', 'e')`))
+
+	assert.NoError(t, db.migrate(t.Context(), migrations))
+
+	rows, err := db.QueryContext(t.Context(), `SELECT EventID, Place, TeamID, TeamName FROM Finalists ORDER BY EventID, Place`)
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var eventID, teamID, teamName string
+		var place int
+		assert.NoError(t, rows.Scan(&eventID, &place, &teamID, &teamName))
+		got = append(got, fmt.Sprintf("%s %d %s %s", eventID, place, teamID, teamName))
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []string{
+		"double 1 e E",
+		"double 2 d D",
+		"single 1 a A",
+		"single 2 b B",
+	}, got)
+
+	var personID string
+	err = db.QueryRowContext(t.Context(), `SELECT PersonID FROM FinalistPlayers WHERE EventID = 'single' AND Place = 1`).Scan(&personID)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", personID)
+}
+
+func TestMigrate_AppliesOnlyNewMigrations(t *testing.T) {
+	db := newEmptyInMemory(t)
+
+	ms := []Migration{{
+		Version: 1,
+		Name:    "create",
+		SQL:     `CREATE TABLE Test (A INT)`,
+	}}
+	assert.NoError(t, db.migrate(t.Context(), ms))
+
+	ms = append(ms, Migration{
+		Version: 2,
+		Name:    "add column",
+		SQL: `
+			ALTER TABLE Test ADD COLUMN B INT;
+			INSERT INTO Test (A, B) VALUES (1, 2);
+		`,
+	})
+	assert.NoError(t, db.migrate(t.Context(), ms))
+
+	var a, b int
+	err := db.QueryRowContext(t.Context(), `SELECT A, B FROM Test`).Scan(&a, &b)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, a)
+	assert.Equal(t, 2, b)
+
+	version, err := db.SchemaVersion(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+}
+
+func TestMigrate_FailedMigrationIsRolledBack(t *testing.T) {
+	db := newEmptyInMemory(t)
+
+	ms := []Migration{{
+		Version: 1,
+		Name:    "create",
+		SQL:     `CREATE TABLE Test (A INT)`,
+	}, {
+		Version: 2,
+		Name:    "broken",
+		SQL: `
+			INSERT INTO Test (A) VALUES (1);
+			INSERT INTO NotATable (A) VALUES (1);
+		`,
+	}}
+	assert.Error(t, db.migrate(t.Context(), ms))
+
+	version, err := db.SchemaVersion(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	var n int
+	err = db.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM Test`).Scan(&n)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestMigrate_SchemaTooNew(t *testing.T) {
+	db := newEmptyInMemory(t)
+
+	ms := []Migration{{
+		Version: 1,
+		Name:    "one",
+		SQL:     `CREATE TABLE One (A INT)`,
+	}, {
+		Version: 2,
+		Name:    "two",
+		SQL:     `CREATE TABLE Two (A INT)`,
+	}}
+	assert.NoError(t, db.migrate(t.Context(), ms))
+
+	// Simulate running an older binary against the migrated database.
+	err := db.migrate(t.Context(), ms[:1])
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("expected ErrSchemaTooNew, got %v", err)
+	}
+}
+
+func TestMigrate_VersionsMustBeSequential(t *testing.T) {
+	db := newEmptyInMemory(t)
+
+	err := db.migrate(t.Context(), []Migration{{
+		Version: 1,
+		Name:    "one",
+		SQL:     `CREATE TABLE One (A INT)`,
+	}, {
+		Version: 3,
+		Name:    "three",
+		SQL:     `CREATE TABLE Three (A INT)`,
+	}})
+	assert.Error(t, err)
+}
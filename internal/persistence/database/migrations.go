@@ -0,0 +1,514 @@
+package database
+
+// migrations is the ordered list of every schema change. Append to the end; never edit or reorder
+// an existing entry.
+var migrations = []Migration{{
+	Version: 1,
+	Name:    "initial schema",
+	// These use IF NOT EXISTS so that databases created before we had migrations (when each
+	// repository created its own tables on startup) are adopted as-is.
+	SQL: `
+		CREATE TABLE IF NOT EXISTS Players (
+			ID VARCHAR(36) PRIMARY KEY,
+			FirstName VARCHAR(255),
+			LastName VARCHAR(255),
+			TeamID VARCHAR(36) DEFAULT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS Teams (
+			ID VARCHAR(36) PRIMARY KEY,
+			Name VARCHAR(255),
+			DivisionID VARCHAR(36)
+		);
+
+		CREATE TABLE IF NOT EXISTS Divisions (
+			ID VARCHAR(36) PRIMARY KEY,
+			Name VARCHAR(255),
+			Size TINYINT
+		);
+
+		CREATE TABLE IF NOT EXISTS Scores (
+			GameID VARCHAR(36),
+			TeamID VARCHAR(36),
+			Score SMALLINT,
+
+			PRIMARY KEY (GameID, TeamID)
+		);
+
+		CREATE TABLE IF NOT EXISTS TournamentGames (
+			Round   SMALLINT,
+			Idx     SMALLINT,
+			TeamID1 VARCHAR(36),
+			TeamID2 VARCHAR(36),
+			Winner  VARCHAR(36),
+
+			PRIMARY KEY (Round, Idx)
+		);
+
+		CREATE TABLE IF NOT EXISTS RoomCodes (
+			Code TEXT,
+			Expires DATETIME,
+
+			PRIMARY KEY (Code)
+		);
+
+		CREATE TABLE IF NOT EXISTS Users (
+			Username TEXT,
+			PasswordHash BLOB,
+
+			PRIMARY KEY (Username)
+		);
+
+		CREATE TABLE IF NOT EXISTS Sessions (
+			ID TEXT,
+			Username TEXT,
+			Expires DATETIME,
+
+			PRIMARY KEY (ID)
+		);
+	`,
+}, {
+	Version: 2,
+	Name:    "events",
+	// Everything that existed before events is moved into a single "legacy" event, which becomes
+	// the active one.
+	SQL: `
+		CREATE TABLE Events (
+			ID VARCHAR(36) PRIMARY KEY,
+			Name VARCHAR(255),
+			Date DATETIME,
+			Status VARCHAR(16),
+			Active BOOLEAN NOT NULL DEFAULT FALSE
+		);
+
+		INSERT INTO Events (ID, Name, Date, Status, Active)
+		VALUES ('legacy', 'Szczepaniak Annual', CURRENT_TIMESTAMP, 'open', TRUE);
+
+		ALTER TABLE Players ADD COLUMN EventID VARCHAR(36) NOT NULL DEFAULT 'legacy';
+		ALTER TABLE Teams ADD COLUMN EventID VARCHAR(36) NOT NULL DEFAULT 'legacy';
+		ALTER TABLE Divisions ADD COLUMN EventID VARCHAR(36) NOT NULL DEFAULT 'legacy';
+		ALTER TABLE Scores ADD COLUMN EventID VARCHAR(36) NOT NULL DEFAULT 'legacy';
+
+		CREATE INDEX PlayersByEvent ON Players (EventID);
+		CREATE INDEX TeamsByEvent ON Teams (EventID);
+		CREATE INDEX DivisionsByEvent ON Divisions (EventID);
+		CREATE INDEX ScoresByEvent ON Scores (EventID);
+
+		-- SQLite can't change a primary key in place, so the bracket table is rebuilt.
+		CREATE TABLE TournamentGamesByEvent (
+			EventID VARCHAR(36),
+			Round   SMALLINT,
+			Idx     SMALLINT,
+			TeamID1 VARCHAR(36),
+			TeamID2 VARCHAR(36),
+			Winner  VARCHAR(36),
+
+			PRIMARY KEY (EventID, Round, Idx)
+		);
+
+		INSERT INTO TournamentGamesByEvent (EventID, Round, Idx, TeamID1, TeamID2, Winner)
+		SELECT 'legacy', Round, Idx, TeamID1, TeamID2, Winner FROM TournamentGames;
+
+		DROP TABLE TournamentGames;
+		ALTER TABLE TournamentGamesByEvent RENAME TO TournamentGames;
+	`,
+}, {
+	Version: 3,
+	Name:    "game point schemes",
+	// The defaults are the usual scheme: 2 points for a win, 3 for a skunk, 4 for a double skunk.
+	SQL: `
+		ALTER TABLE Events ADD COLUMN WinPoints SMALLINT NOT NULL DEFAULT 2;
+		ALTER TABLE Events ADD COLUMN SkunkWinPoints SMALLINT NOT NULL DEFAULT 3;
+		ALTER TABLE Events ADD COLUMN DoubleSkunkWinPoints SMALLINT NOT NULL DEFAULT 4;
+		ALTER TABLE Events ADD COLUMN LossPoints SMALLINT NOT NULL DEFAULT 0;
+	`,
+}, {
+	Version: 4,
+	Name:    "tiebreakers",
+	// Tiebreakers is a comma-separated list of the event's tiebreakers, in order. CoinFlips records
+	// every team's draw for the final coin-flip tiebreaker so that it never changes once made.
+	SQL: `
+		ALTER TABLE Events ADD COLUMN Tiebreakers VARCHAR(255) NOT NULL
+			DEFAULT 'head-to-head,point-spread,points-allowed,skunk-differential';
+
+		CREATE TABLE CoinFlips (
+			EventID VARCHAR(36),
+			TeamID VARCHAR(36),
+			Draw INTEGER NOT NULL,
+			FlippedAt DATETIME NOT NULL,
+
+			PRIMARY KEY (EventID, TeamID)
+		);
+	`,
+}, {
+	Version: 5,
+	Name:    "bracket byes",
+	// A bye is a first-round game with only one team, who advances without playing.
+	SQL: `
+		ALTER TABLE TournamentGames ADD COLUMN Bye BOOLEAN NOT NULL DEFAULT FALSE;
+	`,
+}, {
+	Version: 6,
+	Name:    "double elimination",
+	// Double-elimination brackets live apart from TournamentGames. Side is "winners", "losers", or
+	// "final"; the final side has the grand final in round 0 and its reset game in round 1.
+	SQL: `
+		CREATE TABLE DoubleEliminationGames (
+			EventID VARCHAR(36),
+			Side    VARCHAR(8),
+			Round   SMALLINT,
+			Idx     SMALLINT,
+			TeamID1 VARCHAR(36),
+			TeamID2 VARCHAR(36),
+			Winner  VARCHAR(36),
+
+			PRIMARY KEY (EventID, Side, Round, Idx)
+		);
+	`,
+}, {
+	Version: 7,
+	Name:    "consolation brackets",
+	// An event can have a consolation bracket next to its main one, so both bracket tables are
+	// rebuilt with the bracket's name in their primary keys.
+	SQL: `
+		CREATE TABLE TournamentGamesByBracket (
+			EventID VARCHAR(36),
+			Bracket VARCHAR(16) NOT NULL DEFAULT 'main',
+			Round   SMALLINT,
+			Idx     SMALLINT,
+			TeamID1 VARCHAR(36),
+			TeamID2 VARCHAR(36),
+			Winner  VARCHAR(36),
+			Bye     BOOLEAN NOT NULL DEFAULT FALSE,
+
+			PRIMARY KEY (EventID, Bracket, Round, Idx)
+		);
+
+		INSERT INTO TournamentGamesByBracket (EventID, Round, Idx, TeamID1, TeamID2, Winner, Bye)
+		SELECT EventID, Round, Idx, TeamID1, TeamID2, Winner, Bye FROM TournamentGames;
+
+		DROP TABLE TournamentGames;
+		ALTER TABLE TournamentGamesByBracket RENAME TO TournamentGames;
+
+		CREATE TABLE DoubleEliminationGamesByBracket (
+			EventID VARCHAR(36),
+			Bracket VARCHAR(16) NOT NULL DEFAULT 'main',
+			Side    VARCHAR(8),
+			Round   SMALLINT,
+			Idx     SMALLINT,
+			TeamID1 VARCHAR(36),
+			TeamID2 VARCHAR(36),
+			Winner  VARCHAR(36),
+
+			PRIMARY KEY (EventID, Bracket, Side, Round, Idx)
+		);
+
+		INSERT INTO DoubleEliminationGamesByBracket (EventID, Side, Round, Idx, TeamID1, TeamID2, Winner)
+		SELECT EventID, Side, Round, Idx, TeamID1, TeamID2, Winner FROM DoubleEliminationGames;
+
+		DROP TABLE DoubleEliminationGames;
+		ALTER TABLE DoubleEliminationGamesByBracket RENAME TO DoubleEliminationGames;
+	`,
+}, {
+	Version: 8,
+	Name:    "third-place games",
+	// A single-elimination bracket can have a game between its semifinal losers. There's at most
+	// one per bracket, so it lives in its own table rather than as a round of TournamentGames.
+	SQL: `
+		CREATE TABLE ThirdPlaceGames (
+			EventID VARCHAR(36),
+			Bracket VARCHAR(16),
+			TeamID1 VARCHAR(36),
+			TeamID2 VARCHAR(36),
+			Winner  VARCHAR(36),
+
+			PRIMARY KEY (EventID, Bracket)
+		);
+	`,
+}, {
+	Version: 9,
+	Name:    "bracket series",
+	// A bracket game can be a best-of-N series. Series holds the length of every game that isn't a
+	// single game, and SeriesGames the scores of each game played, in the order of the bracket
+	// game's teams. Both are keyed the same way as the bracket games; single-elimination games are
+	// on the "winners" side.
+	SQL: `
+		CREATE TABLE Series (
+			EventID VARCHAR(36),
+			Bracket VARCHAR(16),
+			Side    VARCHAR(16),
+			Round   SMALLINT,
+			Idx     SMALLINT,
+			BestOf  SMALLINT NOT NULL,
+
+			PRIMARY KEY (EventID, Bracket, Side, Round, Idx)
+		);
+
+		CREATE TABLE SeriesGames (
+			EventID VARCHAR(36),
+			Bracket VARCHAR(16),
+			Side    VARCHAR(16),
+			Round   SMALLINT,
+			Idx     SMALLINT,
+			Game    SMALLINT,
+			Score1  SMALLINT NOT NULL,
+			Score2  SMALLINT NOT NULL,
+
+			PRIMARY KEY (EventID, Bracket, Side, Round, Idx, Game)
+		);
+	`,
+}, {
+	Version: 10,
+	Name:    "bracket reports",
+	// Players can report the result of their own bracket game. Each team has at most one report per
+	// game, saying whether they won; the reports are cleared once the game has a winner.
+	SQL: `
+		CREATE TABLE BracketReports (
+			EventID VARCHAR(36),
+			Bracket VARCHAR(16),
+			Side    VARCHAR(16),
+			Round   SMALLINT,
+			Idx     SMALLINT,
+			TeamID  VARCHAR(36),
+			Won     BOOLEAN NOT NULL,
+
+			PRIMARY KEY (EventID, Bracket, Side, Round, Idx, TeamID)
+		);
+	`,
+}, {
+	Version: 11,
+	Name:    "score confirmation",
+	// An event can require the other team to confirm a prelim score that a player entered. The score
+	// goes into Scores as usual, and PendingScores marks it until it's confirmed. If the other team
+	// enters a different score instead, theirs is kept alongside as a dispute for an admin to settle.
+	SQL: `
+		ALTER TABLE Events ADD COLUMN ConfirmScores BOOLEAN NOT NULL DEFAULT FALSE;
+
+		CREATE TABLE PendingScores (
+			GameID            VARCHAR(36) PRIMARY KEY,
+			EventID           VARCHAR(36) NOT NULL,
+			ReportedBy        VARCHAR(36) NOT NULL,
+			DisputeWinner     VARCHAR(36),
+			DisputeLoserScore SMALLINT
+		);
+
+		CREATE INDEX PendingScoresByEvent ON PendingScores (EventID);
+	`,
+}, {
+	Version: 12,
+	Name:    "game rounds",
+	// Prelim games are generated in numbered rounds so teams know what to play next. Both Scores rows
+	// of a game carry its round; 0 means the game wasn't part of a generated schedule.
+	SQL: `
+		ALTER TABLE Scores ADD COLUMN Round SMALLINT NOT NULL DEFAULT 0;
+	`,
+}, {
+	Version: 13,
+	Name:    "tables",
+	// The physical tables games are played at. Generating the prelim schedule assigns each game a
+	// table, and a division can be pinned to a cluster of tables (e.g. one room of the venue).
+	SQL: `
+		CREATE TABLE Tables (
+			ID      VARCHAR(36) PRIMARY KEY,
+			EventID VARCHAR(36) NOT NULL,
+			Number  SMALLINT NOT NULL,
+			Name    VARCHAR(255) NOT NULL,
+			Cluster VARCHAR(255) NOT NULL DEFAULT '',
+
+			UNIQUE (EventID, Number)
+		);
+
+		ALTER TABLE Divisions ADD COLUMN TableCluster VARCHAR(255) NOT NULL DEFAULT '';
+		ALTER TABLE Scores ADD COLUMN TableID VARCHAR(36) NOT NULL DEFAULT '';
+	`,
+}, {
+	Version: 14,
+	Name:    "crossover games",
+	// Divisions can be paired up for a crossover phase, where each team plays teams from the paired
+	// division. Crossover games are flagged so they can be told apart from games within a division.
+	SQL: `
+		ALTER TABLE Divisions ADD COLUMN CrossoverDivisionID VARCHAR(36) NOT NULL DEFAULT '';
+		ALTER TABLE Scores ADD COLUMN Crossover BOOLEAN NOT NULL DEFAULT FALSE;
+	`,
+}, {
+	Version: 15,
+	Name:    "swiss prelims",
+	// An event can play its prelims as a Swiss instead of in divisions: SwissRounds rounds, each
+	// paired from the standings when the one before it is done. With an odd number of teams one team
+	// sits each round out; Byes records who, so nobody gets a second bye while others have none.
+	SQL: `
+		ALTER TABLE Events ADD COLUMN SwissRounds SMALLINT NOT NULL DEFAULT 0;
+
+		CREATE TABLE Byes (
+			EventID VARCHAR(36) NOT NULL,
+			Round   SMALLINT NOT NULL,
+			TeamID  VARCHAR(36) NOT NULL,
+
+			PRIMARY KEY (EventID, Round)
+		);
+	`,
+}, {
+	Version: 16,
+	Name:    "team ratings",
+	// Teams get a rating so divisions can be balanced by strength, and pairs of teams can be kept
+	// out of the same division. Pairs are stored with the smaller team ID first.
+	SQL: `
+		ALTER TABLE Teams ADD COLUMN Rating INT NOT NULL DEFAULT 0;
+
+		CREATE TABLE KeepApart (
+			EventID VARCHAR(36) NOT NULL,
+			TeamID1 VARCHAR(36) NOT NULL,
+			TeamID2 VARCHAR(36) NOT NULL,
+
+			PRIMARY KEY (TeamID1, TeamID2)
+		);
+	`,
+}, {
+	Version: 17,
+	Name:    "partner requests",
+	// Players get a rating so teams can be balanced, can ask to play with a partner, and can be kept
+	// off the same team. Conflicts are stored with the smaller player ID first.
+	SQL: `
+		ALTER TABLE Players ADD COLUMN Rating INT NOT NULL DEFAULT 0;
+
+		CREATE TABLE PartnerRequests (
+			EventID VARCHAR(36) NOT NULL,
+			PlayerID VARCHAR(36) NOT NULL PRIMARY KEY,
+			PartnerID VARCHAR(36) NOT NULL
+		);
+
+		CREATE TABLE PlayerConflicts (
+			EventID VARCHAR(36) NOT NULL,
+			PlayerID1 VARCHAR(36) NOT NULL,
+			PlayerID2 VARCHAR(36) NOT NULL,
+
+			PRIMARY KEY (PlayerID1, PlayerID2)
+		);
+	`,
+}, {
+	Version: 18,
+	Name:    "player ratings",
+	// Each player's rating after their event's games, from replaying every event's results in order.
+	// The whole table is rewritten whenever ratings are recomputed.
+	SQL: `
+		CREATE TABLE PlayerRatings (
+			EventID  VARCHAR(36) NOT NULL,
+			PlayerID VARCHAR(36) NOT NULL PRIMARY KEY,
+			Rating   INT NOT NULL,
+			Games    INT NOT NULL
+		);
+
+		CREATE INDEX PlayerRatingsByEvent ON PlayerRatings (EventID);
+	`,
+}, {
+	Version: 19,
+	Name:    "people",
+	// A person is who plays, from one event to the next; each event has its own player for them.
+	// Each existing player becomes a person of their own, keeping the player's ID: players with the
+	// same name may be different people, so linking the ones who are the same is left to an admin.
+	SQL: `
+		CREATE TABLE People (
+			ID        VARCHAR(36) NOT NULL PRIMARY KEY,
+			FirstName VARCHAR(255) NOT NULL,
+			LastName  VARCHAR(255) NOT NULL,
+			NameKey   VARCHAR(511) NOT NULL
+		);
+
+		ALTER TABLE Players ADD COLUMN PersonID VARCHAR(36);
+
+		INSERT INTO People (ID, FirstName, LastName, NameKey)
+		SELECT ID, trim(FirstName), trim(LastName),
+			lower(trim(FirstName)) || ' ' || lower(trim(LastName))
+		FROM Players;
+
+		UPDATE Players SET PersonID = ID;
+
+		CREATE INDEX PlayersByPerson ON Players (PersonID);
+	`,
+}, {
+	Version: 20,
+	Name:    "finalists",
+	// The champion (Place 1) and runner-up (Place 2) of each event's main bracket, kept apart from
+	// the bracket so they outlive it. Team names and people are copied in when the final is decided
+	// for the same reason. Brackets that were already finished are backfilled: the last round of a
+	// single-elimination bracket, or the grand final (if the team from the winners side won it) or
+	// its reset game of a double-elimination bracket.
+	SQL: `
+		CREATE TABLE Finalists (
+			EventID  VARCHAR(36) NOT NULL,
+			Place    SMALLINT NOT NULL,
+			TeamID   VARCHAR(36) NOT NULL,
+			TeamName VARCHAR(255) NOT NULL,
+
+			PRIMARY KEY (EventID, Place)
+		);
+
+		CREATE TABLE FinalistPlayers (
+			EventID  VARCHAR(36) NOT NULL,
+			Place    SMALLINT NOT NULL,
+			PersonID VARCHAR(36) NOT NULL,
+
+			PRIMARY KEY (EventID, Place, PersonID)
+		);
+
+		CREATE TEMPORARY TABLE Finals AS
+		SELECT EventID, TeamID1, TeamID2, Winner FROM TournamentGames g
+		WHERE Bracket = 'main' AND Winner IS NOT NULL AND Round = (
+			SELECT max(Round) FROM TournamentGames WHERE EventID = g.EventID AND Bracket = 'main'
+		)
+		UNION ALL
+		SELECT EventID, TeamID1, TeamID2, Winner FROM DoubleEliminationGames
+		WHERE Bracket = 'main' AND Side = 'final' AND Winner IS NOT NULL
+			AND (Round = 1 OR Winner = TeamID1);
+
+		INSERT INTO Finalists (EventID, Place, TeamID, TeamName)
+		SELECT f.EventID, 1, t.ID, coalesce(t.Name, '') FROM Finals f JOIN Teams t ON t.ID = f.Winner
+		UNION ALL
+		SELECT f.EventID, 2, t.ID, coalesce(t.Name, '') FROM Finals f
+		JOIN Teams t ON t.ID = iif(f.Winner = f.TeamID1, f.TeamID2, f.TeamID1);
+
+		INSERT INTO FinalistPlayers (EventID, Place, PersonID)
+		SELECT DISTINCT f.EventID, f.Place, p.PersonID FROM Finalists f
+		JOIN Players p ON p.TeamID = f.TeamID
+		WHERE p.PersonID IS NOT NULL;
+
+		DROP TABLE Finals;
+	`,
+}, {
+	Version: 21,
+	Name:    "bracket report reporters",
+	// Reporter identifies the browser a bracket report came from, so that one person can't report
+	// both sides of a game. Reports made before it existed have none.
+	SQL: `
+		ALTER TABLE BracketReports ADD COLUMN Reporter VARCHAR(36) NOT NULL DEFAULT '';
+	`,
+}, {
+	Version: 22,
+	Name:    "people may share a name",
+	// Two different people can have the same name, so NameKey only suggests who a new player is.
+	// People is rebuilt in case it was created with NameKey unique, and is indexed by it instead.
+	SQL: `
+		CREATE TABLE PeopleByName (
+			ID        VARCHAR(36) NOT NULL PRIMARY KEY,
+			FirstName VARCHAR(255) NOT NULL,
+			LastName  VARCHAR(255) NOT NULL,
+			NameKey   VARCHAR(511) NOT NULL
+		);
+
+		INSERT INTO PeopleByName (ID, FirstName, LastName, NameKey)
+		SELECT ID, FirstName, LastName, NameKey FROM People;
+
+		DROP TABLE People;
+		ALTER TABLE PeopleByName RENAME TO People;
+
+		CREATE INDEX PeopleByNameKey ON People (NameKey);
+	`,
+}, {
+	Version: 23,
+	Name:    "bracket report scores",
+	// Teams report the loser's score along with who won, so that bracket games decided by their
+	// reports keep a score. Reports made before it existed have none (0).
+	SQL: `
+		ALTER TABLE BracketReports ADD COLUMN LoserScore SMALLINT NOT NULL DEFAULT 0;
+	`,
+}}
@@ -11,10 +11,14 @@ import (
 	"github.com/cszczepaniak/gotest/assert"
 )
 
+// NewInMemory returns an in-memory database with all migrations applied.
 func NewInMemory(t *testing.T) Database {
 	// See https://pkg.go.dev/github.com/ncruces/go-sqlite3/vfs/memdb#example-package
 	memdb.Create("test.db", nil)
 	db, err := sql.Open("sqlite3", "file:/test.db?vfs=memdb")
 	assert.NoError(t, err)
-	return New(db)
+
+	d := New(db)
+	assert.NoError(t, d.Migrate(t.Context()))
+	return d
 }
@@ -4,19 +4,25 @@ import (
 	"context"
 
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder"
-	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/column"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/filter"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/formatter"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/table"
 	"github.com/google/uuid"
 
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 )
 
 type Division struct {
 	ID   string
 	Name string
 	Size int
+	// TableCluster is the cluster of tables the division's prelim games are played at, or empty if
+	// they can be played at any table.
+	TableCluster string
+	// CrossoverDivisionID is the division this one plays in the crossover phase, or empty if it
+	// isn't paired with one.
+	CrossoverDivisionID string
 }
 
 type Repository struct {
@@ -31,18 +37,6 @@ func NewRepository(db database.Database) Repository {
 	}
 }
 
-func (s Repository) Init(ctx context.Context) error {
-	_, err := s.b.CreateTable("Divisions").
-		IfNotExists().
-		Columns(
-			column.VarChar("ID", 36).PrimaryKey(),
-			column.VarChar("Name", 255),
-			column.TinyInt("Size"), // 4 or 6
-		).
-		ExecContext(ctx, s.db)
-	return err
-}
-
 func (s Repository) Create(ctx context.Context) (Division, error) {
 	division := Division{
 		ID:   uuid.NewString(),
@@ -50,9 +44,14 @@ func (s Repository) Create(ctx context.Context) (Division, error) {
 		Size: 4,
 	}
 
-	_, err := s.b.InsertIntoTable("Divisions").
-		Fields("ID", "Name", "Size").
-		Values(division.ID, division.Name, 4).
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return Division{}, err
+	}
+
+	_, err = s.b.InsertIntoTable("Divisions").
+		Fields("ID", "Name", "Size", "EventID").
+		Values(division.ID, division.Name, 4, eventID).
 		ExecContext(ctx, s.db)
 	if err != nil {
 		return Division{}, err
@@ -84,9 +83,43 @@ func (s Repository) UpdateSize(ctx context.Context, id string, size int) error {
 	return err
 }
 
+func (s Repository) SetTableCluster(ctx context.Context, id, cluster string) error {
+	_, err := s.b.UpdateTable("Divisions").
+		SetFieldTo("TableCluster", cluster).
+		Where(filter.Equals("ID", id)).
+		ExecContext(ctx, s.db)
+	return err
+}
+
+// PairForCrossover pairs two divisions so their teams play each other in the crossover phase. Any
+// division either of them was paired with before is left unpaired. If otherID is empty, the
+// division is unpaired.
+func (s Repository) PairForCrossover(ctx context.Context, id, otherID string) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		err := s.db.ExecVoid(ctx, `
+			UPDATE Divisions SET CrossoverDivisionID = ''
+			WHERE ID IN (?, ?) OR CrossoverDivisionID IN (?, ?)`,
+			id, otherID, id, otherID,
+		)
+		if err != nil {
+			return err
+		}
+
+		if otherID == "" {
+			return nil
+		}
+
+		err = s.db.ExecVoid(ctx, `UPDATE Divisions SET CrossoverDivisionID = ? WHERE ID = ?`, otherID, id)
+		if err != nil {
+			return err
+		}
+		return s.db.ExecVoid(ctx, `UPDATE Divisions SET CrossoverDivisionID = ? WHERE ID = ?`, id, otherID)
+	})
+}
+
 func (s Repository) Get(ctx context.Context, id string) (Division, error) {
 	row, err := s.b.SelectFrom(table.Named("Divisions")).
-		Columns("ID", "Name", "Size").
+		Columns("ID", "Name", "Size", "TableCluster", "CrossoverDivisionID").
 		Where(filter.Equals("ID", id)).
 		QueryRowContext(ctx, s.db)
 	if err != nil {
@@ -94,7 +127,7 @@ func (s Repository) Get(ctx context.Context, id string) (Division, error) {
 	}
 
 	var division Division
-	err = row.Scan(&division.ID, &division.Name, &division.Size)
+	err = row.Scan(&division.ID, &division.Name, &division.Size, &division.TableCluster, &division.CrossoverDivisionID)
 	if err != nil {
 		return Division{}, err
 	}
@@ -103,8 +136,14 @@ func (s Repository) Get(ctx context.Context, id string) (Division, error) {
 }
 
 func (s Repository) GetAll(ctx context.Context) ([]Division, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := s.b.SelectFrom(table.Named("Divisions")).
-		Columns("ID", "Name", "Size").
+		Columns("ID", "Name", "Size", "TableCluster", "CrossoverDivisionID").
+		Where(filter.Equals("EventID", eventID)).
 		QueryContext(ctx, s.db)
 	if err != nil {
 		return nil, err
@@ -114,7 +153,7 @@ func (s Repository) GetAll(ctx context.Context) ([]Division, error) {
 	var divisions []Division
 	for rows.Next() {
 		var d Division
-		err := rows.Scan(&d.ID, &d.Name, &d.Size)
+		err := rows.Scan(&d.ID, &d.Name, &d.Size, &d.TableCluster, &d.CrossoverDivisionID)
 		if err != nil {
 			return nil, err
 		}
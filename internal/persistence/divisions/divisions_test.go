@@ -13,7 +13,6 @@ import (
 func TestDivisionsRepo(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
-	assert.NoError(t, s.Init(t.Context()))
 
 	division1, err := s.Create(t.Context())
 	assert.NoError(t, err)
@@ -59,7 +58,6 @@ func TestDivisionsRepo(t *testing.T) {
 func TestDivisionsRepo_Rename(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
-	assert.NoError(t, s.Init(t.Context()))
 
 	division, err := s.Create(t.Context())
 	assert.NoError(t, err)
@@ -71,3 +69,57 @@ func TestDivisionsRepo_Rename(t *testing.T) {
 	assert.Equal(t, division.ID, gotDivision.ID)
 	assert.Equal(t, "New Name", gotDivision.Name)
 }
+
+func TestDivisionsRepo_SetTableCluster(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db)
+
+	division, err := s.Create(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "", division.TableCluster)
+
+	assert.NoError(t, s.SetTableCluster(t.Context(), division.ID, "Upstairs"))
+
+	gotDivision, err := s.Get(t.Context(), division.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Upstairs", gotDivision.TableCluster)
+
+	divisions, err := s.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Upstairs", divisions[0].TableCluster)
+}
+
+func TestDivisionsRepo_PairForCrossover(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db)
+
+	var ids []string
+	for range 3 {
+		d, err := s.Create(t.Context())
+		assert.NoError(t, err)
+		ids = append(ids, d.ID)
+	}
+	a, b, c := ids[0], ids[1], ids[2]
+
+	partner := func(id string) string {
+		t.Helper()
+		d, err := s.Get(t.Context(), id)
+		assert.NoError(t, err)
+		return d.CrossoverDivisionID
+	}
+
+	assert.NoError(t, s.PairForCrossover(t.Context(), a, b))
+	assert.Equal(t, b, partner(a))
+	assert.Equal(t, a, partner(b))
+	assert.Equal(t, "", partner(c))
+
+	// Pairing a with c leaves b without a partner.
+	assert.NoError(t, s.PairForCrossover(t.Context(), a, c))
+	assert.Equal(t, c, partner(a))
+	assert.Equal(t, "", partner(b))
+	assert.Equal(t, a, partner(c))
+
+	assert.NoError(t, s.PairForCrossover(t.Context(), c, ""))
+	assert.Equal(t, "", partner(a))
+	assert.Equal(t, "", partner(c))
+}
@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+)
+
+type eventKey struct{}
+
+// WithEvent returns a context that scopes repository queries to the given event.
+func WithEvent(ctx context.Context, e Event) context.Context {
+	return context.WithValue(ctx, eventKey{}, e)
+}
+
+// FromContext returns the event the context is scoped to, if any.
+func FromContext(ctx context.Context) (Event, bool) {
+	e, ok := ctx.Value(eventKey{}).(Event)
+	return e, ok
+}
+
+// CurrentID returns the ID of the event that repository queries should be scoped to: the event in
+// the context if there is one, otherwise the active event.
+func CurrentID(ctx context.Context, db database.Database) (string, error) {
+	if e, ok := FromContext(ctx); ok {
+		return e.ID, nil
+	}
+
+	var id string
+	err := db.QueryRowContext(ctx, `SELECT ID FROM Events WHERE Active`).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNoActiveEvent
+		}
+		return "", err
+	}
+
+	return id, nil
+}
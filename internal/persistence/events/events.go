@@ -0,0 +1,388 @@
+package events
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+)
+
+var (
+	ErrEventNotFound      = errors.New("event not found")
+	ErrNoActiveEvent      = errors.New("there is no active event")
+	ErrEventArchived      = errors.New("event is archived")
+	ErrCannotArchive      = errors.New("the active event can't be archived")
+	ErrEventNameMissing   = errors.New("event must have a name")
+	ErrInvalidPoints      = errors.New("game points must not be negative, and a win must be worth more than a loss")
+	ErrInvalidTiebreaks   = errors.New("tiebreakers must be known and must not repeat")
+	ErrInvalidSwissRounds = errors.New("number of Swiss rounds must not be negative")
+)
+
+// LegacyID is the ID of the event that data created before events existed was migrated into.
+const LegacyID = "legacy"
+
+type Status string
+
+const (
+	StatusOpen     Status = "open"
+	StatusArchived Status = "archived"
+)
+
+// PointScheme is how many game points a team earns for each result in the prelims. Standings are
+// ranked by game points.
+type PointScheme struct {
+	Win int
+	// SkunkWin is for a win where the loser finished under 91.
+	SkunkWin int
+	// DoubleSkunkWin is for a win where the loser finished under 61.
+	DoubleSkunkWin int
+	Loss           int
+}
+
+// DefaultPointScheme is the scheme new events start with.
+var DefaultPointScheme = PointScheme{
+	Win:            2,
+	SkunkWin:       3,
+	DoubleSkunkWin: 4,
+	Loss:           0,
+}
+
+func (p PointScheme) Validate() error {
+	if p.Win < 0 || p.SkunkWin < 0 || p.DoubleSkunkWin < 0 || p.Loss < 0 {
+		return ErrInvalidPoints
+	}
+	if p.Loss >= min(p.Win, p.SkunkWin, p.DoubleSkunkWin) {
+		return ErrInvalidPoints
+	}
+	return nil
+}
+
+// Tiebreaker separates teams that are level on games played and game points.
+type Tiebreaker string
+
+const (
+	// TiebreakHeadToHead ranks tied teams by their record in games against each other.
+	TiebreakHeadToHead Tiebreaker = "head-to-head"
+	// TiebreakPointSpread ranks tied teams by points scored minus points allowed.
+	TiebreakPointSpread Tiebreaker = "point-spread"
+	// TiebreakPointsAllowed ranks tied teams by fewest points allowed.
+	TiebreakPointsAllowed Tiebreaker = "points-allowed"
+	// TiebreakSkunkDifferential ranks tied teams by skunks given minus skunks taken.
+	TiebreakSkunkDifferential Tiebreaker = "skunk-differential"
+	// TiebreakCoinFlip is always applied last, after the event's own tiebreakers. Each flip is
+	// recorded so that it never changes.
+	TiebreakCoinFlip Tiebreaker = "coin-flip"
+)
+
+// Tiebreakers are the tiebreakers an event can order, in their default order.
+var Tiebreakers = []Tiebreaker{
+	TiebreakHeadToHead,
+	TiebreakPointSpread,
+	TiebreakPointsAllowed,
+	TiebreakSkunkDifferential,
+}
+
+func (t Tiebreaker) String() string {
+	switch t {
+	case TiebreakHeadToHead:
+		return "Head-to-head"
+	case TiebreakPointSpread:
+		return "Point spread"
+	case TiebreakPointsAllowed:
+		return "Points allowed"
+	case TiebreakSkunkDifferential:
+		return "Skunk differential"
+	case TiebreakCoinFlip:
+		return "Coin flip"
+	default:
+		return string(t)
+	}
+}
+
+// ValidateTiebreakers returns ErrInvalidTiebreaks unless ts is some ordering of a subset of
+// Tiebreakers. The coin flip isn't included; it always comes last.
+func ValidateTiebreakers(ts []Tiebreaker) error {
+	for i, t := range ts {
+		if !slices.Contains(Tiebreakers, t) || slices.Contains(ts[:i], t) {
+			return ErrInvalidTiebreaks
+		}
+	}
+	return nil
+}
+
+// Event is a single tournament (e.g. one year's Szczepaniak annual). Players, teams, divisions,
+// games, and brackets all belong to exactly one event.
+type Event struct {
+	ID     string
+	Name   string
+	Date   time.Time
+	Status Status
+	// Active is true for the one event that the site is currently running. Public pages and admin
+	// tools operate on the active event unless told otherwise.
+	Active bool
+	Points PointScheme
+	// Tiebreakers are applied in order to teams that are still tied; a coin flip follows them.
+	Tiebreakers []Tiebreaker
+	// ConfirmScores makes a prelim score entered by a player pending until someone from the other
+	// team confirms it.
+	ConfirmScores bool
+	// SwissRounds is the number of rounds in a Swiss prelim, or 0 if the prelims are played in
+	// divisions.
+	SwissRounds int
+}
+
+func (e Event) Archived() bool {
+	return e.Status == StatusArchived
+}
+
+type Repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+// Create creates a new, open event. The event is not made active.
+func (r Repository) Create(ctx context.Context, name string, date time.Time) (Event, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Event{}, ErrEventNameMissing
+	}
+
+	e := Event{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Date:        date,
+		Status:      StatusOpen,
+		Points:      DefaultPointScheme,
+		Tiebreakers: slices.Clone(Tiebreakers),
+	}
+
+	err := r.db.ExecVoid(
+		ctx,
+		`INSERT INTO Events (
+			ID, Name, Date, Status, Active, WinPoints, SkunkWinPoints, DoubleSkunkWinPoints, LossPoints, Tiebreakers
+		) VALUES (?, ?, ?, ?, FALSE, ?, ?, ?, ?, ?)`,
+		e.ID, e.Name, e.Date, e.Status,
+		e.Points.Win, e.Points.SkunkWin, e.Points.DoubleSkunkWin, e.Points.Loss,
+		joinTiebreakers(e.Tiebreakers),
+	)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+func (r Repository) Get(ctx context.Context, id string) (Event, error) {
+	e, err := scanEvent(r.db.QueryRowContext(
+		ctx,
+		`SELECT `+eventColumns+` FROM Events WHERE ID = ?`,
+		id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Event{}, ErrEventNotFound
+	}
+	return e, err
+}
+
+// GetActive returns the active event, or ErrNoActiveEvent if there isn't one.
+func (r Repository) GetActive(ctx context.Context) (Event, error) {
+	e, err := scanEvent(r.db.QueryRowContext(
+		ctx,
+		`SELECT `+eventColumns+` FROM Events WHERE Active`,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Event{}, ErrNoActiveEvent
+	}
+	return e, err
+}
+
+// GetAll returns every event, most recent first.
+func (r Repository) GetAll(ctx context.Context) ([]Event, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+eventColumns+` FROM Events`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var es []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(es, func(a, b Event) int {
+		return cmp.Or(
+			b.Date.Compare(a.Date),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	return es, nil
+}
+
+// Activate makes the given event the active one. Archived events can't be activated.
+func (r Repository) Activate(ctx context.Context, id string) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		e, err := r.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if e.Archived() {
+			return ErrEventArchived
+		}
+
+		err = r.db.ExecVoid(ctx, `UPDATE Events SET Active = FALSE WHERE Active`)
+		if err != nil {
+			return err
+		}
+
+		return r.db.ExecOne(ctx, `UPDATE Events SET Active = TRUE WHERE ID = ?`, id)
+	})
+}
+
+// Archive marks the given event as archived. Its data stays browsable, but it can no longer be made
+// active and its settings can no longer change. The active event can't be archived; activate another
+// event first.
+func (r Repository) Archive(ctx context.Context, id string) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		e, err := r.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if e.Active {
+			return ErrCannotArchive
+		}
+
+		return r.db.ExecOne(ctx, `UPDATE Events SET Status = ? WHERE ID = ?`, StatusArchived, id)
+	})
+}
+
+// getOpen gets the given event for changing its settings, or returns ErrEventArchived if it's
+// archived.
+func (r Repository) getOpen(ctx context.Context, id string) (Event, error) {
+	e, err := r.Get(ctx, id)
+	if err != nil {
+		return Event{}, err
+	}
+	if e.Archived() {
+		return Event{}, ErrEventArchived
+	}
+	return e, nil
+}
+
+// SetPoints changes the game point scheme of the given event.
+func (r Repository) SetPoints(ctx context.Context, id string, p PointScheme) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := r.getOpen(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return r.db.ExecOne(
+			ctx,
+			`UPDATE Events SET WinPoints = ?, SkunkWinPoints = ?, DoubleSkunkWinPoints = ?, LossPoints = ?
+			WHERE ID = ?`,
+			p.Win, p.SkunkWin, p.DoubleSkunkWin, p.Loss, id,
+		)
+	})
+}
+
+// SetTiebreakers changes the order of the given event's tiebreakers. See ValidateTiebreakers.
+func (r Repository) SetTiebreakers(ctx context.Context, id string, ts []Tiebreaker) error {
+	if err := ValidateTiebreakers(ts); err != nil {
+		return err
+	}
+
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := r.getOpen(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return r.db.ExecOne(ctx, `UPDATE Events SET Tiebreakers = ? WHERE ID = ?`, joinTiebreakers(ts), id)
+	})
+}
+
+// SetConfirmScores turns score confirmation on or off for the given event.
+func (r Repository) SetConfirmScores(ctx context.Context, id string, confirm bool) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := r.getOpen(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return r.db.ExecOne(ctx, `UPDATE Events SET ConfirmScores = ? WHERE ID = ?`, confirm, id)
+	})
+}
+
+// SetSwissRounds sets the number of rounds of the given event's Swiss prelim. 0 plays the prelims in
+// divisions instead.
+func (r Repository) SetSwissRounds(ctx context.Context, id string, rounds int) error {
+	if rounds < 0 {
+		return ErrInvalidSwissRounds
+	}
+
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := r.getOpen(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return r.db.ExecOne(ctx, `UPDATE Events SET SwissRounds = ? WHERE ID = ?`, rounds, id)
+	})
+}
+
+func joinTiebreakers(ts []Tiebreaker) string {
+	strs := make([]string, 0, len(ts))
+	for _, t := range ts {
+		strs = append(strs, string(t))
+	}
+	return strings.Join(strs, ",")
+}
+
+const eventColumns = `ID, Name, Date, Status, Active, WinPoints, SkunkWinPoints, DoubleSkunkWinPoints, LossPoints, Tiebreakers, ConfirmScores, SwissRounds`
+
+func scanEvent(scanner interface{ Scan(...any) error }) (Event, error) {
+	var e Event
+	var tiebreakers string
+	err := scanner.Scan(
+		&e.ID, &e.Name, &e.Date, &e.Status, &e.Active,
+		&e.Points.Win, &e.Points.SkunkWin, &e.Points.DoubleSkunkWin, &e.Points.Loss,
+		&tiebreakers, &e.ConfirmScores, &e.SwissRounds,
+	)
+	if err != nil {
+		return Event{}, err
+	}
+
+	for t := range strings.SplitSeq(tiebreakers, ",") {
+		if t != "" {
+			e.Tiebreakers = append(e.Tiebreakers, Tiebreaker(t))
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,217 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+)
+
+func TestCreate(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	date := time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC)
+	e, err := repo.Create(t.Context(), "  2025  ", date)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025", e.Name)
+	assert.Equal(t, StatusOpen, e.Status)
+	assert.Equal(t, false, e.Active)
+
+	got, err := repo.Get(t.Context(), e.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, e.Name, got.Name)
+	assert.Equal(t, true, date.Equal(got.Date))
+
+	_, err = repo.Create(t.Context(), " ", date)
+	assert.ErrorIs(t, err, ErrEventNameMissing)
+
+	_, err = repo.Get(t.Context(), "nope")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+}
+
+func TestGetAll(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	_, err := repo.Create(t.Context(), "Old", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	_, err = repo.Create(t.Context(), "Future", time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	es, err := repo.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, es, 3)
+
+	// The legacy event is dated when the database was migrated.
+	assert.Equal(t, "Future", es[0].Name)
+	assert.Equal(t, LegacyID, es[1].ID)
+	assert.Equal(t, "Old", es[2].Name)
+}
+
+func TestActivate(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	// Existing databases start with the legacy event active.
+	active, err := repo.GetActive(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, LegacyID, active.ID)
+
+	e, err := repo.Create(t.Context(), "2025", time.Now())
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.Activate(t.Context(), e.ID))
+
+	active, err = repo.GetActive(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, e.ID, active.ID)
+
+	legacy, err := repo.Get(t.Context(), LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, false, legacy.Active)
+
+	assert.ErrorIs(t, repo.Activate(t.Context(), "nope"), ErrEventNotFound)
+}
+
+func TestArchive(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	assert.ErrorIs(t, repo.Archive(t.Context(), LegacyID), ErrCannotArchive)
+
+	e, err := repo.Create(t.Context(), "2025", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Activate(t.Context(), e.ID))
+
+	assert.NoError(t, repo.Archive(t.Context(), LegacyID))
+
+	legacy, err := repo.Get(t.Context(), LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, true, legacy.Archived())
+
+	assert.ErrorIs(t, repo.Activate(t.Context(), LegacyID), ErrEventArchived)
+	assert.ErrorIs(t, repo.SetPoints(t.Context(), LegacyID, DefaultPointScheme), ErrEventArchived)
+	assert.ErrorIs(t, repo.SetTiebreakers(t.Context(), LegacyID, Tiebreakers), ErrEventArchived)
+	assert.ErrorIs(t, repo.SetConfirmScores(t.Context(), LegacyID, true), ErrEventArchived)
+	assert.ErrorIs(t, repo.SetSwissRounds(t.Context(), LegacyID, 3), ErrEventArchived)
+
+	active, err := repo.GetActive(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, e.ID, active.ID)
+}
+
+func TestCurrentID(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	id, err := CurrentID(t.Context(), db)
+	assert.NoError(t, err)
+	assert.Equal(t, LegacyID, id)
+
+	e, err := repo.Create(t.Context(), "2025", time.Now())
+	assert.NoError(t, err)
+
+	id, err = CurrentID(WithEvent(t.Context(), e), db)
+	assert.NoError(t, err)
+	assert.Equal(t, e.ID, id)
+
+	assert.NoError(t, db.ExecVoid(t.Context(), `UPDATE Events SET Active = FALSE`))
+	_, err = CurrentID(t.Context(), db)
+	assert.ErrorIs(t, err, ErrNoActiveEvent)
+}
+
+func TestSetPoints(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	// The legacy event gets the default scheme from the migration.
+	legacy, err := repo.Get(t.Context(), LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPointScheme, legacy.Points)
+
+	e, err := repo.Create(t.Context(), "2025", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPointScheme, e.Points)
+
+	p := PointScheme{Win: 1, SkunkWin: 2, DoubleSkunkWin: 2, Loss: 0}
+	assert.NoError(t, repo.SetPoints(t.Context(), e.ID, p))
+
+	got, err := repo.Get(t.Context(), e.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, p, got.Points)
+
+	assert.ErrorIs(t, repo.SetPoints(t.Context(), e.ID, PointScheme{Win: -1}), ErrInvalidPoints)
+	assert.ErrorIs(t, repo.SetPoints(t.Context(), e.ID, PointScheme{Win: 2, SkunkWin: 3, DoubleSkunkWin: 4, Loss: 2}), ErrInvalidPoints)
+	assert.ErrorIs(t, repo.SetPoints(t.Context(), "nope", DefaultPointScheme), ErrEventNotFound)
+}
+
+func TestSetTiebreakers(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	legacy, err := repo.Get(t.Context(), LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, Tiebreakers, legacy.Tiebreakers)
+
+	e, err := repo.Create(t.Context(), "2025", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, Tiebreakers, e.Tiebreakers)
+
+	ts := []Tiebreaker{TiebreakSkunkDifferential, TiebreakHeadToHead}
+	assert.NoError(t, repo.SetTiebreakers(t.Context(), e.ID, ts))
+
+	got, err := repo.Get(t.Context(), e.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ts, got.Tiebreakers)
+
+	// Only the coin flip is left.
+	assert.NoError(t, repo.SetTiebreakers(t.Context(), e.ID, nil))
+	got, err = repo.Get(t.Context(), e.ID)
+	assert.NoError(t, err)
+	assert.SliceLen(t, got.Tiebreakers, 0)
+
+	for _, bad := range [][]Tiebreaker{
+		{TiebreakHeadToHead, TiebreakHeadToHead},
+		{TiebreakCoinFlip},
+		{"nope"},
+	} {
+		assert.ErrorIs(t, repo.SetTiebreakers(t.Context(), e.ID, bad), ErrInvalidTiebreaks)
+	}
+	assert.ErrorIs(t, repo.SetTiebreakers(t.Context(), "nope", Tiebreakers), ErrEventNotFound)
+}
+
+func TestSetConfirmScores(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	e, err := repo.Create(t.Context(), "2025", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, false, e.ConfirmScores)
+
+	assert.NoError(t, repo.SetConfirmScores(t.Context(), e.ID, true))
+	got, err := repo.Get(t.Context(), e.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, true, got.ConfirmScores)
+
+	assert.ErrorIs(t, repo.SetConfirmScores(t.Context(), "nope", true), ErrEventNotFound)
+}
+
+func TestSetSwissRounds(t *testing.T) {
+	db := database.NewInMemory(t)
+	repo := NewRepository(db)
+
+	e, err := repo.Create(t.Context(), "2025", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, e.SwissRounds)
+
+	assert.NoError(t, repo.SetSwissRounds(t.Context(), e.ID, 5))
+	got, err := repo.Get(t.Context(), e.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, got.SwissRounds)
+
+	assert.ErrorIs(t, repo.SetSwissRounds(t.Context(), e.ID, -1), ErrInvalidSwissRounds)
+	assert.ErrorIs(t, repo.SetSwissRounds(t.Context(), "nope", 3), ErrEventNotFound)
+}
@@ -0,0 +1,58 @@
+package games
+
+import (
+	"context"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+// Bye is a round of a Swiss prelim that a team sits out because there's an odd number of teams. It
+// counts as a win in the standings.
+type Bye struct {
+	Round  int
+	TeamID string
+}
+
+// GiveBye records that the team sits out the given prelim round.
+func (s Repository) GiveBye(ctx context.Context, round int, teamID string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecVoid(
+		ctx,
+		`INSERT INTO Byes (EventID, Round, TeamID) VALUES (?, ?, ?)`,
+		eventID, round, teamID,
+	)
+}
+
+// GetByes returns the current event's byes in round order.
+func (s Repository) GetByes(ctx context.Context) ([]Bye, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT Round, TeamID FROM Byes WHERE EventID = ? ORDER BY Round`,
+		eventID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var byes []Bye
+	for rows.Next() {
+		var b Bye
+		err := rows.Scan(&b.Round, &b.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		byes = append(byes, b)
+	}
+
+	return byes, rows.Err()
+}
@@ -0,0 +1,52 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+func TestByes(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	tr := teams.NewRepository(db)
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c"} {
+		team, err := tr.Create(t.Context(), name)
+		assert.NoError(t, err)
+		ids = append(ids, team.ID)
+	}
+	a, b, c := ids[0], ids[1], ids[2]
+
+	g, err := s.CreateInRound(t.Context(), 1, a, b)
+	assert.NoError(t, err)
+	assert.NoError(t, s.GiveBye(t.Context(), 1, c))
+	assert.NoError(t, s.UpdateScores(t.Context(), g, a, 121, b, 100))
+
+	byes, err := s.GetByes(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, []Bye{{Round: 1, TeamID: c}}, byes)
+
+	// c's bye counts as a win, even though c hasn't played a game.
+	st, err := s.GetStandings(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, st, 3)
+	for _, standing := range st {
+		if standing.TeamID == c {
+			assert.Equal(t, 1, standing.Wins)
+			assert.Equal(t, 1, standing.Byes)
+			assert.Equal(t, 1, standing.GamesPlayed())
+			assert.Equal(t, 0, standing.PointSpread())
+			assert.Equal(t, 0.0, standing.PointsPerGame())
+		}
+	}
+
+	assert.NoError(t, s.DeleteAll(t.Context()))
+	byes, err = s.GetByes(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, byes, 0)
+}
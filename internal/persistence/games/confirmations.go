@@ -0,0 +1,142 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+// PendingScore marks a prelim game whose score was entered by a player and is waiting for someone
+// from the other team to confirm it. The score itself is in Scores like any other.
+type PendingScore struct {
+	GameID string
+	// ReportedBy is the team whose player entered the score.
+	ReportedBy string
+	// Dispute is the result the other team entered instead, if they disagreed. It's nil unless the
+	// game is disputed.
+	Dispute *DisputedScore
+}
+
+// DisputedScore is the other team's version of a disputed game: the winner scored 121.
+type DisputedScore struct {
+	WinnerID   string
+	LoserScore int
+}
+
+func (p PendingScore) Disputed() bool {
+	return p.Dispute != nil
+}
+
+// ConfirmScores reports whether the event that ctx is scoped to requires players' scores to be
+// confirmed by the other team.
+func (s Repository) ConfirmScores(ctx context.Context) (bool, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return false, err
+	}
+
+	e, err := events.NewRepository(s.db).Get(ctx, eventID)
+	if err != nil {
+		return false, err
+	}
+	return e.ConfirmScores, nil
+}
+
+// ReportScore sets the scores of a game like UpdateScores, but leaves it waiting for the team that
+// didn't report it to confirm. Reporting again replaces the score and any dispute.
+func (s Repository) ReportScore(
+	ctx context.Context,
+	gameID string,
+	reportedBy string,
+	team1ID string,
+	team1Score int,
+	team2ID string,
+	team2Score int,
+) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.WithTx(ctx, func(ctx context.Context) error {
+		err := s.updateScores(ctx, gameID, team1ID, team1Score, team2ID, team2Score)
+		if err != nil {
+			return err
+		}
+
+		return s.db.ExecOne(
+			ctx,
+			`INSERT INTO PendingScores (GameID, EventID, ReportedBy) VALUES (?, ?, ?)
+			ON CONFLICT (GameID) DO UPDATE SET
+				ReportedBy = excluded.ReportedBy, DisputeWinner = NULL, DisputeLoserScore = NULL`,
+			gameID, eventID, reportedBy,
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notifyScoreChange(ctx, gameID)
+}
+
+// ConfirmScore accepts the pending score of the given game.
+func (s Repository) ConfirmScore(ctx context.Context, gameID string) error {
+	err := s.db.ExecOne(ctx, `DELETE FROM PendingScores WHERE GameID = ?`, gameID)
+	if err != nil {
+		return err
+	}
+
+	return s.notifyScoreChange(ctx, gameID)
+}
+
+// DisputeScore records that the other team says winnerID won the given game, with the loser on
+// loserScore, instead of the pending score. The game doesn't count in the standings until an admin
+// settles it with UpdateScores.
+func (s Repository) DisputeScore(ctx context.Context, gameID, winnerID string, loserScore int) error {
+	err := s.db.ExecOne(
+		ctx,
+		`UPDATE PendingScores SET DisputeWinner = ?, DisputeLoserScore = ? WHERE GameID = ?`,
+		winnerID, loserScore, gameID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.notifyScoreChange(ctx, gameID)
+}
+
+// GetPendingScores returns the games of the current event that are waiting for confirmation or
+// disputed, by game ID.
+func (s Repository) GetPendingScores(ctx context.Context) (map[string]PendingScore, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT GameID, ReportedBy, DisputeWinner, DisputeLoserScore FROM PendingScores WHERE EventID = ?`,
+		eventID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]PendingScore)
+	for rows.Next() {
+		var p PendingScore
+		var winner sql.NullString
+		var loserScore sql.NullInt64
+		err := rows.Scan(&p.GameID, &p.ReportedBy, &winner, &loserScore)
+		if err != nil {
+			return nil, err
+		}
+		if winner.Valid {
+			p.Dispute = &DisputedScore{WinnerID: winner.String, LoserScore: int(loserScore.Int64)}
+		}
+		res[p.GameID] = p
+	}
+	return res, rows.Err()
+}
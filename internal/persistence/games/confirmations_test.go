@@ -0,0 +1,76 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+func TestPendingScores(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	tr := teams.NewRepository(db)
+	ctx := t.Context()
+
+	confirm, err := s.ConfirmScores(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, false, confirm)
+	assert.NoError(t, events.NewRepository(db).SetConfirmScores(ctx, events.LegacyID, true))
+	confirm, err = s.ConfirmScores(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, true, confirm)
+
+	a, err := tr.Create(ctx, "a")
+	assert.NoError(t, err)
+	b, err := tr.Create(ctx, "b")
+	assert.NoError(t, err)
+
+	g1, err := s.Create(ctx, a.ID, b.ID)
+	assert.NoError(t, err)
+	g2, err := s.Create(ctx, a.ID, b.ID)
+	assert.NoError(t, err)
+
+	// A reported score counts while it's waiting to be confirmed.
+	assert.NoError(t, s.ReportScore(ctx, g1, a.ID, a.ID, 121, b.ID, 90))
+	assert.NoError(t, s.ReportScore(ctx, g2, a.ID, a.ID, 121, b.ID, 100))
+
+	pending, err := s.GetPendingScores(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]PendingScore{
+		g1: {GameID: g1, ReportedBy: a.ID},
+		g2: {GameID: g2, ReportedBy: a.ID},
+	}, pending)
+
+	st, err := s.GetStandings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, st[0].GamesPlayed())
+
+	assert.NoError(t, s.ConfirmScore(ctx, g1))
+	assert.Error(t, s.ConfirmScore(ctx, g1))
+
+	// A disputed game doesn't count until it's settled.
+	assert.NoError(t, s.DisputeScore(ctx, g2, b.ID, 110))
+	pending, err = s.GetPendingScores(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]PendingScore{
+		g2: {GameID: g2, ReportedBy: a.ID, Dispute: &DisputedScore{WinnerID: b.ID, LoserScore: 110}},
+	}, pending)
+
+	st, err = s.GetStandings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, st[0].GamesPlayed())
+
+	assert.NoError(t, s.UpdateScores(ctx, g2, b.ID, 121, a.ID, 110))
+	pending, err = s.GetPendingScores(ctx)
+	assert.NoError(t, err)
+	assert.MapLen(t, pending, 0)
+
+	st, err = s.GetStandings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, st[0].GamesPlayed())
+	assert.Equal(t, 1, st[0].Wins)
+}
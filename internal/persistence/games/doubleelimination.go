@@ -0,0 +1,216 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/bits"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+// BracketSide is the part of a bracket a game is in. Single-elimination brackets only have the
+// winners side and, optionally, a third-place game.
+type BracketSide string
+
+const (
+	SideWinners BracketSide = "winners"
+	SideLosers  BracketSide = "losers"
+	// SideFinal holds the grand final in round 0 and the reset game in round 1.
+	SideFinal BracketSide = "final"
+	// SideThirdPlace holds a single-elimination bracket's third-place game in round 0.
+	SideThirdPlace BracketSide = "third-place"
+)
+
+// DoubleElimination is a double-elimination bracket. Each side's rounds are in order; see
+// InitializeDoubleElimination for their sizes.
+type DoubleElimination struct {
+	Winners []Round
+	Losers  []Round
+	Final   []Round
+}
+
+// IsDoubleEliminationSize reports whether a double-elimination bracket can be made for numTeams
+// teams: a power of two, at least four.
+func IsDoubleEliminationSize(numTeams int) bool {
+	return numTeams >= 4 && numTeams&(numTeams-1) == 0
+}
+
+// InitializeDoubleElimination creates the empty games of a double-elimination bracket. For n
+// teams, the winners side has log2(n) rounds, halving from n/2 games. The losers side has twice
+// as many rounds less two: the first pairs the first-round losers, and then every other round
+// takes in the losers of the next winners round, so its rounds go n/4, n/4, n/8, n/8, ..., 1, 1.
+// The final side has the grand final and its reset game.
+func (s Repository) InitializeDoubleElimination(ctx context.Context, numTeams int) error {
+	if !IsDoubleEliminationSize(numTeams) {
+		return errors.New("double elimination needs a power of two of at least four teams")
+	}
+
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	b := s.b.InsertIntoTable("DoubleEliminationGames").
+		Fields("EventID", "Bracket", "Side", "Round", "Idx")
+
+	winnersRounds := bits.Len(uint(numTeams)) - 1
+	for round := range winnersRounds {
+		for idx := range numTeams >> (round + 1) {
+			b = b.Values(eventID, s.bracket, SideWinners, round, idx)
+		}
+	}
+	for round := range 2 * (winnersRounds - 1) {
+		for idx := range numTeams >> (round/2 + 2) {
+			b = b.Values(eventID, s.bracket, SideLosers, round, idx)
+		}
+	}
+	b = b.Values(eventID, s.bracket, SideFinal, 0, 0)
+	b = b.Values(eventID, s.bracket, SideFinal, 1, 0)
+
+	_, err = b.ExecContext(ctx, s.db)
+	return err
+}
+
+// LoadDoubleElimination returns the event's double-elimination bracket. All of its sides are
+// empty if the event doesn't have one.
+func (s Repository) LoadDoubleElimination(ctx context.Context) (DoubleElimination, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return DoubleElimination{}, err
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT Side, Round, Idx, TeamID1, TeamID2, Winner FROM DoubleEliminationGames
+		WHERE EventID = ? AND Bracket = ?
+		ORDER BY Side, Round, Idx`,
+		eventID,
+		s.bracket,
+	)
+	if err != nil {
+		return DoubleElimination{}, err
+	}
+	defer rows.Close()
+
+	var de DoubleElimination
+	for rows.Next() {
+		var side BracketSide
+		var round, idx int
+		var teamID1, teamID2, winner sql.Null[string]
+		err := rows.Scan(&side, &round, &idx, &teamID1, &teamID2, &winner)
+		if err != nil {
+			return DoubleElimination{}, err
+		}
+
+		var rounds *[]Round
+		switch side {
+		case SideWinners:
+			rounds = &de.Winners
+		case SideLosers:
+			rounds = &de.Losers
+		case SideFinal:
+			rounds = &de.Final
+		default:
+			return DoubleElimination{}, errors.New("unknown bracket side: " + string(side))
+		}
+
+		// Rows come in order, so each game is the next one in its round.
+		if round == len(*rounds) {
+			*rounds = append(*rounds, Round{N: round})
+		}
+		(*rounds)[round].Games = append((*rounds)[round].Games, TournamentGame{
+			Round:   round,
+			TeamIDs: [2]string{teamID1.V, teamID2.V},
+			Winner:  winner.V,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return DoubleElimination{}, err
+	}
+
+	return de, nil
+}
+
+// PutTeamIntoDoubleEliminationGame puts teamID into the first (pos 0) or second (pos 1) slot of
+// the given game. The slot must be empty.
+func (s Repository) PutTeamIntoDoubleEliminationGame(
+	ctx context.Context,
+	side BracketSide,
+	round, idx, pos int,
+	teamID string,
+) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	col := "TeamID1"
+	if pos == 1 {
+		col = "TeamID2"
+	}
+	return s.db.ExecOne(
+		ctx,
+		`UPDATE DoubleEliminationGames SET `+col+` = ?
+		WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ? AND `+col+` IS NULL`,
+		teamID, eventID, s.bracket, side, round, idx,
+	)
+}
+
+func (s Repository) ClearTeamFromDoubleEliminationGame(
+	ctx context.Context,
+	side BracketSide,
+	round, idx int,
+	teamID string,
+) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range []string{"TeamID1", "TeamID2"} {
+		err := s.db.ExecVoid(
+			ctx,
+			`UPDATE DoubleEliminationGames SET `+col+` = NULL
+			WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ? AND `+col+` = ?`,
+			eventID, s.bracket, side, round, idx, teamID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Repository) SetDoubleEliminationWinner(
+	ctx context.Context,
+	side BracketSide,
+	round, idx int,
+	winner string,
+) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`UPDATE DoubleEliminationGames SET Winner = ?
+		WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ?`,
+		winner, eventID, s.bracket, side, round, idx,
+	)
+}
+
+func (s Repository) ClearDoubleEliminationWinner(ctx context.Context, side BracketSide, round, idx int) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`UPDATE DoubleEliminationGames SET Winner = NULL
+		WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ?`,
+		eventID, s.bracket, side, round, idx,
+	)
+}
@@ -0,0 +1,56 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+)
+
+func TestDoubleElimination(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	ctx := t.Context()
+
+	assert.Error(t, s.InitializeDoubleElimination(ctx, 2))
+	assert.Error(t, s.InitializeDoubleElimination(ctx, 6))
+	assert.NoError(t, s.InitializeDoubleElimination(ctx, 8))
+
+	de, err := s.LoadDoubleElimination(ctx)
+	assert.NoError(t, err)
+
+	gamesPerRound := func(rs []Round) []int {
+		var res []int
+		for _, r := range rs {
+			res = append(res, len(r.Games))
+		}
+		return res
+	}
+	assert.Equal(t, []int{4, 2, 1}, gamesPerRound(de.Winners))
+	assert.Equal(t, []int{2, 2, 1, 1}, gamesPerRound(de.Losers))
+	assert.Equal(t, []int{1, 1}, gamesPerRound(de.Final))
+
+	assert.NoError(t, s.PutTeamIntoDoubleEliminationGame(ctx, SideLosers, 1, 1, 1, "a"))
+	assert.Error(t, s.PutTeamIntoDoubleEliminationGame(ctx, SideLosers, 1, 1, 1, "b"))
+	assert.NoError(t, s.PutTeamIntoDoubleEliminationGame(ctx, SideLosers, 1, 1, 0, "b"))
+	assert.NoError(t, s.SetDoubleEliminationWinner(ctx, SideLosers, 1, 1, "a"))
+
+	de, err = s.LoadDoubleElimination(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]string{"b", "a"}, de.Losers[1].Games[1].TeamIDs)
+	assert.Equal(t, "a", de.Losers[1].Games[1].Winner)
+
+	assert.NoError(t, s.ClearDoubleEliminationWinner(ctx, SideLosers, 1, 1))
+	assert.NoError(t, s.ClearTeamFromDoubleEliminationGame(ctx, SideLosers, 1, 1, "a"))
+
+	de, err = s.LoadDoubleElimination(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]string{"b", ""}, de.Losers[1].Games[1].TeamIDs)
+	assert.Equal(t, "", de.Losers[1].Games[1].Winner)
+
+	assert.NoError(t, s.DeleteTournament(ctx))
+	de, err = s.LoadDoubleElimination(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, de.Winners, 0)
+}
@@ -0,0 +1,165 @@
+package games
+
+import (
+	"context"
+	"time"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+const (
+	PlaceChampion = 1
+	PlaceRunnerUp = 2
+)
+
+// FinalistPlayer is one of the people on a finalist team.
+type FinalistPlayer struct {
+	PersonID  string
+	FirstName string
+	LastName  string
+}
+
+// Finalist is a team that played in the final of an event's main bracket. It's kept when the
+// bracket is deleted, and so are the team's name and people as they were when the final was
+// decided.
+type Finalist struct {
+	EventID   string
+	EventName string
+	EventDate time.Time
+	// Place is PlaceChampion or PlaceRunnerUp.
+	Place    int
+	TeamID   string
+	TeamName string
+	Players  []FinalistPlayer
+}
+
+// SetFinalists records the champion and runner-up of the current event's main bracket, replacing
+// any that were recorded before.
+func (s Repository) SetFinalists(ctx context.Context, championID, runnerUpID string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		err := s.clearFinalists(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range []struct {
+			place  int
+			teamID string
+		}{{PlaceChampion, championID}, {PlaceRunnerUp, runnerUpID}} {
+			err := s.db.ExecOne(
+				ctx,
+				`INSERT INTO Finalists (EventID, Place, TeamID, TeamName)
+				SELECT ?, ?, ID, coalesce(Name, '') FROM Teams WHERE ID = ?`,
+				eventID, f.place, f.teamID,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = s.db.ExecVoid(
+				ctx,
+				`INSERT INTO FinalistPlayers (EventID, Place, PersonID)
+				SELECT DISTINCT ?, ?, PersonID FROM Players WHERE TeamID = ? AND PersonID IS NOT NULL`,
+				eventID, f.place, f.teamID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClearFinalists forgets the finalists of the current event.
+func (s Repository) ClearFinalists(ctx context.Context) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		return s.clearFinalists(ctx, eventID)
+	})
+}
+
+func (s Repository) clearFinalists(ctx context.Context, eventID string) error {
+	for _, table := range []string{"Finalists", "FinalistPlayers"} {
+		err := s.db.ExecVoid(ctx, `DELETE FROM `+table+` WHERE EventID = ?`, eventID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAllFinalists returns the finalists of every event, most recent event first, each event's
+// champion before its runner-up.
+func (s Repository) GetAllFinalists(ctx context.Context) ([]Finalist, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT f.EventID, e.Name, e.Date, f.Place, f.TeamID, f.TeamName
+		FROM Finalists f JOIN Events e ON e.ID = f.EventID
+		ORDER BY e.Date DESC, e.Name, f.Place`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fs []Finalist
+	for rows.Next() {
+		var f Finalist
+		err := rows.Scan(&f.EventID, &f.EventName, &f.EventDate, &f.Place, &f.TeamID, &f.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	players, err := s.finalistPlayers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range fs {
+		fs[i].Players = players[finalistKey{f.EventID, f.Place}]
+	}
+	return fs, nil
+}
+
+type finalistKey struct {
+	eventID string
+	place   int
+}
+
+func (s Repository) finalistPlayers(ctx context.Context) (map[finalistKey][]FinalistPlayer, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT fp.EventID, fp.Place, p.ID, p.FirstName, p.LastName
+		FROM FinalistPlayers fp JOIN People p ON p.ID = fp.PersonID
+		ORDER BY p.LastName, p.FirstName`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	players := make(map[finalistKey][]FinalistPlayer)
+	for rows.Next() {
+		var k finalistKey
+		var p FinalistPlayer
+		err := rows.Scan(&k.eventID, &k.place, &p.PersonID, &p.FirstName, &p.LastName)
+		if err != nil {
+			return nil, err
+		}
+		players[k] = append(players[k], p)
+	}
+	return players, rows.Err()
+}
@@ -0,0 +1,79 @@
+package games
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+func TestFinalists(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	er := events.NewRepository(db)
+	pr := players.NewRepository(db)
+	tr := teams.NewRepository(db)
+	ctx := t.Context()
+
+	newTeam := func(ctx context.Context, name string, firstNames ...string) teams.Team {
+		team, err := tr.Create(ctx, name)
+		assert.NoError(t, err)
+		for _, first := range firstNames {
+			id, err := pr.Create(ctx, first, "Smith")
+			assert.NoError(t, err)
+			assert.NoError(t, pr.AssignToTeam(ctx, id, team.ID))
+		}
+		return team
+	}
+
+	a := newTeam(ctx, "A", "Bob", "Alice")
+	b := newTeam(ctx, "B", "Carol")
+	assert.NoError(t, s.SetFinalists(ctx, a.ID, b.ID))
+
+	// Setting them again replaces them.
+	assert.NoError(t, s.SetFinalists(ctx, b.ID, a.ID))
+
+	next, err := er.Create(ctx, "Next Year", time.Now().AddDate(1, 0, 0))
+	assert.NoError(t, err)
+	nextCtx := events.WithEvent(ctx, next)
+	c := newTeam(nextCtx, "C", "Dave")
+	d := newTeam(nextCtx, "D", "Erin")
+	assert.NoError(t, s.SetFinalists(nextCtx, c.ID, d.ID))
+
+	// They outlive the teams and players.
+	assert.NoError(t, tr.Delete(ctx, a.ID))
+
+	fs, err := s.GetAllFinalists(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, fs, 4)
+
+	assert.Equal(t, next.ID, fs[0].EventID)
+	assert.Equal(t, "Next Year", fs[0].EventName)
+	assert.Equal(t, PlaceChampion, fs[0].Place)
+	assert.Equal(t, "C", fs[0].TeamName)
+	assert.SliceLen(t, fs[0].Players, 1)
+	assert.Equal(t, "Dave", fs[0].Players[0].FirstName)
+	assert.Equal(t, PlaceRunnerUp, fs[1].Place)
+	assert.Equal(t, "D", fs[1].TeamName)
+
+	assert.Equal(t, events.LegacyID, fs[2].EventID)
+	assert.Equal(t, PlaceChampion, fs[2].Place)
+	assert.Equal(t, "B", fs[2].TeamName)
+	assert.Equal(t, PlaceRunnerUp, fs[3].Place)
+	assert.Equal(t, "A", fs[3].TeamName)
+	assert.SliceLen(t, fs[3].Players, 2)
+	assert.Equal(t, "Alice", fs[3].Players[0].FirstName)
+	assert.Equal(t, "Bob", fs[3].Players[1].FirstName)
+
+	assert.NoError(t, s.ClearFinalists(nextCtx))
+	fs, err = s.GetAllFinalists(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, fs, 2)
+	assert.Equal(t, events.LegacyID, fs[0].EventID)
+}
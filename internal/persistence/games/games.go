@@ -5,7 +5,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"maps"
+	"math/bits"
 	"slices"
+	"time"
 
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/filter"
@@ -15,12 +18,20 @@ import (
 
 	"github.com/cszczepaniak/cribbly/internal/notifier"
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 )
 
 type Score struct {
 	GameID string
 	TeamID string
 	Score  int
+	// Round is the game's round in the prelim schedule, starting at 1. It's 0 for games that were
+	// created outside of a schedule.
+	Round int
+	// TableID is the table the game is played at, or empty if it hasn't been given one.
+	TableID string
+	// Crossover is true for games between teams of paired divisions; see CreateCrossover.
+	Crossover bool
 }
 
 type Game struct {
@@ -28,52 +39,103 @@ type Game struct {
 	Scores [2]Score
 }
 
+// ScoreChange is published on the score notifier whenever a game's scores change, or whether they
+// count does. It goes to the game's EventTopic, its GameTopic, and the DivisionTopic of each of its
+// teams' divisions.
+type ScoreChange struct {
+	EventID string
+	GameID  string
+	// Scores are the game's scores after the change, ordered by team ID.
+	Scores [2]Score
+	// Disputed is true if the game's teams disagree about its result, in which case it doesn't
+	// count in the standings; see DisputeScore.
+	Disputed bool
+}
+
+type ScoreNotifier = notifier.Notifier[ScoreChange]
+
+// EventTopic is the score notifier topic for every game in an event.
+func EventTopic(eventID string) string {
+	return "event:" + eventID
+}
+
+// GameTopic is the score notifier topic for a single game.
+func GameTopic(gameID string) string {
+	return "game:" + gameID
+}
+
+// DivisionTopic is the score notifier topic for the games of a division's teams.
+func DivisionTopic(divisionID string) string {
+	return "division:" + divisionID
+}
+
 type Repository struct {
 	db            database.Database
 	b             *sqlbuilder.Builder
-	scoreNotifier *notifier.Notifier
+	scoreNotifier *ScoreNotifier
+	// bracket is the bracket that the tournament methods work on.
+	bracket BracketName
 }
 
-func NewRepository(db database.Database, scoreNotifier *notifier.Notifier) Repository {
+func NewRepository(db database.Database, scoreNotifier *ScoreNotifier) Repository {
 	return Repository{
 		db:            db,
 		b:             sqlbuilder.New(formatter.Sqlite{}),
 		scoreNotifier: scoreNotifier,
+		bracket:       MainBracket,
 	}
 }
 
-func (s Repository) Init(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS Scores (
-			GameID VARCHAR(36),
-			TeamID VARCHAR(36),
-			Score SMALLINT,
-			
-			PRIMARY KEY (GameID, TeamID)
-		)`)
-	if err != nil {
-		return err
-	}
+// BracketName names one of an event's playoff brackets.
+type BracketName string
 
-	_, err = s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS TournamentGames (
-			Round   SMALLINT,
-			Idx     SMALLINT,
-			TeamID1 VARCHAR(36),
-			TeamID2 VARCHAR(36),
-			Winner  VARCHAR(36),
-			
-			PRIMARY KEY (Round, Idx)
-		)`)
+const (
+	// MainBracket is the bracket for the top teams in the standings.
+	MainBracket BracketName = "main"
+	// ConsolationBracket is an optional second bracket for the teams that just missed the main
+	// one.
+	ConsolationBracket BracketName = "consolation"
+)
 
-	return err
+// ForBracket returns a copy of the repository whose tournament methods (InitializeTournament,
+// LoadTournament, the double-elimination methods, and so on) work on the given bracket instead.
+func (s Repository) ForBracket(b BracketName) Repository {
+	s.bracket = b
+	return s
+}
+
+// CurrentEventID returns the ID of the event the repository's queries are scoped to for ctx.
+func (s Repository) CurrentEventID(ctx context.Context) (string, error) {
+	return events.CurrentID(ctx, s.db)
 }
 
 func (s Repository) Create(ctx context.Context, teamID1, teamID2 string) (string, error) {
+	return s.CreateInRound(ctx, 0, teamID1, teamID2)
+}
+
+// CreateInRound creates a game that's played in the given round of the prelim schedule.
+func (s Repository) CreateInRound(ctx context.Context, round int, teamID1, teamID2 string) (string, error) {
+	return s.create(ctx, round, false, teamID1, teamID2)
+}
+
+// CreateCrossover creates a crossover game, played in the given round of the prelim schedule
+// between teams of two paired divisions. It counts in the standings like any other prelim game.
+func (s Repository) CreateCrossover(ctx context.Context, round int, teamID1, teamID2 string) (string, error) {
+	return s.create(ctx, round, true, teamID1, teamID2)
+}
+
+func (s Repository) create(ctx context.Context, round int, crossover bool, teamID1, teamID2 string) (string, error) {
 	id := uuid.NewString()
 
-	_, err := s.b.InsertIntoTable("Scores").
-		Fields("GameID", "TeamID", "Score").
-		Values(id, teamID1, 0).
-		Values(id, teamID2, 0).
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.b.InsertIntoTable("Scores").
+		Fields("GameID", "TeamID", "Score", "EventID", "Round", "Crossover").
+		Values(id, teamID1, 0, eventID, round, crossover).
+		Values(id, teamID2, 0, eventID, round, crossover).
 		ExecContext(ctx, s.db)
 	if err != nil {
 		return "", err
@@ -82,36 +144,89 @@ func (s Repository) Create(ctx context.Context, teamID1, teamID2 string) (string
 	return id, nil
 }
 
+// SetTable assigns a prelim game to a table.
+func (s Repository) SetTable(ctx context.Context, gameID, tableID string) error {
+	_, err := s.b.UpdateTable("Scores").
+		SetFieldTo("TableID", tableID).
+		Where(filter.Equals("GameID", gameID)).
+		ExecContext(ctx, s.db)
+	return err
+}
+
+// BracketSlots returns the number of first-round slots in a bracket of numTeams teams: the next
+// power of two. The slots left over are byes.
+func BracketSlots(numTeams int) int {
+	if numTeams < 2 {
+		return 2
+	}
+	return 1 << bits.Len(uint(numTeams-1))
+}
+
+// InitializeTournament creates the empty games of a bracket for numTeams teams. Any number of
+// teams is allowed; the first round has room for BracketSlots(numTeams) teams and the empty slots
+// become byes when the bracket is seeded (see PutByeIntoTournamentGame).
 func (s Repository) InitializeTournament(ctx context.Context, numTeams int) error {
-	if (numTeams-1)&(numTeams) != 0 {
-		return errors.New("number of teams must be a power of two")
+	if numTeams < 2 {
+		return errors.New("a bracket needs at least two teams")
+	}
+
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
 	}
 
 	b := s.b.InsertIntoTable("TournamentGames").
-		Fields("Round", "Idx")
+		Fields("EventID", "Bracket", "Round", "Idx")
 
-	numGamesInRound := numTeams / 2
+	numGamesInRound := BracketSlots(numTeams) / 2
 	round := 0
 	for numGamesInRound > 0 {
 		for idx := range numGamesInRound {
-			b = b.Values(round, idx)
+			b = b.Values(eventID, s.bracket, round, idx)
 		}
 		numGamesInRound /= 2
 		round++
 	}
 
-	_, err := b.ExecContext(ctx, s.db)
+	_, err = b.ExecContext(ctx, s.db)
 	return err
 }
 
 func (s Repository) DeleteTournament(ctx context.Context) error {
-	return s.db.ExecVoid(ctx, `DELETE FROM TournamentGames`)
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	tables := []string{
+		"TournamentGames",
+		"DoubleEliminationGames",
+		"ThirdPlaceGames",
+		"Series",
+		"SeriesGames",
+		"BracketReports",
+	}
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		for _, table := range tables {
+			err := s.db.ExecVoid(
+				ctx,
+				`DELETE FROM `+table+` WHERE EventID = ? AND Bracket = ?`,
+				eventID, s.bracket,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 type TournamentGame struct {
 	Round   int
 	TeamIDs [2]string
 	Winner  string
+	// Bye is true for a first-round game with an empty slot. Its one team is already the winner.
+	Bye bool
 }
 
 type Round struct {
@@ -121,24 +236,36 @@ type Round struct {
 
 type Tournament struct {
 	Rounds []Round
+	// ThirdPlace is the game between the semifinal losers, or nil if the bracket doesn't have one.
+	ThirdPlace *TournamentGame
 }
 
 func (s Repository) LoadTournament(ctx context.Context) (Tournament, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return Tournament{}, err
+	}
+
 	rows, err := s.db.QueryContext(
 		ctx,
 		// Ordering by DESC here allows us to allocate the exact size of the various arrays below.
-		`SELECT Round, Idx, TeamID1, TeamID2, Winner FROM TournamentGames
+		`SELECT Round, Idx, TeamID1, TeamID2, Winner, Bye FROM TournamentGames
+		WHERE EventID = ? AND Bracket = ?
 		ORDER BY Round DESC, Idx DESC`,
+		eventID,
+		s.bracket,
 	)
 	if err != nil {
 		return Tournament{}, err
 	}
+	defer rows.Close()
 
 	var tourney Tournament
 	for rows.Next() {
 		var round, idx int
 		var teamID1, teamID2, winner sql.Null[string]
-		err := rows.Scan(&round, &idx, &teamID1, &teamID2, &winner)
+		var bye bool
+		err := rows.Scan(&round, &idx, &teamID1, &teamID2, &winner, &bye)
 		if err != nil {
 			return Tournament{}, err
 		}
@@ -153,61 +280,117 @@ func (s Repository) LoadTournament(ctx context.Context) (Tournament, error) {
 		thisGame := thisRound.Games[idx]
 		thisGame.TeamIDs = [2]string{teamID1.V, teamID2.V}
 		thisGame.Winner = winner.V
+		thisGame.Bye = bye
 		thisRound.Games[idx] = thisGame
 		tourney.Rounds[round] = thisRound
 	}
+	if err := rows.Err(); err != nil {
+		return Tournament{}, err
+	}
+
+	tourney.ThirdPlace, err = s.loadThirdPlaceGame(ctx, eventID)
+	if err != nil {
+		return Tournament{}, err
+	}
 
 	return tourney, nil
 }
 
 func (s Repository) PutTeam1IntoTournamentGame(ctx context.Context, round, idx int, teamID string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
 	return s.db.ExecOne(
 		ctx,
 		`UPDATE TournamentGames SET TeamID1 = ? 
-		WHERE Round = ? AND Idx = ? AND TeamID1 IS NULL`,
-		teamID, round, idx,
+		WHERE EventID = ? AND Bracket = ? AND Round = ? AND Idx = ? AND TeamID1 IS NULL`,
+		teamID, eventID, s.bracket, round, idx,
+	)
+}
+
+// PutByeIntoTournamentGame makes the given first-round game a bye: teamID takes the first slot,
+// the second stays empty, and teamID is recorded as the winner.
+func (s Repository) PutByeIntoTournamentGame(ctx context.Context, idx int, teamID string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`UPDATE TournamentGames SET TeamID1 = ?, Winner = ?, Bye = TRUE
+		WHERE EventID = ? AND Bracket = ? AND Round = 0 AND Idx = ? AND TeamID1 IS NULL AND TeamID2 IS NULL`,
+		teamID, teamID, eventID, s.bracket, idx,
 	)
 }
 
 func (s Repository) PutTeam2IntoTournamentGame(ctx context.Context, round, idx int, teamID string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
 	return s.db.ExecVoid(
 		ctx,
 		`UPDATE TournamentGames SET TeamID2 = ? 
-		WHERE Round = ? AND Idx = ? AND TeamID2 IS NULL`,
-		teamID, round, idx,
+		WHERE EventID = ? AND Bracket = ? AND Round = ? AND Idx = ? AND TeamID2 IS NULL`,
+		teamID, eventID, s.bracket, round, idx,
 	)
 }
 
 func (s Repository) SetTournamentGameWinner(ctx context.Context, round, idx int, winner string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
 	return s.db.ExecOne(
 		ctx,
-		`UPDATE TournamentGames SET Winner = ? WHERE Round = ? AND Idx = ?`,
+		`UPDATE TournamentGames SET Winner = ? WHERE EventID = ? AND Bracket = ? AND Round = ? AND Idx = ?`,
 		winner,
+		eventID,
+		s.bracket,
 		round,
 		idx,
 	)
 }
 
 func (s Repository) ClearTournamentGameWinner(ctx context.Context, round, idx int) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
 	return s.db.ExecOne(
 		ctx,
-		`UPDATE TournamentGames SET Winner = NULL WHERE Round = ? AND Idx = ?`,
+		`UPDATE TournamentGames SET Winner = NULL WHERE EventID = ? AND Bracket = ? AND Round = ? AND Idx = ?`,
+		eventID,
+		s.bracket,
 		round,
 		idx,
 	)
 }
 
 func (s Repository) ClearTeamFromTournamentGame(ctx context.Context, round, idx int, teamID string) error {
-	_, err := s.db.ExecContext(ctx,
-		`UPDATE TournamentGames SET TeamID1 = NULL WHERE Round = ? AND Idx = ? AND TeamID1 = ?`,
-		round, idx, teamID,
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE TournamentGames SET TeamID1 = NULL
+		WHERE EventID = ? AND Bracket = ? AND Round = ? AND Idx = ? AND TeamID1 = ?`,
+		eventID, s.bracket, round, idx, teamID,
 	)
 	if err != nil {
 		return err
 	}
 	_, err = s.db.ExecContext(ctx,
-		`UPDATE TournamentGames SET TeamID2 = NULL WHERE Round = ? AND Idx = ? AND TeamID2 = ?`,
-		round, idx, teamID,
+		`UPDATE TournamentGames SET TeamID2 = NULL
+		WHERE EventID = ? AND Bracket = ? AND Round = ? AND Idx = ? AND TeamID2 = ?`,
+		eventID, s.bracket, round, idx, teamID,
 	)
 	return err
 }
@@ -221,10 +404,11 @@ func (s Repository) UpdateScore(ctx context.Context, gameID, teamID string, scor
 		return err
 	}
 
-	s.scoreNotifier.Notify()
-	return nil
+	return s.notifyScoreChange(ctx, gameID)
 }
 
+// UpdateScores sets the scores of both teams in a game. Setting them this way settles the game: if
+// it was waiting for confirmation or disputed, it no longer is.
 func (s Repository) UpdateScores(
 	ctx context.Context,
 	gameID string,
@@ -232,6 +416,29 @@ func (s Repository) UpdateScores(
 	team1Score int,
 	team2ID string,
 	team2Score int,
+) error {
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		err := s.updateScores(ctx, gameID, team1ID, team1Score, team2ID, team2Score)
+		if err != nil {
+			return err
+		}
+
+		return s.db.ExecVoid(ctx, `DELETE FROM PendingScores WHERE GameID = ?`, gameID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notifyScoreChange(ctx, gameID)
+}
+
+func (s Repository) updateScores(
+	ctx context.Context,
+	gameID string,
+	team1ID string,
+	team1Score int,
+	team2ID string,
+	team2Score int,
 ) error {
 	return s.db.WithTx(ctx, func(ctx context.Context) error {
 		err := s.db.ExecOne(
@@ -245,21 +452,60 @@ func (s Repository) UpdateScores(
 			return err
 		}
 
-		err = s.db.ExecOne(
+		return s.db.ExecOne(
 			ctx,
 			"UPDATE Scores SET Score = ? WHERE GameID = ? AND TeamID = ?",
 			team2Score,
 			gameID,
 			team2ID,
 		)
+	})
+}
 
+// notifyScoreChange publishes the current scores of the given game. Call it after the change has
+// been committed so that subscribers who reload see it.
+func (s Repository) notifyScoreChange(ctx context.Context, gameID string) error {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT s.EventID, s.TeamID, s.Score, COALESCE(t.DivisionID, ''), EXISTS (
+			SELECT 1 FROM PendingScores WHERE GameID = s.GameID AND DisputeWinner IS NOT NULL
+		)
+		FROM Scores s LEFT JOIN Teams t ON s.TeamID = t.ID
+		WHERE s.GameID = ?
+		ORDER BY s.TeamID`,
+		gameID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	change := ScoreChange{GameID: gameID}
+	var divisionIDs []string
+	for i := 0; rows.Next(); i++ {
+		if i == 2 {
+			return errors.New("too many scores for game")
+		}
+
+		var divisionID string
+		change.Scores[i].GameID = gameID
+		err := rows.Scan(&change.EventID, &change.Scores[i].TeamID, &change.Scores[i].Score, &divisionID, &change.Disputed)
 		if err != nil {
 			return err
 		}
+		if divisionID != "" && !slices.Contains(divisionIDs, divisionID) {
+			divisionIDs = append(divisionIDs, divisionID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
-		s.scoreNotifier.Notify()
-		return nil
-	})
+	topics := []string{EventTopic(change.EventID), GameTopic(gameID)}
+	for _, id := range divisionIDs {
+		topics = append(topics, DivisionTopic(id))
+	}
+	s.scoreNotifier.Notify(change, topics...)
+	return nil
 }
 
 func (s Repository) GetScore(ctx context.Context, gameID, teamID string) (int, error) {
@@ -281,7 +527,15 @@ func (s Repository) GetScore(ctx context.Context, gameID, teamID string) (int, e
 }
 
 func (s Repository) GetAll(ctx context.Context) ([]Score, error) {
-	rows, err := s.b.SelectFrom(table.Named("Scores")).Columns("GameID", "TeamID", "Score").QueryContext(ctx, s.db)
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.b.SelectFrom(table.Named("Scores")).
+		Columns("GameID", "TeamID", "Score", "Round", "TableID", "Crossover").
+		Where(filter.Equals("EventID", eventID)).
+		QueryContext(ctx, s.db)
 	if err != nil {
 		return nil, err
 	}
@@ -290,7 +544,7 @@ func (s Repository) GetAll(ctx context.Context) ([]Score, error) {
 	var scores []Score
 	for rows.Next() {
 		var s Score
-		err := rows.Scan(&s.GameID, &s.TeamID, &s.Score)
+		err := rows.Scan(&s.GameID, &s.TeamID, &s.Score, &s.Round, &s.TableID, &s.Crossover)
 		if err != nil {
 			return nil, err
 		}
@@ -302,13 +556,29 @@ func (s Repository) GetAll(ctx context.Context) ([]Score, error) {
 }
 
 func (s Repository) DeleteAll(ctx context.Context) error {
-	_, err := s.b.DeleteFromTable("Scores").ExecContext(ctx, s.db)
-	return err
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := s.b.DeleteFromTable("Scores").Where(filter.Equals("EventID", eventID)).ExecContext(ctx, s.db)
+		if err != nil {
+			return err
+		}
+
+		err = s.db.ExecVoid(ctx, `DELETE FROM PendingScores WHERE EventID = ?`, eventID)
+		if err != nil {
+			return err
+		}
+
+		return s.db.ExecVoid(ctx, `DELETE FROM Byes WHERE EventID = ?`, eventID)
+	})
 }
 
 func (s Repository) Get(ctx context.Context, id string) ([2]Score, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT GameID, TeamID, Score FROM Scores WHERE GameID = ? ORDER BY TeamID`,
+		SELECT GameID, TeamID, Score, Round, TableID, Crossover FROM Scores WHERE GameID = ? ORDER BY TeamID`,
 		id,
 	)
 	if err != nil {
@@ -319,9 +589,10 @@ func (s Repository) Get(ctx context.Context, id string) ([2]Score, error) {
 	var res [2]Score
 	idx := 0
 	for rows.Next() {
-		var gameID, teamID string
-		var score int
-		err := rows.Scan(&gameID, &teamID, &score)
+		var gameID, teamID, tableID string
+		var score, round int
+		var crossover bool
+		err := rows.Scan(&gameID, &teamID, &score, &round, &tableID, &crossover)
 		if err != nil {
 			return [2]Score{}, err
 		}
@@ -331,9 +602,12 @@ func (s Repository) Get(ctx context.Context, id string) ([2]Score, error) {
 		}
 
 		res[idx] = Score{
-			GameID: gameID,
-			TeamID: teamID,
-			Score:  score,
+			GameID:    gameID,
+			TeamID:    teamID,
+			Score:     score,
+			Round:     round,
+			TableID:   tableID,
+			Crossover: crossover,
 		}
 		idx++
 	}
@@ -343,7 +617,7 @@ func (s Repository) Get(ctx context.Context, id string) ([2]Score, error) {
 
 func (s Repository) GetForTeam(ctx context.Context, teamID string) (map[string][2]Score, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT GameID, TeamID, Score FROM Scores WHERE GameID IN (
+		SELECT GameID, TeamID, Score, Round, TableID, Crossover FROM Scores WHERE GameID IN (
 			SELECT GameID FROM Scores WHERE TeamID = ?
 		) ORDER BY GameID, TeamID
 	`, teamID)
@@ -354,9 +628,10 @@ func (s Repository) GetForTeam(ctx context.Context, teamID string) (map[string][
 
 	res := make(map[string][2]Score)
 	for rows.Next() {
-		var gameID, teamID string
-		var score int
-		err := rows.Scan(&gameID, &teamID, &score)
+		var gameID, teamID, tableID string
+		var score, round int
+		var crossover bool
+		err := rows.Scan(&gameID, &teamID, &score, &round, &tableID, &crossover)
 		if err != nil {
 			return nil, err
 		}
@@ -365,9 +640,12 @@ func (s Repository) GetForTeam(ctx context.Context, teamID string) (map[string][
 		if !ok {
 			// Doesn't exist yet; fill in the first element.
 			res[gameID] = [2]Score{{
-				GameID: gameID,
-				TeamID: teamID,
-				Score:  score,
+				GameID:    gameID,
+				TeamID:    teamID,
+				Score:     score,
+				Round:     round,
+				TableID:   tableID,
+				Crossover: crossover,
 			}}
 			continue
 		}
@@ -378,9 +656,12 @@ func (s Repository) GetForTeam(ctx context.Context, teamID string) (map[string][
 		}
 
 		scores[1] = Score{
-			GameID: gameID,
-			TeamID: teamID,
-			Score:  score,
+			GameID:    gameID,
+			TeamID:    teamID,
+			Score:     score,
+			Round:     round,
+			TableID:   tableID,
+			Crossover: crossover,
 		}
 		res[gameID] = scores
 	}
@@ -388,12 +669,126 @@ func (s Repository) GetForTeam(ctx context.Context, teamID string) (map[string][
 	return res, nil
 }
 
+const (
+	// WinningScore is the score that wins a game.
+	WinningScore = 121
+	// SkunkLine is the score a loser must reach to avoid being skunked.
+	SkunkLine = 91
+	// DoubleSkunkLine is the score a loser must reach to avoid being double skunked.
+	DoubleSkunkLine = 61
+)
+
+// Result is how a game was won, graded by the loser's score.
+type Result int
+
+const (
+	ResultUndecided Result = iota
+	ResultWin
+	ResultSkunk
+	ResultDoubleSkunk
+)
+
+func (r Result) String() string {
+	switch r {
+	case ResultWin:
+		return "Win"
+	case ResultSkunk:
+		return "Skunk"
+	case ResultDoubleSkunk:
+		return "Double skunk"
+	default:
+		return ""
+	}
+}
+
+// WinnerPoints returns the game points the winner earns for this result under the given scheme.
+func (r Result) WinnerPoints(p events.PointScheme) int {
+	switch r {
+	case ResultWin:
+		return p.Win
+	case ResultSkunk:
+		return p.SkunkWin
+	case ResultDoubleSkunk:
+		return p.DoubleSkunkWin
+	default:
+		return 0
+	}
+}
+
+// GameResult grades a game from its two scores. The game is undecided until exactly one team has
+// reached WinningScore.
+func GameResult(score1, score2 int) Result {
+	var loser int
+	switch {
+	case score1 >= WinningScore && score2 < WinningScore:
+		loser = score2
+	case score2 >= WinningScore && score1 < WinningScore:
+		loser = score1
+	default:
+		return ResultUndecided
+	}
+
+	switch {
+	case loser < DoubleSkunkLine:
+		return ResultDoubleSkunk
+	case loser < SkunkLine:
+		return ResultSkunk
+	default:
+		return ResultWin
+	}
+}
+
+// GamePoints grades a game and returns the game points each team earned under the given scheme.
+// Both are zero while the game is undecided.
+func GamePoints(score1, score2 int, p events.PointScheme) (res Result, points1, points2 int) {
+	res = GameResult(score1, score2)
+	switch {
+	case res == ResultUndecided:
+		return res, 0, 0
+	case score1 > score2:
+		return res, res.WinnerPoints(p), p.Loss
+	default:
+		return res, p.Loss, res.WinnerPoints(p)
+	}
+}
+
 type Standing struct {
-	TeamID     string
-	TeamName   string
-	Wins       int
-	Losses     int
+	TeamID   string
+	TeamName string
+	Wins     int
+	Losses   int
+	// SkunksGiven is the number of wins where the loser finished under SkunkLine, including double
+	// skunks. DoubleSkunksGiven counts only those under DoubleSkunkLine.
+	SkunksGiven       int
+	DoubleSkunksGiven int
+	// SkunksTaken and DoubleSkunksTaken are the same for this team's losses.
+	SkunksTaken       int
+	DoubleSkunksTaken int
+	// GamePoints is the total earned under the event's point scheme.
+	GamePoints    int
+	PointsAllowed int
+	// Byes is the number of Swiss rounds the team sat out. Each one is counted as a win worth the
+	// event's win points, but doesn't affect the team's points.
+	Byes int
+	// Tiebreak is the tiebreaker that put this team ahead of the next one in the standings. It's
+	// empty if the two weren't level on games played and game points, and for the last team.
+	Tiebreak events.Tiebreaker
+	// CoinFlippedAt is when the deciding coin flip was recorded if Tiebreak is the coin flip.
+	CoinFlippedAt time.Time
+	// CoinFlipPending is true if this team is level with the next one on everything but a coin flip
+	// that hasn't been made yet. Coins are flipped when the standings are final; see
+	// FinalizeStandings.
+	CoinFlipPending bool
+
 	totalScore int
+	// results are this team's decided games.
+	results []result
+}
+
+type result struct {
+	opponentID    string
+	score         int
+	opponentScore int
 }
 
 // GamesPlayed returns the number of games this team has played.
@@ -410,45 +805,245 @@ func (s Standing) WinRate() float64 {
 	return float64(s.Wins) / float64(g)
 }
 
-// PointsPerGame returns points per game. Returns 0 when the team has played no games.
+// PointsPerGame returns points per game, not counting byes. Returns 0 when the team has played no
+// games.
 func (s Standing) PointsPerGame() float64 {
-	g := s.GamesPlayed()
+	g := s.GamesPlayed() - s.Byes
 	if g == 0 {
 		return 0
 	}
 	return float64(s.totalScore) / float64(g)
 }
 
-// GetStandings returns all teams ordered by standings. Ranking uses win rate, then loss rate,
-// then points per game so that teams in divisions with different game counts (e.g. 3 vs 4 teams)
-// are comparable: 2-0 and 3-0 both rank as undefeated.
+// PointSpread is points scored minus points allowed.
+func (s Standing) PointSpread() int {
+	return s.totalScore - s.PointsAllowed
+}
+
+// SkunkDifferential is skunks given minus skunks taken.
+func (s Standing) SkunkDifferential() int {
+	return s.SkunksGiven - s.SkunksTaken
+}
+
+func (s *Standing) addGame(opponentID string, score, opponentScore int, p events.PointScheme) {
+	res, points, _ := GamePoints(score, opponentScore, p)
+	if res == ResultUndecided {
+		return
+	}
+
+	s.results = append(s.results, result{
+		opponentID:    opponentID,
+		score:         score,
+		opponentScore: opponentScore,
+	})
+	s.totalScore += score
+	s.PointsAllowed += opponentScore
+	s.GamePoints += points
+	if score > opponentScore {
+		s.Wins++
+		if res >= ResultSkunk {
+			s.SkunksGiven++
+		}
+		if res == ResultDoubleSkunk {
+			s.DoubleSkunksGiven++
+		}
+	} else {
+		s.Losses++
+		if res >= ResultSkunk {
+			s.SkunksTaken++
+		}
+		if res == ResultDoubleSkunk {
+			s.DoubleSkunksTaken++
+		}
+	}
+}
+
+// PointScheme returns the game point scheme of the event that ctx is scoped to.
+func (s Repository) PointScheme(ctx context.Context) (events.PointScheme, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return events.PointScheme{}, err
+	}
+
+	e, err := events.NewRepository(s.db).Get(ctx, eventID)
+	if err != nil {
+		return events.PointScheme{}, err
+	}
+	return e.Points, nil
+}
+
+// GetStandings returns all teams ordered by standings. Only decided games count. Teams that have
+// played more games rank first so that a team isn't passed by one that just hasn't finished yet;
+// then teams rank by game points under the event's point scheme. Teams still level after that are
+// separated by the event's tiebreakers and finally a coin flip; see Standing.Tiebreak. GetStandings
+// only reads the coin flips that FinalizeStandings recorded, and teams still level without one stay
+// that way; see Standing.CoinFlipPending.
+//
+// Crossover games count the same as games within a division, and a Swiss bye counts as a win; see
+// Standing.Byes. Disputed games don't count until an admin settles them; see DisputeScore.
 func (s Repository) GetStandings(ctx context.Context) ([]Standing, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT s.TeamID, t.Name, SUM(s.Score), SUM(s.Score >= 121), SUM(s.Score > 0 AND s.Score < 121)
-		FROM Scores s INNER JOIN Teams t ON s.TeamID = t.ID
-		GROUP BY s.TeamID, t.Name
-	`)
+	return s.getStandings(ctx, false)
+}
+
+// FinalizeStandings returns the standings like GetStandings, first flipping a coin for each team
+// still level with another after every tiebreaker. The flips are recorded so the standings never
+// reshuffle. Call it when the standings decide something, such as a bracket's seeds.
+func (s Repository) FinalizeStandings(ctx context.Context) ([]Standing, error) {
+	return s.getStandings(ctx, true)
+}
+
+func (s Repository) getStandings(ctx context.Context, finalize bool) ([]Standing, error) {
+	recs, err := s.loadRecords(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return s.rank(ctx, recs, finalize)
+}
+
+// records are the games and byes behind an event's standings, by team.
+type records struct {
+	eventID     string
+	points      events.PointScheme
+	tiebreakers []events.Tiebreaker
+	teams       map[string]*record
+}
+
+type record struct {
+	name string
+	byes int
+	// games holds the team's games that count, by game ID. Undecided games are kept so that a team
+	// that has only played those is still in the standings.
+	games map[string]result
+}
+
+// team returns the record of the given team, adding an empty one if it doesn't have one yet.
+func (r records) team(teamID, name string) *record {
+	rec, ok := r.teams[teamID]
+	if !ok {
+		rec = &record{name: name, games: make(map[string]result)}
+		r.teams[teamID] = rec
+	}
+	return rec
+}
+
+func (s Repository) loadRecords(ctx context.Context) (records, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return records{}, err
+	}
+
+	e, err := events.NewRepository(s.db).Get(ctx, eventID)
+	if err != nil {
+		return records{}, err
+	}
+
+	recs := records{
+		eventID:     eventID,
+		points:      e.Points,
+		tiebreakers: e.Tiebreakers,
+		teams:       make(map[string]*record),
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT s.GameID, s.TeamID, t.Name, s.Score, o.TeamID, o.Score
+		FROM Scores s
+		INNER JOIN Teams t ON s.TeamID = t.ID
+		INNER JOIN Scores o ON o.GameID = s.GameID AND o.TeamID <> s.TeamID
+		WHERE s.EventID = ? AND s.GameID NOT IN (
+			SELECT GameID FROM PendingScores WHERE EventID = ? AND DisputeWinner IS NOT NULL
+		)
+	`, eventID, eventID)
+	if err != nil {
+		return records{}, err
+	}
 	defer rows.Close()
 
-	var res []Standing
 	for rows.Next() {
-		var st Standing
-		err := rows.Scan(&st.TeamID, &st.TeamName, &st.totalScore, &st.Wins, &st.Losses)
+		var gameID, teamID, teamName string
+		var r result
+		err := rows.Scan(&gameID, &teamID, &teamName, &r.score, &r.opponentID, &r.opponentScore)
 		if err != nil {
-			return nil, err
+			return records{}, err
+		}
+		recs.team(teamID, teamName).games[gameID] = r
+	}
+	if err := rows.Err(); err != nil {
+		return records{}, err
+	}
+	rows.Close()
+
+	byeRows, err := s.db.QueryContext(ctx, `
+		SELECT b.TeamID, t.Name
+		FROM Byes b
+		INNER JOIN Teams t ON b.TeamID = t.ID
+		WHERE b.EventID = ?
+	`, eventID)
+	if err != nil {
+		return records{}, err
+	}
+	defer byeRows.Close()
+
+	for byeRows.Next() {
+		var teamID, teamName string
+		err := byeRows.Scan(&teamID, &teamName)
+		if err != nil {
+			return records{}, err
+		}
+		recs.team(teamID, teamName).byes++
+	}
+	return recs, byeRows.Err()
+}
+
+// rank orders the teams in recs into the standings. If finalize is set, it flips a coin for each
+// team still level after every tiebreaker; otherwise it only reads the flips already recorded.
+func (s Repository) rank(ctx context.Context, recs records, finalize bool) ([]Standing, error) {
+	res := make([]*Standing, 0, len(recs.teams))
+	for teamID, rec := range recs.teams {
+		st := &Standing{TeamID: teamID, TeamName: rec.name}
+		for _, gameID := range slices.Sorted(maps.Keys(rec.games)) {
+			r := rec.games[gameID]
+			st.addGame(r.opponentID, r.score, r.opponentScore, recs.points)
 		}
+		st.Byes = rec.byes
+		st.Wins += rec.byes
+		st.GamePoints += rec.byes * recs.points.Win
 		res = append(res, st)
 	}
 
-	slices.SortFunc(res, func(a, b Standing) int {
-		return cmp.Or(
-			cmp.Compare(b.GamesPlayed(), a.GamesPlayed()),     // Games played (desc)
-			cmp.Compare(b.WinRate(), a.WinRate()),             // Win rate (desc)
-			cmp.Compare(b.PointsPerGame(), a.PointsPerGame()), // PPG (desc)
-		)
+	// Start from a fixed order so that teams the tiebreakers can't separate always come out the
+	// same way.
+	slices.SortFunc(res, func(a, b *Standing) int {
+		return cmp.Compare(a.TeamID, b.TeamID)
 	})
-	return res, nil
+	slices.SortStableFunc(res, compareRecords)
+
+	t := tiebreaking{
+		chain: append(slices.Clone(recs.tiebreakers), events.TiebreakCoinFlip),
+		flip: func(teamIDs []string) (map[string]coinFlip, error) {
+			if finalize {
+				return s.flipCoins(ctx, recs.eventID, teamIDs)
+			}
+			return s.coinFlips(ctx, recs.eventID, teamIDs)
+		},
+	}
+	for group := range groupsOf(res, compareRecords) {
+		err := t.breakTies(group, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	standings := make([]Standing, 0, len(res))
+	for _, st := range res {
+		standings = append(standings, *st)
+	}
+	return standings, nil
+}
+
+// compareRecords orders teams before tiebreakers: most games played, then most game points.
+func compareRecords(a, b *Standing) int {
+	return cmp.Or(
+		cmp.Compare(b.GamesPlayed(), a.GamesPlayed()),
+		cmp.Compare(b.GamePoints, a.GamePoints),
+	)
 }
@@ -3,19 +3,19 @@ package games
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cszczepaniak/gotest/assert"
 
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
-
-	"github.com/cszczepaniak/cribbly/internal/notifier"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
 )
 
 func TestGames(t *testing.T) {
-	n := &notifier.Notifier{}
+	n := &ScoreNotifier{}
 	db := database.NewInMemory(t)
 	s := NewRepository(db, n)
-	assert.NoError(t, s.Init(t.Context()))
 
 	t1 := "a"
 	t2 := "b"
@@ -76,11 +76,79 @@ func TestGames(t *testing.T) {
 	}, gs[g3])
 }
 
+func TestGames_CreateInRound(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+
+	g1, err := s.CreateInRound(t.Context(), 2, "a", "b")
+	assert.NoError(t, err)
+	g2, err := s.Create(t.Context(), "a", "c")
+	assert.NoError(t, err)
+
+	gs, err := s.GetForTeam(t.Context(), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, [2]Score{
+		{GameID: g1, TeamID: "a", Round: 2},
+		{GameID: g1, TeamID: "b", Round: 2},
+	}, gs[g1])
+	assert.Equal(t, [2]Score{
+		{GameID: g2, TeamID: "a"},
+		{GameID: g2, TeamID: "c"},
+	}, gs[g2])
+
+	scores, err := s.Get(t.Context(), g1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, scores[0].Round)
+	assert.Equal(t, 2, scores[1].Round)
+
+	assert.NoError(t, s.SetTable(t.Context(), g1, "table-7"))
+
+	scores, err = s.Get(t.Context(), g1)
+	assert.NoError(t, err)
+	assert.Equal(t, "table-7", scores[0].TableID)
+	assert.Equal(t, "table-7", scores[1].TableID)
+}
+
+func TestGames_CreateCrossover(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	tr := teams.NewRepository(db)
+
+	a, err := tr.Create(t.Context(), "a")
+	assert.NoError(t, err)
+	b, err := tr.Create(t.Context(), "b")
+	assert.NoError(t, err)
+
+	g1, err := s.CreateInRound(t.Context(), 1, a.ID, b.ID)
+	assert.NoError(t, err)
+	g2, err := s.CreateCrossover(t.Context(), 2, a.ID, b.ID)
+	assert.NoError(t, err)
+
+	scores, err := s.Get(t.Context(), g2)
+	assert.NoError(t, err)
+	assert.Equal(t, true, scores[0].Crossover)
+	assert.Equal(t, 2, scores[0].Round)
+
+	gs, err := s.GetForTeam(t.Context(), a.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, false, gs[g1][0].Crossover)
+	assert.Equal(t, true, gs[g2][1].Crossover)
+
+	// Crossover games count in the standings.
+	assert.NoError(t, s.UpdateScores(t.Context(), g1, a.ID, 121, b.ID, 110))
+	assert.NoError(t, s.UpdateScores(t.Context(), g2, a.ID, 121, b.ID, 115))
+
+	st, err := s.GetStandings(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, a.ID, st[0].TeamID)
+	assert.Equal(t, 2, st[0].Wins)
+	assert.Equal(t, 2, st[1].Losses)
+}
+
 func TestGames_Notifications(t *testing.T) {
-	n := &notifier.Notifier{}
+	n := &ScoreNotifier{}
 	db := database.NewInMemory(t)
 	s := NewRepository(db, n)
-	assert.NoError(t, s.Init(t.Context()))
 
 	t1 := "a"
 	t2 := "b"
@@ -92,23 +160,62 @@ func TestGames_Notifications(t *testing.T) {
 	g2, err := s.Create(t.Context(), t1, t3)
 	assert.NoError(t, err)
 
+	assert.NoError(t, db.ExecVoid(t.Context(), `INSERT INTO Teams (ID, Name, EventID, DivisionID) VALUES
+		('a', 'A', 'legacy', 'd1'), ('c', 'C', 'legacy', 'd2')`))
+
 	sub, cancel := n.Subscribe()
 	t.Cleanup(cancel)
+	eventSub, cancelEvent := n.Subscribe(EventTopic(events.LegacyID))
+	t.Cleanup(cancelEvent)
+	otherSub, cancelOther := n.Subscribe(EventTopic("other"))
+	t.Cleanup(cancelOther)
+	g2Sub, cancelG2 := n.Subscribe(GameTopic(g2))
+	t.Cleanup(cancelG2)
+	d2Sub, cancelD2 := n.Subscribe(DivisionTopic("d2"))
+	t.Cleanup(cancelD2)
 
 	assert.NoError(t, s.UpdateScore(t.Context(), g1, t1, 100))
-	// should get notified
-	<-sub
+	ev := <-sub
+	assert.Equal(t, ScoreChange{
+		EventID: events.LegacyID,
+		GameID:  g1,
+		Scores: [2]Score{
+			{GameID: g1, TeamID: t1, Score: 100},
+			{GameID: g1, TeamID: t2, Score: 0},
+		},
+	}, ev.Change)
+	assert.Equal(t, []string{EventTopic(events.LegacyID), GameTopic(g1), DivisionTopic("d1")}, ev.Topics)
 
 	assert.NoError(t, s.UpdateScores(t.Context(), g2, t1, 121, t3, 99))
-	// should get notified
-	<-sub
+	ev = <-sub
+	assert.Equal(t, g2, ev.Change.GameID)
+	assert.Equal(t, [2]Score{
+		{GameID: g2, TeamID: t1, Score: 121},
+		{GameID: g2, TeamID: t3, Score: 99},
+	}, ev.Change.Scores)
+
+	// Both changes went to the event's topic and none to another event's.
+	assert.Equal(t, g1, (<-eventSub).Change.GameID)
+	assert.Equal(t, g2, (<-eventSub).Change.GameID)
+	assert.Equal(t, 0, len(otherSub))
+
+	// Only the change to g2 went to its topic and to the division of its second team.
+	assert.Equal(t, g2, (<-g2Sub).Change.GameID)
+	assert.Equal(t, 0, len(g2Sub))
+	assert.Equal(t, g2, (<-d2Sub).Change.GameID)
+	assert.Equal(t, 0, len(d2Sub))
+
+	// A disputed game is published as one, since it stops counting.
+	assert.NoError(t, s.ReportScore(t.Context(), g2, t1, t1, 121, t3, 90))
+	assert.Equal(t, false, (<-sub).Change.Disputed)
+	assert.NoError(t, s.DisputeScore(t.Context(), g2, t3, 100))
+	assert.Equal(t, true, (<-sub).Change.Disputed)
 }
 
 func TestGames_UpdateScores_TeamsMustExistForGame(t *testing.T) {
-	n := &notifier.Notifier{}
+	n := &ScoreNotifier{}
 	db := database.NewInMemory(t)
 	s := NewRepository(db, n)
-	assert.NoError(t, s.Init(t.Context()))
 
 	t1 := "a"
 	t2 := "b"
@@ -131,12 +238,11 @@ func TestGames_UpdateScores_TeamsMustExistForGame(t *testing.T) {
 }
 
 func TestTournamentGames(t *testing.T) {
-	n := &notifier.Notifier{}
+	n := &ScoreNotifier{}
 	db := database.NewInMemory(t)
 	s := NewRepository(db, n)
-	assert.NoError(t, s.Init(t.Context()))
 
-	err := s.InitializeTournament(t.Context(), 17)
+	err := s.InitializeTournament(t.Context(), 1)
 	assert.Error(t, err)
 
 	assert.NoError(t, s.InitializeTournament(t.Context(), 32))
@@ -192,3 +298,341 @@ func TestTournamentGames(t *testing.T) {
 		assert.Equal(t, t2, g.TeamIDs[1])
 	}
 }
+
+func TestTournamentGames_Byes(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+
+	assert.Equal(t, 2, BracketSlots(2))
+	assert.Equal(t, 16, BracketSlots(12))
+	assert.Equal(t, 16, BracketSlots(16))
+	assert.Equal(t, 32, BracketSlots(20))
+
+	// 12 teams fill a 16-team bracket with 4 byes.
+	assert.NoError(t, s.InitializeTournament(t.Context(), 12))
+
+	tourney, err := s.LoadTournament(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, tourney.Rounds, 4)
+	assert.SliceLen(t, tourney.Rounds[0].Games, 8)
+
+	assert.NoError(t, s.PutByeIntoTournamentGame(t.Context(), 0, "team0"))
+	assert.Error(t, s.PutByeIntoTournamentGame(t.Context(), 0, "team1"))
+
+	tourney, err = s.LoadTournament(t.Context())
+	assert.NoError(t, err)
+	bye := tourney.Rounds[0].Games[0]
+	assert.Equal(t, true, bye.Bye)
+	assert.Equal(t, [2]string{"team0", ""}, bye.TeamIDs)
+	assert.Equal(t, "team0", bye.Winner)
+	assert.Equal(t, false, tourney.Rounds[0].Games[1].Bye)
+}
+
+func TestTournamentGames_ConsolationBracket(t *testing.T) {
+	db := database.NewInMemory(t)
+	main := NewRepository(db, &ScoreNotifier{})
+	consolation := main.ForBracket(ConsolationBracket)
+	ctx := t.Context()
+
+	assert.NoError(t, main.InitializeTournament(ctx, 4))
+	assert.NoError(t, consolation.InitializeTournament(ctx, 2))
+	assert.NoError(t, consolation.PutTeam1IntoTournamentGame(ctx, 0, 0, "a"))
+
+	tourney, err := main.LoadTournament(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, tourney.Rounds, 2)
+	assert.Equal(t, "", tourney.Rounds[0].Games[0].TeamIDs[0])
+
+	tourney, err = consolation.LoadTournament(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, tourney.Rounds, 1)
+	assert.Equal(t, "a", tourney.Rounds[0].Games[0].TeamIDs[0])
+
+	assert.NoError(t, consolation.DeleteTournament(ctx))
+	tourney, err = main.LoadTournament(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, tourney.Rounds, 2)
+}
+
+func TestGames_ScopedToEvent(t *testing.T) {
+	n := &ScoreNotifier{}
+	db := database.NewInMemory(t)
+	s := NewRepository(db, n)
+
+	other, err := events.NewRepository(db).Create(t.Context(), "other", time.Now())
+	assert.NoError(t, err)
+	otherCtx := events.WithEvent(t.Context(), other)
+
+	_, err = s.Create(t.Context(), "a", "b")
+	assert.NoError(t, err)
+	_, err = s.Create(otherCtx, "c", "d")
+	assert.NoError(t, err)
+
+	scores, err := s.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, scores, 2)
+	assert.Equal(t, "a", scores[0].TeamID)
+
+	scores, err = s.GetAll(otherCtx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, scores, 2)
+	assert.Equal(t, "c", scores[0].TeamID)
+
+	// Each event has its own bracket.
+	assert.NoError(t, s.InitializeTournament(t.Context(), 4))
+	assert.NoError(t, s.InitializeTournament(otherCtx, 2))
+	assert.NoError(t, s.PutTeam1IntoTournamentGame(otherCtx, 0, 0, "c"))
+
+	tourney, err := s.LoadTournament(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, tourney.Rounds, 2)
+	assert.Equal(t, "", tourney.Rounds[0].Games[0].TeamIDs[0])
+
+	tourney, err = s.LoadTournament(otherCtx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, tourney.Rounds, 1)
+	assert.Equal(t, "c", tourney.Rounds[0].Games[0].TeamIDs[0])
+
+	assert.NoError(t, s.DeleteAll(otherCtx))
+	assert.NoError(t, s.DeleteTournament(otherCtx))
+
+	scores, err = s.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, scores, 2)
+
+	tourney, err = s.LoadTournament(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, tourney.Rounds, 2)
+}
+
+func TestGameResult(t *testing.T) {
+	tests := []struct {
+		score1, score2 int
+		exp            Result
+	}{
+		{0, 0, ResultUndecided},
+		{100, 80, ResultUndecided},
+		{121, 91, ResultWin},
+		{90, 121, ResultSkunk},
+		{121, 61, ResultSkunk},
+		{121, 60, ResultDoubleSkunk},
+		{121, 121, ResultUndecided},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.exp, GameResult(tc.score1, tc.score2))
+	}
+}
+
+func TestGetStandings_Skunks(t *testing.T) {
+	n := &ScoreNotifier{}
+	db := database.NewInMemory(t)
+	s := NewRepository(db, n)
+	tr := teams.NewRepository(db)
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		team, err := tr.Create(t.Context(), name)
+		assert.NoError(t, err)
+		ids = append(ids, team.ID)
+	}
+	a, b, c, d := ids[0], ids[1], ids[2], ids[3]
+
+	play := func(t1, t2 string, s1, s2 int) {
+		t.Helper()
+		g, err := s.Create(t.Context(), t1, t2)
+		assert.NoError(t, err)
+		assert.NoError(t, s.UpdateScores(t.Context(), g, t1, s1, t2, s2))
+	}
+
+	play(a, c, 121, 100) // a wins
+	play(a, b, 121, 95)  // a wins
+	play(b, d, 121, 50)  // b double skunks d
+	play(c, d, 121, 80)  // c skunks d
+	play(a, d, 121, 95)  // a wins
+	play(c, b, 0, 0)     // not played yet
+
+	st, err := s.GetStandings(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, st, 4)
+
+	// a and d have played 3 games; b and c have played 2.
+	assert.Equal(t, a, st[0].TeamID)
+	assert.Equal(t, 6, st[0].GamePoints)
+	assert.Equal(t, d, st[1].TeamID)
+	assert.Equal(t, 0, st[1].GamePoints)
+	assert.Equal(t, 2, st[1].SkunksTaken)
+	assert.Equal(t, 1, st[1].DoubleSkunksTaken)
+	assert.Equal(t, -2, st[1].SkunkDifferential())
+
+	// b's double skunk beats c's skunk even though both are 1-1.
+	assert.Equal(t, b, st[2].TeamID)
+	assert.Equal(t, 4, st[2].GamePoints)
+	assert.Equal(t, 1, st[2].SkunksGiven)
+	assert.Equal(t, 1, st[2].DoubleSkunksGiven)
+	assert.Equal(t, c, st[3].TeamID)
+	assert.Equal(t, 3, st[3].GamePoints)
+	assert.Equal(t, 1, st[3].SkunksGiven)
+	assert.Equal(t, 0, st[3].DoubleSkunksGiven)
+
+	// With a flat scheme, b and c are tied on points and haven't played each other, so b's better
+	// point spread (+45 to +20) decides it.
+	assert.NoError(t, events.NewRepository(db).SetPoints(
+		t.Context(),
+		events.LegacyID,
+		events.PointScheme{Win: 1, SkunkWin: 1, DoubleSkunkWin: 1, Loss: 0},
+	))
+	st, err = s.GetStandings(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, b, st[2].TeamID)
+	assert.Equal(t, 1, st[2].GamePoints)
+	assert.Equal(t, events.TiebreakPointSpread, st[2].Tiebreak)
+	assert.Equal(t, c, st[3].TeamID)
+	assert.Equal(t, 1, st[3].GamePoints)
+	assert.Equal(t, events.Tiebreaker(""), st[3].Tiebreak)
+}
+
+func TestGetStandings_Tiebreakers(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	tr := teams.NewRepository(db)
+	er := events.NewRepository(db)
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		team, err := tr.Create(t.Context(), name)
+		assert.NoError(t, err)
+		ids = append(ids, team.ID)
+	}
+	a, b, c, d := ids[0], ids[1], ids[2], ids[3]
+
+	play := func(t1, t2 string, s1, s2 int) {
+		t.Helper()
+		g, err := s.Create(t.Context(), t1, t2)
+		assert.NoError(t, err)
+		assert.NoError(t, s.UpdateScores(t.Context(), g, t1, s1, t2, s2))
+	}
+
+	play(a, b, 121, 120)
+	play(c, a, 121, 91)
+	play(b, d, 121, 91)
+	play(c, d, 121, 120)
+
+	teamIDs := func(st []Standing) []string {
+		var res []string
+		for _, s := range st {
+			res = append(res, s.TeamID)
+		}
+		return res
+	}
+
+	// a and b are both 1-1; a beat b even though b has the better point spread.
+	st, err := s.GetStandings(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{c, a, b, d}, teamIDs(st))
+	assert.Equal(t, events.Tiebreaker(""), st[0].Tiebreak)
+	assert.Equal(t, events.TiebreakHeadToHead, st[1].Tiebreak)
+	assert.Equal(t, -29, st[1].PointSpread())
+	assert.Equal(t, 29, st[2].PointSpread())
+
+	assert.NoError(t, er.SetTiebreakers(
+		t.Context(),
+		events.LegacyID,
+		[]events.Tiebreaker{events.TiebreakPointSpread, events.TiebreakHeadToHead},
+	))
+	st, err = s.GetStandings(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{c, b, a, d}, teamIDs(st))
+	assert.Equal(t, events.TiebreakPointSpread, st[1].Tiebreak)
+}
+
+func TestGetStandings_TiebreakersThreeWayCycle(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	tr := teams.NewRepository(db)
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c"} {
+		team, err := tr.Create(t.Context(), name)
+		assert.NoError(t, err)
+		ids = append(ids, team.ID)
+	}
+	a, b, c := ids[0], ids[1], ids[2]
+
+	play := func(t1, t2 string, s1, s2 int) {
+		t.Helper()
+		g, err := s.Create(t.Context(), t1, t2)
+		assert.NoError(t, err)
+		assert.NoError(t, s.UpdateScores(t.Context(), g, t1, s1, t2, s2))
+	}
+
+	// Everyone is 1-1 and beat one of the others, so head-to-head can't separate them.
+	play(a, b, 121, 119)
+	play(b, c, 121, 91)
+	play(c, a, 121, 95)
+
+	st, err := s.GetStandings(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, st, 3)
+	assert.Equal(t, b, st[0].TeamID)
+	assert.Equal(t, 28, st[0].PointSpread())
+	assert.Equal(t, events.TiebreakPointSpread, st[0].Tiebreak)
+	assert.Equal(t, c, st[1].TeamID)
+	assert.Equal(t, -4, st[1].PointSpread())
+	assert.Equal(t, events.TiebreakPointSpread, st[1].Tiebreak)
+	assert.Equal(t, a, st[2].TeamID)
+	assert.Equal(t, -24, st[2].PointSpread())
+}
+
+func TestGetStandings_CoinFlip(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	tr := teams.NewRepository(db)
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		team, err := tr.Create(t.Context(), name)
+		assert.NoError(t, err)
+		ids = append(ids, team.ID)
+	}
+
+	// a and b are identical on every tiebreaker, and so are c and d.
+	for _, pair := range [][2]string{{ids[0], ids[2]}, {ids[1], ids[3]}} {
+		g, err := s.Create(t.Context(), pair[0], pair[1])
+		assert.NoError(t, err)
+		assert.NoError(t, s.UpdateScores(t.Context(), g, pair[0], 121, pair[1], 100))
+	}
+
+	// Reading the standings doesn't flip any coins, so the ties stay.
+	pending, err := s.GetStandings(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, pending, 4)
+	assert.Equal(t, events.Tiebreaker(""), pending[0].Tiebreak)
+	assert.Equal(t, true, pending[0].CoinFlipPending)
+	assert.Equal(t, false, pending[1].CoinFlipPending)
+	assert.Equal(t, true, pending[2].CoinFlipPending)
+	assert.Equal(t, false, pending[3].CoinFlipPending)
+
+	var n int
+	assert.NoError(t, db.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM CoinFlips`).Scan(&n))
+	assert.Equal(t, 0, n)
+
+	first, err := s.FinalizeStandings(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, first, 4)
+	assert.Equal(t, events.TiebreakCoinFlip, first[0].Tiebreak)
+	assert.Equal(t, false, first[0].CoinFlippedAt.IsZero())
+	assert.Equal(t, false, first[0].CoinFlipPending)
+	assert.Equal(t, events.Tiebreaker(""), first[1].Tiebreak)
+	assert.Equal(t, events.TiebreakCoinFlip, first[2].Tiebreak)
+
+	// The flips are recorded, so the order never changes.
+	for range 5 {
+		st, err := s.GetStandings(t.Context())
+		assert.NoError(t, err)
+		for i := range st {
+			assert.Equal(t, first[i].TeamID, st[i].TeamID)
+			assert.Equal(t, first[i].CoinFlipPending, st[i].CoinFlipPending)
+			assert.Equal(t, first[i].CoinFlippedAt, st[i].CoinFlippedAt)
+		}
+	}
+}
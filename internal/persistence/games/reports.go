@@ -0,0 +1,84 @@
+package games
+
+import (
+	"context"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+// BracketReport is a team's report of a bracket game.
+type BracketReport struct {
+	Won bool
+	// LoserScore is the losing team's score; the winner has 121. It's 0 for reports made before
+	// scores were reported.
+	LoserScore int
+	// Reporter identifies who made the report, or is empty if that isn't known.
+	Reporter string
+}
+
+// LoadBracketReports returns the results that teams have reported for the bracket's games, by
+// reporting team.
+func (s Repository) LoadBracketReports(ctx context.Context) (map[BracketSlot]map[string]BracketReport, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT Side, Round, Idx, TeamID, Won, LoserScore, Reporter FROM BracketReports WHERE EventID = ? AND Bracket = ?`,
+		eventID, s.bracket,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[BracketSlot]map[string]BracketReport)
+	for rows.Next() {
+		var slot BracketSlot
+		var teamID string
+		var r BracketReport
+		err := rows.Scan(&slot.Side, &slot.Round, &slot.Idx, &teamID, &r.Won, &r.LoserScore, &r.Reporter)
+		if err != nil {
+			return nil, err
+		}
+		if res[slot] == nil {
+			res[slot] = make(map[string]BracketReport)
+		}
+		res[slot][teamID] = r
+	}
+	return res, rows.Err()
+}
+
+// PutBracketReport records teamID's report of the given bracket game, replacing any report they
+// already made.
+func (s Repository) PutBracketReport(ctx context.Context, side BracketSide, round, idx int, teamID string, r BracketReport) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`INSERT INTO BracketReports (EventID, Bracket, Side, Round, Idx, TeamID, Won, LoserScore, Reporter)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (EventID, Bracket, Side, Round, Idx, TeamID)
+		DO UPDATE SET Won = excluded.Won, LoserScore = excluded.LoserScore, Reporter = excluded.Reporter`,
+		eventID, s.bracket, side, round, idx, teamID, r.Won, r.LoserScore, r.Reporter,
+	)
+}
+
+// DeleteBracketReports removes every report of the given bracket game.
+func (s Repository) DeleteBracketReports(ctx context.Context, side BracketSide, round, idx int) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecVoid(
+		ctx,
+		`DELETE FROM BracketReports WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ?`,
+		eventID, s.bracket, side, round, idx,
+	)
+}
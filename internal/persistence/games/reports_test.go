@@ -0,0 +1,50 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+)
+
+func TestBracketReports(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	ctx := t.Context()
+
+	final := BracketSlot{SideWinners, 1, 0}
+	semi := BracketSlot{SideWinners, 0, 1}
+
+	won := BracketReport{Won: true, LoserScore: 90, Reporter: "x"}
+	assert.NoError(t, s.PutBracketReport(ctx, SideWinners, 1, 0, "a", won))
+	assert.NoError(t, s.PutBracketReport(ctx, SideWinners, 1, 0, "b", BracketReport{Won: true}))
+	assert.NoError(t, s.PutBracketReport(ctx, SideWinners, 0, 1, "c", won))
+	// A team can change their report.
+	lost := BracketReport{Won: false, LoserScore: 118, Reporter: "y"}
+	assert.NoError(t, s.PutBracketReport(ctx, SideWinners, 0, 1, "c", lost))
+	assert.NoError(t, s.ForBracket(ConsolationBracket).PutBracketReport(ctx, SideWinners, 1, 0, "d", won))
+
+	reports, err := s.LoadBracketReports(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[BracketSlot]map[string]BracketReport{
+		final: {"a": won, "b": {Won: true}},
+		semi:  {"c": lost},
+	}, reports)
+
+	assert.NoError(t, s.DeleteBracketReports(ctx, SideWinners, 1, 0))
+	reports, err = s.LoadBracketReports(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[BracketSlot]map[string]BracketReport{
+		semi: {"c": lost},
+	}, reports)
+
+	assert.NoError(t, s.DeleteTournament(ctx))
+	reports, err = s.LoadBracketReports(ctx)
+	assert.NoError(t, err)
+	assert.MapLen(t, reports, 0)
+
+	reports, err = s.ForBracket(ConsolationBracket).LoadBracketReports(ctx)
+	assert.NoError(t, err)
+	assert.MapLen(t, reports, 1)
+}
@@ -0,0 +1,143 @@
+package games
+
+import (
+	"context"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+// BracketSlot identifies a game in a bracket.
+type BracketSlot struct {
+	Side  BracketSide
+	Round int
+	Idx   int
+}
+
+// Series is a best-of-N bracket game. Games holds the scores of each game played so far, in the
+// order of the bracket game's teams.
+type Series struct {
+	BestOf int
+	Games  [][2]int
+}
+
+// LoadSeries returns the series of the bracket. Games that are neither longer than one game nor
+// have any scores recorded aren't included.
+func (s Repository) LoadSeries(ctx context.Context) (map[BracketSlot]Series, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[BracketSlot]Series)
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT Side, Round, Idx, BestOf FROM Series WHERE EventID = ? AND Bracket = ?`,
+		eventID, s.bracket,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slot BracketSlot
+		var bestOf int
+		err := rows.Scan(&slot.Side, &slot.Round, &slot.Idx, &bestOf)
+		if err != nil {
+			return nil, err
+		}
+		res[slot] = Series{BestOf: bestOf}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	gameRows, err := s.db.QueryContext(
+		ctx,
+		`SELECT Side, Round, Idx, Score1, Score2 FROM SeriesGames
+		WHERE EventID = ? AND Bracket = ?
+		ORDER BY Side, Round, Idx, Game`,
+		eventID, s.bracket,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer gameRows.Close()
+
+	for gameRows.Next() {
+		var slot BracketSlot
+		var scores [2]int
+		err := gameRows.Scan(&slot.Side, &slot.Round, &slot.Idx, &scores[0], &scores[1])
+		if err != nil {
+			return nil, err
+		}
+		series, ok := res[slot]
+		if !ok {
+			series.BestOf = 1
+		}
+		series.Games = append(series.Games, scores)
+		res[slot] = series
+	}
+	return res, gameRows.Err()
+}
+
+// SetSeriesLength makes the given bracket game a best-of-bestOf series.
+func (s Repository) SetSeriesLength(ctx context.Context, side BracketSide, round, idx, bestOf int) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	if bestOf == 1 {
+		return s.db.ExecVoid(
+			ctx,
+			`DELETE FROM Series WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ?`,
+			eventID, s.bracket, side, round, idx,
+		)
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`INSERT INTO Series (EventID, Bracket, Side, Round, Idx, BestOf) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (EventID, Bracket, Side, Round, Idx) DO UPDATE SET BestOf = excluded.BestOf`,
+		eventID, s.bracket, side, round, idx, bestOf,
+	)
+}
+
+// AddSeriesGame records the scores of the next game of the given bracket game's series, in the
+// order of its teams.
+func (s Repository) AddSeriesGame(ctx context.Context, side BracketSide, round, idx, score1, score2 int) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`INSERT INTO SeriesGames (EventID, Bracket, Side, Round, Idx, Game, Score1, Score2)
+		SELECT ?, ?, ?, ?, ?, COUNT(*), ?, ? FROM SeriesGames
+		WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ?`,
+		eventID, s.bracket, side, round, idx, score1, score2,
+		eventID, s.bracket, side, round, idx,
+	)
+}
+
+// DeleteLastSeriesGame removes the most recent game of the given bracket game's series.
+func (s Repository) DeleteLastSeriesGame(ctx context.Context, side BracketSide, round, idx int) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`DELETE FROM SeriesGames
+		WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ? AND Game = (
+			SELECT MAX(Game) FROM SeriesGames
+			WHERE EventID = ? AND Bracket = ? AND Side = ? AND Round = ? AND Idx = ?
+		)`,
+		eventID, s.bracket, side, round, idx,
+		eventID, s.bracket, side, round, idx,
+	)
+}
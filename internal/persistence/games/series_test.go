@@ -0,0 +1,52 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+)
+
+func TestSeries(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	ctx := t.Context()
+
+	final := BracketSlot{SideWinners, 1, 0}
+	semi := BracketSlot{SideWinners, 0, 1}
+
+	assert.NoError(t, s.SetSeriesLength(ctx, SideWinners, 1, 0, 5))
+	assert.NoError(t, s.SetSeriesLength(ctx, SideWinners, 1, 0, 3))
+	assert.NoError(t, s.AddSeriesGame(ctx, SideWinners, 1, 0, 121, 90))
+	assert.NoError(t, s.AddSeriesGame(ctx, SideWinners, 1, 0, 100, 121))
+	// A single game can have its score recorded too.
+	assert.NoError(t, s.AddSeriesGame(ctx, SideWinners, 0, 1, 80, 121))
+
+	series, err := s.LoadSeries(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[BracketSlot]Series{
+		final: {BestOf: 3, Games: [][2]int{{121, 90}, {100, 121}}},
+		semi:  {BestOf: 1, Games: [][2]int{{80, 121}}},
+	}, series)
+
+	assert.NoError(t, s.DeleteLastSeriesGame(ctx, SideWinners, 1, 0))
+	assert.NoError(t, s.DeleteLastSeriesGame(ctx, SideWinners, 0, 1))
+	assert.Error(t, s.DeleteLastSeriesGame(ctx, SideWinners, 0, 1))
+	assert.NoError(t, s.AddSeriesGame(ctx, SideWinners, 1, 0, 121, 3))
+
+	// The consolation bracket's games are separate.
+	assert.NoError(t, s.ForBracket(ConsolationBracket).SetSeriesLength(ctx, SideWinners, 1, 0, 7))
+
+	series, err = s.LoadSeries(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[BracketSlot]Series{
+		final: {BestOf: 3, Games: [][2]int{{121, 90}, {121, 3}}},
+	}, series)
+
+	assert.NoError(t, s.SetSeriesLength(ctx, SideWinners, 1, 0, 1))
+	assert.NoError(t, s.DeleteTournament(ctx))
+	series, err = s.LoadSeries(ctx)
+	assert.NoError(t, err)
+	assert.MapLen(t, series, 0)
+}
@@ -0,0 +1,64 @@
+package games
+
+import "context"
+
+// LiveStandings holds the records behind an event's standings so that they can be kept up to date
+// from score changes instead of reading every score again for each one. Byes and team names are
+// those at the time it was loaded.
+type LiveStandings struct {
+	repo Repository
+	recs records
+}
+
+// LoadStandings loads the records behind the standings of the event that ctx is scoped to.
+func (s Repository) LoadStandings(ctx context.Context) (*LiveStandings, error) {
+	recs, err := s.loadRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &LiveStandings{repo: s, recs: recs}, nil
+}
+
+// Apply updates the records of the game in c to its new scores. It returns false if it can't,
+// because c is for another event or a team it has no record of; the caller should load the
+// standings again.
+func (l *LiveStandings) Apply(c ScoreChange) bool {
+	if c.EventID != l.recs.eventID {
+		return false
+	}
+
+	if c.Disputed {
+		// Disputed games don't count until they're settled.
+		for _, sc := range c.Scores {
+			rec, ok := l.recs.teams[sc.TeamID]
+			if !ok {
+				continue
+			}
+			delete(rec.games, c.GameID)
+			if len(rec.games) == 0 && rec.byes == 0 {
+				delete(l.recs.teams, sc.TeamID)
+			}
+		}
+		return true
+	}
+
+	for _, sc := range c.Scores {
+		if _, ok := l.recs.teams[sc.TeamID]; !ok {
+			return false
+		}
+	}
+	for i, sc := range c.Scores {
+		o := c.Scores[1-i]
+		l.recs.teams[sc.TeamID].games[c.GameID] = result{
+			opponentID:    o.TeamID,
+			score:         sc.Score,
+			opponentScore: o.Score,
+		}
+	}
+	return true
+}
+
+// Standings ranks the teams like GetStandings. It only reads the coin flips that have been recorded.
+func (l *LiveStandings) Standings(ctx context.Context) ([]Standing, error) {
+	return l.repo.rank(ctx, l.recs, false)
+}
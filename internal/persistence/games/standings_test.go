@@ -0,0 +1,121 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+// standingRow is what the standings show of a team, without Standing's unexported fields.
+type standingRow struct {
+	TeamID          string
+	Wins, Losses    int
+	GamePoints      int
+	PointSpread     int
+	PointsPerGame   float64
+	Tiebreak        events.Tiebreaker
+	CoinFlipPending bool
+}
+
+func standingRows(st []Standing) []standingRow {
+	var res []standingRow
+	for _, s := range st {
+		res = append(res, standingRow{
+			TeamID:          s.TeamID,
+			Wins:            s.Wins,
+			Losses:          s.Losses,
+			GamePoints:      s.GamePoints,
+			PointSpread:     s.PointSpread(),
+			PointsPerGame:   s.PointsPerGame(),
+			Tiebreak:        s.Tiebreak,
+			CoinFlipPending: s.CoinFlipPending,
+		})
+	}
+	return res
+}
+
+func TestLiveStandings_Apply(t *testing.T) {
+	n := &ScoreNotifier{}
+	db := database.NewInMemory(t)
+	s := NewRepository(db, n)
+	tr := teams.NewRepository(db)
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		team, err := tr.Create(t.Context(), name)
+		assert.NoError(t, err)
+		ids = append(ids, team.ID)
+	}
+	a, b, c, d := ids[0], ids[1], ids[2], ids[3]
+
+	create := func(t1, t2 string) string {
+		t.Helper()
+		g, err := s.Create(t.Context(), t1, t2)
+		assert.NoError(t, err)
+		return g
+	}
+	ab := create(a, b)
+	cd := create(c, d)
+	ac := create(a, c)
+	assert.NoError(t, s.UpdateScores(t.Context(), ab, a, 121, b, 100))
+
+	live, err := s.LoadStandings(t.Context())
+	assert.NoError(t, err)
+
+	sub, cancel := n.Subscribe()
+	t.Cleanup(cancel)
+
+	// apply applies the next change and checks that the standings are the same as reading them
+	// again.
+	apply := func() {
+		t.Helper()
+		assert.Equal(t, true, live.Apply((<-sub).Change))
+
+		got, err := live.Standings(t.Context())
+		assert.NoError(t, err)
+		want, err := s.GetStandings(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, standingRows(want), standingRows(got))
+	}
+
+	assert.NoError(t, s.UpdateScores(t.Context(), cd, c, 121, d, 80))
+	apply()
+
+	// A correction replaces the game's old scores rather than adding to them.
+	assert.NoError(t, s.UpdateScores(t.Context(), cd, c, 110, d, 121))
+	apply()
+
+	assert.NoError(t, s.UpdateScores(t.Context(), ac, a, 121, c, 60))
+	apply()
+
+	// A disputed game stops counting.
+	assert.NoError(t, s.ReportScore(t.Context(), ab, a, a, 121, b, 100))
+	apply()
+	assert.NoError(t, s.DisputeScore(t.Context(), ab, b, 110))
+	apply()
+
+	st, err := live.Standings(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, st, 3)
+	for _, row := range st {
+		assert.Equal(t, false, row.TeamID == b)
+	}
+
+	// b's only game was the disputed one, so settling it brings back a team the standings no
+	// longer have a record of.
+	assert.NoError(t, s.UpdateScores(t.Context(), ab, a, 121, b, 110))
+	assert.Equal(t, false, live.Apply((<-sub).Change))
+}
+
+func TestLiveStandings_Apply_OtherEvent(t *testing.T) {
+	s := NewRepository(database.NewInMemory(t), &ScoreNotifier{})
+
+	live, err := s.LoadStandings(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, false, live.Apply(ScoreChange{EventID: "other", GameID: "g"}))
+}
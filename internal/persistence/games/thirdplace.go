@@ -0,0 +1,110 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+// InitializeThirdPlaceGame creates the empty third-place game of the bracket. It's filled in with
+// the semifinal losers as the semifinals are decided.
+func (s Repository) InitializeThirdPlaceGame(ctx context.Context) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.b.InsertIntoTable("ThirdPlaceGames").
+		Fields("EventID", "Bracket").
+		Values(eventID, s.bracket).
+		ExecContext(ctx, s.db)
+	return err
+}
+
+func (s Repository) loadThirdPlaceGame(ctx context.Context, eventID string) (*TournamentGame, error) {
+	var teamID1, teamID2, winner sql.Null[string]
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT TeamID1, TeamID2, Winner FROM ThirdPlaceGames WHERE EventID = ? AND Bracket = ?`,
+		eventID, s.bracket,
+	).Scan(&teamID1, &teamID2, &winner)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &TournamentGame{
+		TeamIDs: [2]string{teamID1.V, teamID2.V},
+		Winner:  winner.V,
+	}, nil
+}
+
+// PutTeamIntoThirdPlaceGame puts teamID into the first (pos 0) or second (pos 1) slot of the
+// third-place game.
+func (s Repository) PutTeamIntoThirdPlaceGame(ctx context.Context, pos int, teamID string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	col := "TeamID1"
+	if pos == 1 {
+		col = "TeamID2"
+	}
+	return s.db.ExecOne(
+		ctx,
+		`UPDATE ThirdPlaceGames SET `+col+` = ?
+		WHERE EventID = ? AND Bracket = ? AND `+col+` IS NULL`,
+		teamID, eventID, s.bracket,
+	)
+}
+
+func (s Repository) ClearTeamFromThirdPlaceGame(ctx context.Context, teamID string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range []string{"TeamID1", "TeamID2"} {
+		err := s.db.ExecVoid(
+			ctx,
+			`UPDATE ThirdPlaceGames SET `+col+` = NULL
+			WHERE EventID = ? AND Bracket = ? AND `+col+` = ?`,
+			eventID, s.bracket, teamID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Repository) SetThirdPlaceWinner(ctx context.Context, winner string) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`UPDATE ThirdPlaceGames SET Winner = ? WHERE EventID = ? AND Bracket = ?`,
+		winner, eventID, s.bracket,
+	)
+}
+
+func (s Repository) ClearThirdPlaceWinner(ctx context.Context) error {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecOne(
+		ctx,
+		`UPDATE ThirdPlaceGames SET Winner = NULL WHERE EventID = ? AND Bracket = ?`,
+		eventID, s.bracket,
+	)
+}
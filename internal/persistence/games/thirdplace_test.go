@@ -0,0 +1,47 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+)
+
+func TestThirdPlaceGame(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db, &ScoreNotifier{})
+	ctx := t.Context()
+
+	assert.NoError(t, s.InitializeTournament(ctx, 4))
+	tourney, err := s.LoadTournament(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, (*TournamentGame)(nil), tourney.ThirdPlace)
+
+	assert.NoError(t, s.InitializeThirdPlaceGame(ctx))
+	assert.NoError(t, s.PutTeamIntoThirdPlaceGame(ctx, 1, "a"))
+	assert.Error(t, s.PutTeamIntoThirdPlaceGame(ctx, 1, "b"))
+	assert.NoError(t, s.PutTeamIntoThirdPlaceGame(ctx, 0, "b"))
+	assert.NoError(t, s.SetThirdPlaceWinner(ctx, "a"))
+
+	tourney, err = s.LoadTournament(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &TournamentGame{TeamIDs: [2]string{"b", "a"}, Winner: "a"}, tourney.ThirdPlace)
+
+	// The consolation bracket doesn't share it.
+	cons, err := s.ForBracket(ConsolationBracket).LoadTournament(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, (*TournamentGame)(nil), cons.ThirdPlace)
+
+	assert.NoError(t, s.ClearThirdPlaceWinner(ctx))
+	assert.NoError(t, s.ClearTeamFromThirdPlaceGame(ctx, "a"))
+
+	tourney, err = s.LoadTournament(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &TournamentGame{TeamIDs: [2]string{"b", ""}}, tourney.ThirdPlace)
+
+	assert.NoError(t, s.DeleteTournament(ctx))
+	tourney, err = s.LoadTournament(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, (*TournamentGame)(nil), tourney.ThirdPlace)
+}
@@ -0,0 +1,195 @@
+package games
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+type coinFlip struct {
+	draw      int64
+	flippedAt time.Time
+}
+
+// tiebreaking ranks teams that are level on their records.
+type tiebreaking struct {
+	// chain is the event's tiebreakers in order, ending with the coin flip.
+	chain []events.Tiebreaker
+	// flip returns the coin flip of each of the given teams. Teams whose coin hasn't been flipped
+	// may be missing, and then the teams stay level; see Standing.CoinFlipPending.
+	flip func(teamIDs []string) (map[string]coinFlip, error)
+}
+
+// breakTies orders a group of teams that are level on everything before chain[from]. The first
+// tiebreaker that separates any of them splits the group, and each part is ranked again from the
+// start of the chain; head-to-head in particular only counts games against the teams that are
+// still tied.
+func (t tiebreaking) breakTies(group []*Standing, from int) error {
+	if len(group) < 2 {
+		return nil
+	}
+
+	for i := from; i < len(t.chain); i++ {
+		tb := t.chain[i]
+		keys, flips, err := t.keys(tb, group)
+		if err != nil {
+			return err
+		}
+
+		byKey := func(a, b *Standing) int {
+			return cmp.Compare(keys[b.TeamID], keys[a.TeamID])
+		}
+		slices.SortStableFunc(group, byKey)
+
+		var runs [][]*Standing
+		for run := range groupsOf(group, byKey) {
+			runs = append(runs, run)
+		}
+		if len(runs) == 1 {
+			continue
+		}
+
+		for j, run := range runs {
+			err := t.breakTies(run, 0)
+			if err != nil {
+				return err
+			}
+			if j == len(runs)-1 {
+				continue
+			}
+
+			last := run[len(run)-1]
+			last.Tiebreak = tb
+			if tb == events.TiebreakCoinFlip {
+				last.CoinFlippedAt = flips[last.TeamID].flippedAt
+			}
+		}
+		return nil
+	}
+
+	// Nothing separated them, which only happens before their coins are flipped.
+	for _, st := range group[:len(group)-1] {
+		st.CoinFlipPending = true
+	}
+	return nil
+}
+
+// keys returns each team's value for the tiebreaker; higher is better.
+func (t tiebreaking) keys(tb events.Tiebreaker, group []*Standing) (map[string]int64, map[string]coinFlip, error) {
+	keys := make(map[string]int64, len(group))
+	switch tb {
+	case events.TiebreakHeadToHead:
+		inGroup := make(map[string]bool, len(group))
+		for _, st := range group {
+			inGroup[st.TeamID] = true
+		}
+		for _, st := range group {
+			for _, r := range st.results {
+				if !inGroup[r.opponentID] {
+					continue
+				}
+				if r.score > r.opponentScore {
+					keys[st.TeamID]++
+				} else {
+					keys[st.TeamID]--
+				}
+			}
+		}
+	case events.TiebreakPointSpread:
+		for _, st := range group {
+			keys[st.TeamID] = int64(st.PointSpread())
+		}
+	case events.TiebreakPointsAllowed:
+		for _, st := range group {
+			keys[st.TeamID] = -int64(st.PointsAllowed)
+		}
+	case events.TiebreakSkunkDifferential:
+		for _, st := range group {
+			keys[st.TeamID] = int64(st.SkunkDifferential())
+		}
+	case events.TiebreakCoinFlip:
+		teamIDs := make([]string, 0, len(group))
+		for _, st := range group {
+			teamIDs = append(teamIDs, st.TeamID)
+		}
+		flips, err := t.flip(teamIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(flips) < len(group) {
+			return keys, nil, nil
+		}
+		for _, st := range group {
+			keys[st.TeamID] = flips[st.TeamID].draw
+		}
+		return keys, flips, nil
+	}
+	return keys, nil, nil
+}
+
+// groupsOf yields the runs of consecutive elements of a sorted slice that compare as equal.
+func groupsOf[T any](s []T, compare func(a, b T) int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		start := 0
+		for i := 1; i <= len(s); i++ {
+			if i < len(s) && compare(s[start], s[i]) == 0 {
+				continue
+			}
+			if !yield(s[start:i]) {
+				return
+			}
+			start = i
+		}
+	}
+}
+
+// flipCoins returns the coin flip of each of the given teams in the event. A team's flip is
+// recorded the first time it's needed and reused from then on so the standings never reshuffle.
+func (s Repository) flipCoins(ctx context.Context, eventID string, teamIDs []string) (map[string]coinFlip, error) {
+	var res map[string]coinFlip
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		for _, teamID := range teamIDs {
+			err := s.db.ExecVoid(ctx, `
+				INSERT OR IGNORE INTO CoinFlips (EventID, TeamID, Draw, FlippedAt) VALUES (?, ?, ?, ?)
+			`, eventID, teamID, rand.Int64(), now)
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		res, err = s.coinFlips(ctx, eventID, teamIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// coinFlips returns the recorded coin flips of those of the given teams in the event that have one.
+func (s Repository) coinFlips(ctx context.Context, eventID string, teamIDs []string) (map[string]coinFlip, error) {
+	res := make(map[string]coinFlip, len(teamIDs))
+	for _, teamID := range teamIDs {
+		var f coinFlip
+		err := s.db.QueryRowContext(ctx, `
+			SELECT Draw, FlippedAt FROM CoinFlips WHERE EventID = ? AND TeamID = ?
+		`, eventID, teamID).Scan(&f.draw, &f.flippedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res[teamID] = f
+	}
+	return res, nil
+}
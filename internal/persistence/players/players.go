@@ -7,9 +7,9 @@ import (
 	"fmt"
 	"iter"
 	"slices"
+	"strings"
 
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder"
-	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/column"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/filter"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/formatter"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/sel"
@@ -17,23 +17,45 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 )
 
 var (
 	ErrPlayerAlreadyOnATeam = errors.New("player was already assigned to a team")
+	ErrSamePlayer           = errors.New("a player can't be paired with themselves")
+	ErrPersonInEvent        = errors.New("that person already has a player in this event")
 )
 
 type Player struct {
 	ID        string
+	EventID   string
 	FirstName string
 	LastName  string
 	TeamID    string
+	// PersonID is the person this player is. It's the same for the players of every event the
+	// person plays in; see Person.
+	PersonID string
+	// Rating is how strong the player is; higher is stronger. It's used to balance teams.
+	Rating int
 }
 
 func (p Player) Name() string {
 	return p.FirstName + " " + p.LastName
 }
 
+// Person is someone who plays, from one event to the next. Each event has its own player for them.
+// A new player is linked to a person with the same name (ignoring case and surrounding spaces) by
+// default, and an admin can link them to someone else; see LinkToPerson.
+type Person struct {
+	ID        string
+	FirstName string
+	LastName  string
+}
+
+func (p Person) Name() string {
+	return p.FirstName + " " + p.LastName
+}
+
 type Repository struct {
 	db database.Database
 	b  *sqlbuilder.Builder
@@ -46,22 +68,15 @@ func NewRepository(db database.Database) Repository {
 	}
 }
 
-func (s Repository) Init(ctx context.Context) error {
-	_, err := s.b.CreateTable("Players").
-		IfNotExists().
-		Columns(
-			column.VarChar("ID", 36).PrimaryKey(),
-			column.VarChar("FirstName", 255),
-			column.VarChar("LastName", 255),
-			column.VarChar("TeamID", 36).DefaultNull(),
-		).
-		ExecContext(ctx, s.db)
-	return err
-}
-
 func (s Repository) GetAll(ctx context.Context) ([]Player, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
 	return scanPlayers(
 		s.selectPlayers().
+			Where(filter.Equals("EventID", eventID)).
 			QueryContext(ctx, s.db),
 	)
 }
@@ -93,11 +108,202 @@ func (s Repository) GetFreeAgent(ctx context.Context, id string) (Player, error)
 	return scanPlayer(row)
 }
 
+// GetPerson returns the person with the given ID.
+func (s Repository) GetPerson(ctx context.Context, id string) (Person, error) {
+	var p Person
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT ID, FirstName, LastName FROM People WHERE ID = ?`,
+		id,
+	).Scan(&p.ID, &p.FirstName, &p.LastName)
+	if err != nil {
+		return Person{}, err
+	}
+	return p, nil
+}
+
+// GetForPerson returns the person's player in every event they've played in.
+func (s Repository) GetForPerson(ctx context.Context, personID string) ([]Player, error) {
+	return scanPlayers(
+		s.selectPlayers().
+			Where(filter.Equals("PersonID", personID)).
+			QueryContext(ctx, s.db),
+	)
+}
+
+// GetPeople returns everyone who has played, sorted by name.
+func (s Repository) GetPeople(ctx context.Context) ([]Person, error) {
+	return s.queryPeople(ctx, `SELECT ID, FirstName, LastName FROM People ORDER BY LastName, FirstName, ID`)
+}
+
+// GetPeopleNamed returns the people with the given name, ignoring case and surrounding spaces. They
+// are who a player with that name is likely to be.
+func (s Repository) GetPeopleNamed(ctx context.Context, firstName, lastName string) ([]Person, error) {
+	return s.queryPeople(
+		ctx,
+		`SELECT ID, FirstName, LastName FROM People WHERE NameKey = ? ORDER BY ID`,
+		nameKey(firstName, lastName),
+	)
+}
+
+func (s Repository) queryPeople(ctx context.Context, query string, args ...any) ([]Person, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ps []Person
+	for rows.Next() {
+		var p Person
+		err := rows.Scan(&p.ID, &p.FirstName, &p.LastName)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	return ps, rows.Err()
+}
+
+func nameKey(firstName, lastName string) string {
+	return strings.ToLower(strings.TrimSpace(firstName)) + " " + strings.ToLower(strings.TrimSpace(lastName))
+}
+
+// personFor returns the ID of the person a new player in the given event with the given name is by
+// default: whoever with that name played most recently and isn't playing in the event already, or a
+// new person if there's nobody like that.
+func (s Repository) personFor(ctx context.Context, eventID, firstName, lastName string) (string, error) {
+	var id string
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT pe.ID FROM People pe
+		LEFT JOIN Players p ON p.PersonID = pe.ID
+		LEFT JOIN Events e ON e.ID = p.EventID
+		WHERE pe.NameKey = ?
+			AND NOT EXISTS (SELECT 1 FROM Players WHERE PersonID = pe.ID AND EventID = ?)
+		GROUP BY pe.ID
+		ORDER BY max(e.Date) DESC, pe.ID
+		LIMIT 1`,
+		nameKey(firstName, lastName), eventID,
+	).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	return s.createPerson(ctx, firstName, lastName)
+}
+
+func (s Repository) createPerson(ctx context.Context, firstName, lastName string) (string, error) {
+	id := uuid.NewString()
+	err := s.db.ExecOne(
+		ctx,
+		`INSERT INTO People (ID, FirstName, LastName, NameKey) VALUES (?, ?, ?, ?)`,
+		id, strings.TrimSpace(firstName), strings.TrimSpace(lastName), nameKey(firstName, lastName),
+	)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// LinkToPerson makes the player the given person, for when the name they were matched by picked the
+// wrong one. A person can only have one player per event.
+func (s Repository) LinkToPerson(ctx context.Context, playerID, personID string) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		p, err := s.Get(ctx, playerID)
+		if err != nil {
+			return err
+		}
+		if p.PersonID == personID {
+			return nil
+		}
+
+		_, err = s.GetPerson(ctx, personID)
+		if err != nil {
+			return err
+		}
+
+		var n int
+		err = s.db.QueryRowContext(
+			ctx,
+			`SELECT count(*) FROM Players WHERE PersonID = ? AND EventID = ?`,
+			personID, p.EventID,
+		).Scan(&n)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return ErrPersonInEvent
+		}
+
+		return s.setPerson(ctx, p, personID)
+	})
+}
+
+// UnlinkFromPerson makes the player a new person of their own, for when they were matched by name
+// to someone else with the same name. It does nothing if they're already the only player of their
+// person.
+func (s Repository) UnlinkFromPerson(ctx context.Context, playerID string) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		p, err := s.Get(ctx, playerID)
+		if err != nil {
+			return err
+		}
+
+		others, err := s.GetForPerson(ctx, p.PersonID)
+		if err != nil {
+			return err
+		}
+		if len(others) <= 1 {
+			return nil
+		}
+
+		personID, err := s.createPerson(ctx, p.FirstName, p.LastName)
+		if err != nil {
+			return err
+		}
+		return s.setPerson(ctx, p, personID)
+	})
+}
+
+// setPerson links the player to the given person and forgets their old person if nothing refers to
+// them anymore.
+func (s Repository) setPerson(ctx context.Context, p Player, personID string) error {
+	err := s.db.ExecOne(ctx, `UPDATE Players SET PersonID = ? WHERE ID = ?`, personID, p.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.forgetPerson(ctx, p.PersonID)
+}
+
+// forgetPerson deletes the given person if no player or finalist refers to them anymore.
+func (s Repository) forgetPerson(ctx context.Context, personID string) error {
+	return s.db.ExecVoid(
+		ctx,
+		`DELETE FROM People WHERE ID = ?
+			AND NOT EXISTS (SELECT 1 FROM Players WHERE PersonID = People.ID)
+			AND NOT EXISTS (SELECT 1 FROM FinalistPlayers WHERE PersonID = People.ID)`,
+		personID,
+	)
+}
+
 // GetFreeAgents returns all players who are not assigned to a team.
 func (s Repository) GetFreeAgents(ctx context.Context) ([]Player, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
 	return scanPlayers(
 		s.selectPlayers().
-			Where(filter.IsNull("TeamID")).
+			WhereAll(
+				filter.Equals("EventID", eventID),
+				filter.IsNull("TeamID"),
+			).
 			QueryContext(ctx, s.db),
 	)
 }
@@ -179,9 +385,19 @@ func (s Repository) Create(ctx context.Context, firstName, lastName string) (str
 		return "", errors.New("must have a first and last name")
 	}
 
-	_, err := s.b.InsertIntoTable("Players").
-		Fields("ID", "FirstName", "LastName").
-		Values(id, firstName, lastName).
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return "", err
+	}
+
+	personID, err := s.personFor(ctx, eventID, firstName, lastName)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.b.InsertIntoTable("Players").
+		Fields("ID", "FirstName", "LastName", "EventID", "PersonID").
+		Values(id, firstName, lastName, eventID, personID).
 		ExecContext(ctx, s.db)
 	if err != nil {
 		return "", err
@@ -190,42 +406,197 @@ func (s Repository) Create(ctx context.Context, firstName, lastName string) (str
 	return id, nil
 }
 
-// UpdateName sets the player's first and last name. Both must be non-empty.
+// UpdateName sets the player's first and last name. Both must be non-empty. The player stays the
+// same person; if they're that person's only player, the person's name is fixed too. Use
+// LinkToPerson to make them someone else.
 func (s Repository) UpdateName(ctx context.Context, id, firstName, lastName string) error {
 	if firstName == "" || lastName == "" {
 		return errors.New("must have a first and last name")
 	}
 
-	res, err := s.b.UpdateTable("Players").
-		SetFieldTo("FirstName", firstName).
-		SetFieldTo("LastName", lastName).
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		p, err := s.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.b.UpdateTable("Players").
+			SetFieldTo("FirstName", firstName).
+			SetFieldTo("LastName", lastName).
+			Where(filter.Equals("ID", id)).
+			ExecContext(ctx, s.db)
+		if err != nil {
+			return err
+		}
+
+		return s.db.ExecVoid(
+			ctx,
+			`UPDATE People SET FirstName = ?, LastName = ?, NameKey = ?
+			WHERE ID = ? AND NOT EXISTS (SELECT 1 FROM Players WHERE PersonID = People.ID AND ID <> ?)`,
+			strings.TrimSpace(firstName), strings.TrimSpace(lastName), nameKey(firstName, lastName),
+			p.PersonID, id,
+		)
+	})
+}
+
+// Delete deletes the player along with their partner requests and conflicts, and forgets their
+// person if nobody else refers to them.
+func (s Repository) Delete(ctx context.Context, id string) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		p, err := s.Get(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = s.b.DeleteFromTable("Players").
+			Where(filter.Equals("ID", id)).
+			ExecContext(ctx, s.db)
+		if err != nil {
+			return err
+		}
+
+		err = s.db.ExecVoid(ctx, `DELETE FROM PartnerRequests WHERE PlayerID = ? OR PartnerID = ?`, id, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.db.ExecVoid(ctx, `DELETE FROM PlayerConflicts WHERE PlayerID1 = ? OR PlayerID2 = ?`, id, id)
+		if err != nil {
+			return err
+		}
+
+		return s.forgetPerson(ctx, p.PersonID)
+	})
+}
+
+// SetRating sets how strong the player is.
+func (s Repository) SetRating(ctx context.Context, id string, rating int) error {
+	_, err := s.b.UpdateTable("Players").
+		SetFieldTo("Rating", rating).
 		Where(filter.Equals("ID", id)).
 		ExecContext(ctx, s.db)
+	return err
+}
+
+// RequestPartner records who the player asked to be on a team with, replacing any earlier request.
+// An empty partnerID withdraws the request.
+func (s Repository) RequestPartner(ctx context.Context, playerID, partnerID string) error {
+	if playerID == partnerID {
+		return ErrSamePlayer
+	}
+
+	if partnerID == "" {
+		return s.db.ExecVoid(ctx, `DELETE FROM PartnerRequests WHERE PlayerID = ?`, playerID)
+	}
+
+	eventID, err := events.CurrentID(ctx, s.db)
 	if err != nil {
 		return err
 	}
 
-	n, err := res.RowsAffected()
+	return s.db.ExecVoid(
+		ctx,
+		`INSERT INTO PartnerRequests (EventID, PlayerID, PartnerID) VALUES (?, ?, ?)
+		ON CONFLICT (PlayerID) DO UPDATE SET PartnerID = excluded.PartnerID`,
+		eventID, playerID, partnerID,
+	)
+}
+
+// GetPartnerRequests returns the partner each player in the current event asked for, by player ID.
+func (s Repository) GetPartnerRequests(ctx context.Context) (map[string]string, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if n == 0 {
-		return sql.ErrNoRows
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT PlayerID, PartnerID FROM PartnerRequests WHERE EventID = ?`,
+		eventID,
+	)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return nil
+	requests := make(map[string]string)
+	for rows.Next() {
+		var playerID, partnerID string
+		err := rows.Scan(&playerID, &partnerID)
+		if err != nil {
+			return nil, err
+		}
+		requests[playerID] = partnerID
+	}
+
+	return requests, rows.Err()
 }
 
-func (s Repository) Delete(ctx context.Context, id string) error {
-	_, err := s.b.DeleteFromTable("Players").
-		Where(filter.Equals("ID", id)).
-		ExecContext(ctx, s.db)
-	return err
+// AddConflict records that two players must not be put on the same team.
+func (s Repository) AddConflict(ctx context.Context, playerID1, playerID2 string) error {
+	if playerID1 == playerID2 {
+		return ErrSamePlayer
+	}
+
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	playerID1, playerID2 = min(playerID1, playerID2), max(playerID1, playerID2)
+	return s.db.ExecVoid(
+		ctx,
+		`INSERT INTO PlayerConflicts (EventID, PlayerID1, PlayerID2) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`,
+		eventID, playerID1, playerID2,
+	)
+}
+
+// RemoveConflict removes a conflict recorded by AddConflict.
+func (s Repository) RemoveConflict(ctx context.Context, playerID1, playerID2 string) error {
+	playerID1, playerID2 = min(playerID1, playerID2), max(playerID1, playerID2)
+	return s.db.ExecVoid(
+		ctx,
+		`DELETE FROM PlayerConflicts WHERE PlayerID1 = ? AND PlayerID2 = ?`,
+		playerID1, playerID2,
+	)
+}
+
+// GetConflicts returns the current event's pairs of players that must not share a team.
+func (s Repository) GetConflicts(ctx context.Context) ([][2]string, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT PlayerID1, PlayerID2 FROM PlayerConflicts WHERE EventID = ? ORDER BY PlayerID1, PlayerID2`,
+		eventID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs [][2]string
+	for rows.Next() {
+		var p [2]string
+		err := rows.Scan(&p[0], &p[1])
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, p)
+	}
+
+	return pairs, rows.Err()
 }
 
 func (s Repository) selectPlayers() *sel.Builder {
 	return s.b.SelectFrom(table.Named("Players")).
-		Columns("ID", "FirstName", "LastName", "TeamID")
+		Columns("ID", "EventID", "FirstName", "LastName", "TeamID", "PersonID", "Rating")
 }
 
 func scanPlayers(rows *sql.Rows, err error) ([]Player, error) {
@@ -253,13 +624,14 @@ func scanPlayers(rows *sql.Rows, err error) ([]Player, error) {
 
 func scanPlayer(scanner interface{ Scan(...any) error }) (Player, error) {
 	var p Player
-	var teamID sql.Null[string]
-	err := scanner.Scan(&p.ID, &p.FirstName, &p.LastName, &teamID)
+	var teamID, personID sql.Null[string]
+	err := scanner.Scan(&p.ID, &p.EventID, &p.FirstName, &p.LastName, &teamID, &personID, &p.Rating)
 	if err != nil {
 		return Player{}, err
 	}
 
-	// If the team ID was null, return empty string.
+	// If the team or person ID was null, return empty string.
 	p.TeamID = teamID.V
+	p.PersonID = personID.V
 	return p, nil
 }
@@ -3,7 +3,9 @@ package players
 import (
 	"cmp"
 	"database/sql"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,14 +13,13 @@ import (
 
 	"github.com/cszczepaniak/cribbly/internal/moreiter"
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 )
 
 func TestPlayerRepo(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
 
-	assert.NoError(t, s.Init(t.Context()))
-
 	id1, err := s.Create(t.Context(), "Mario", "Mario")
 	assert.NoError(t, err)
 	id2, err := s.Create(t.Context(), "Luigi", "Mario")
@@ -33,18 +34,21 @@ func TestPlayerRepo(t *testing.T) {
 		t,
 		[]Player{{
 			ID:        id1,
+			EventID:   events.LegacyID,
 			FirstName: "Mario",
 			LastName:  "Mario",
 		}, {
 			ID:        id2,
+			EventID:   events.LegacyID,
 			FirstName: "Luigi",
 			LastName:  "Mario",
 		}, {
 			ID:        id3,
+			EventID:   events.LegacyID,
 			FirstName: "Waluigi",
 			LastName:  "Wario",
 		}},
-		players,
+		slices.Collect(moreiter.Map(slices.Values(players), withoutPersonID)),
 		func(x, y Player) int { return cmp.Compare(x.ID, y.ID) },
 	)
 }
@@ -52,7 +56,6 @@ func TestPlayerRepo(t *testing.T) {
 func TestUpdateName(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
-	assert.NoError(t, s.Init(t.Context()))
 
 	id, err := s.Create(t.Context(), "A", "B")
 	assert.NoError(t, err)
@@ -60,7 +63,7 @@ func TestUpdateName(t *testing.T) {
 
 	p, err := s.Get(t.Context(), id)
 	assert.NoError(t, err)
-	assert.Equal(t, Player{ID: id, FirstName: "C", LastName: "D"}, p)
+	assert.Equal(t, Player{ID: id, EventID: events.LegacyID, FirstName: "C", LastName: "D"}, withoutPersonID(p))
 
 	assert.ErrorIs(t, s.UpdateName(t.Context(), uuid.NewString(), "X", "Y"), sql.ErrNoRows)
 	assert.Error(t, s.UpdateName(t.Context(), id, "", "Y"))
@@ -71,8 +74,6 @@ func TestAssigningPlayers(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
 
-	assert.NoError(t, s.Init(t.Context()))
-
 	id1, err := s.Create(t.Context(), "Mario", "Mario")
 	assert.NoError(t, err)
 	id2, err := s.Create(t.Context(), "Luigi", "Mario")
@@ -85,14 +86,16 @@ func TestAssigningPlayers(t *testing.T) {
 		t,
 		[]Player{{
 			ID:        id1,
+			EventID:   events.LegacyID,
 			FirstName: "Mario",
 			LastName:  "Mario",
 		}, {
 			ID:        id2,
+			EventID:   events.LegacyID,
 			FirstName: "Luigi",
 			LastName:  "Mario",
 		}},
-		players,
+		slices.Collect(moreiter.Map(slices.Values(players), withoutPersonID)),
 		func(x, y Player) int { return cmp.Compare(x.ID, y.ID) },
 	)
 
@@ -109,10 +112,11 @@ func TestAssigningPlayers(t *testing.T) {
 	assert.Equal(t,
 		Player{
 			ID:        id2,
+			EventID:   events.LegacyID,
 			FirstName: "Luigi",
 			LastName:  "Mario",
 		},
-		players[0],
+		withoutPersonID(players[0]),
 	)
 
 	// We should also see mario on the team
@@ -122,11 +126,12 @@ func TestAssigningPlayers(t *testing.T) {
 	assert.Equal(t,
 		Player{
 			ID:        id1,
+			EventID:   events.LegacyID,
 			FirstName: "Mario",
 			LastName:  "Mario",
 			TeamID:    teamID,
 		},
-		players[0],
+		withoutPersonID(players[0]),
 	)
 
 	// Assigning mario again is an error!
@@ -149,14 +154,206 @@ func TestAssigningPlayers(t *testing.T) {
 		t,
 		[]Player{{
 			ID:        id1,
+			EventID:   events.LegacyID,
 			FirstName: "Mario",
 			LastName:  "Mario",
 		}, {
 			ID:        id2,
+			EventID:   events.LegacyID,
 			FirstName: "Luigi",
 			LastName:  "Mario",
 		}},
-		players,
+		slices.Collect(moreiter.Map(slices.Values(players), withoutPersonID)),
 		func(x, y Player) int { return cmp.Compare(x.ID, y.ID) },
 	)
 }
+
+func TestPartnerRequests(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db)
+
+	alice, err := s.Create(t.Context(), "Alice", "Smith")
+	assert.NoError(t, err)
+	bob, err := s.Create(t.Context(), "Bob", "Smith")
+	assert.NoError(t, err)
+	carol, err := s.Create(t.Context(), "Carol", "Smith")
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, s.RequestPartner(t.Context(), alice, alice), ErrSamePlayer)
+
+	assert.NoError(t, s.RequestPartner(t.Context(), alice, bob))
+	assert.NoError(t, s.RequestPartner(t.Context(), bob, carol))
+	// A new request replaces the old one.
+	assert.NoError(t, s.RequestPartner(t.Context(), bob, alice))
+
+	requests, err := s.GetPartnerRequests(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{alice: bob, bob: alice}, requests)
+
+	assert.NoError(t, s.RequestPartner(t.Context(), bob, ""))
+
+	requests, err = s.GetPartnerRequests(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{alice: bob}, requests)
+
+	// Deleting a player drops requests for them.
+	assert.NoError(t, s.Delete(t.Context(), bob))
+
+	requests, err = s.GetPartnerRequests(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{}, requests)
+}
+
+func TestConflicts(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db)
+
+	assert.ErrorIs(t, s.AddConflict(t.Context(), "a", "a"), ErrSamePlayer)
+
+	assert.NoError(t, s.AddConflict(t.Context(), "b", "a"))
+	assert.NoError(t, s.AddConflict(t.Context(), "a", "b"))
+	assert.NoError(t, s.AddConflict(t.Context(), "c", "a"))
+
+	conflicts, err := s.GetConflicts(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{{"a", "b"}, {"a", "c"}}, conflicts)
+
+	assert.NoError(t, s.RemoveConflict(t.Context(), "b", "a"))
+
+	conflicts, err = s.GetConflicts(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{{"a", "c"}}, conflicts)
+}
+
+func TestSetRating(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db)
+
+	id, err := s.Create(t.Context(), "Alice", "Smith")
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.SetRating(t.Context(), id, 1600))
+
+	p, err := s.Get(t.Context(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, 1600, p.Rating)
+}
+
+func TestPeople(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db)
+	er := events.NewRepository(db)
+
+	next, err := er.Create(t.Context(), "Next Year", time.Now().AddDate(1, 0, 0))
+	assert.NoError(t, err)
+	nextCtx := events.WithEvent(t.Context(), next)
+
+	id1, err := s.Create(t.Context(), "Alice", "Smith")
+	assert.NoError(t, err)
+	id2, err := s.Create(nextCtx, " alice", "SMITH ")
+	assert.NoError(t, err)
+	id3, err := s.Create(nextCtx, "Alice", "Jones")
+	assert.NoError(t, err)
+	// A second Alice Smith in the same event can't be the first one.
+	id4, err := s.Create(nextCtx, "Alice", "Smith")
+	assert.NoError(t, err)
+
+	get := func(id string) Player {
+		p, err := s.Get(t.Context(), id)
+		assert.NoError(t, err)
+		return p
+	}
+	p1, p2, p3, p4 := get(id1), get(id2), get(id3), get(id4)
+
+	// Players with the same name in different events are the same person by default.
+	assert.Equal(t, true, p1.PersonID != "")
+	assert.Equal(t, p1.PersonID, p2.PersonID)
+	assert.Equal(t, true, p1.PersonID != p3.PersonID)
+	assert.Equal(t, true, p1.PersonID != p4.PersonID)
+
+	person, err := s.GetPerson(t.Context(), p1.PersonID)
+	assert.NoError(t, err)
+	assert.Equal(t, Person{ID: p1.PersonID, FirstName: "Alice", LastName: "Smith"}, person)
+
+	ps, err := s.GetForPerson(t.Context(), p1.PersonID)
+	assert.NoError(t, err)
+	assert.SliceElemsMatchFunc(
+		t,
+		[]string{id1, id2},
+		slices.Collect(moreiter.Map(slices.Values(ps), func(p Player) string { return p.ID })),
+		cmp.Compare[string],
+	)
+
+	named, err := s.GetPeopleNamed(t.Context(), "ALICE", " smith")
+	assert.NoError(t, err)
+	assert.SliceElemsMatchFunc(t, []string{p1.PersonID, p4.PersonID}, personIDs(named), cmp.Compare[string])
+
+	// Renaming a player keeps them the same person. A person with no other players is renamed too.
+	assert.NoError(t, s.UpdateName(t.Context(), id3, "Alicia", "Jones"))
+	assert.Equal(t, p3.PersonID, get(id3).PersonID)
+	person, err = s.GetPerson(t.Context(), p3.PersonID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alicia", person.FirstName)
+
+	assert.NoError(t, s.UpdateName(t.Context(), id2, "Alicia", "Smith"))
+	assert.Equal(t, p1.PersonID, get(id2).PersonID)
+	person, err = s.GetPerson(t.Context(), p1.PersonID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", person.FirstName)
+
+	// An admin can split a player off from the person they were matched to, and link them back.
+	assert.NoError(t, s.UnlinkFromPerson(t.Context(), id2))
+	p2 = get(id2)
+	assert.Equal(t, true, p2.PersonID != p1.PersonID)
+	person, err = s.GetPerson(t.Context(), p2.PersonID)
+	assert.NoError(t, err)
+	assert.Equal(t, Person{ID: p2.PersonID, FirstName: "Alicia", LastName: "Smith"}, person)
+
+	// Unlinking a person's only player changes nothing.
+	assert.NoError(t, s.UnlinkFromPerson(t.Context(), id2))
+	assert.Equal(t, p2.PersonID, get(id2).PersonID)
+
+	assert.NoError(t, s.LinkToPerson(t.Context(), id2, p1.PersonID))
+	assert.Equal(t, p1.PersonID, get(id2).PersonID)
+	// Nobody is left who was only id2.
+	_, err = s.GetPerson(t.Context(), p2.PersonID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// A person can't have two players in one event.
+	assert.ErrorIs(t, s.LinkToPerson(t.Context(), id4, p1.PersonID), ErrPersonInEvent)
+	assert.ErrorIs(t, s.LinkToPerson(t.Context(), id4, "nobody"), sql.ErrNoRows)
+
+	all, err := s.GetPeople(t.Context())
+	assert.NoError(t, err)
+	assert.SliceElemsMatchFunc(
+		t,
+		[]string{p1.PersonID, p3.PersonID, p4.PersonID},
+		personIDs(all),
+		cmp.Compare[string],
+	)
+
+	// Deleting a player forgets their person once nobody else is them.
+	assert.NoError(t, s.Delete(t.Context(), id3))
+	_, err = s.GetPerson(t.Context(), p3.PersonID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	assert.NoError(t, s.Delete(t.Context(), id2))
+	_, err = s.GetPerson(t.Context(), p1.PersonID)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Delete(t.Context(), "nobody"))
+
+	_, err = s.GetPerson(t.Context(), "nobody")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func personIDs(ps []Person) []string {
+	return slices.Collect(moreiter.Map(slices.Values(ps), func(p Person) string { return p.ID }))
+}
+
+// withoutPersonID clears the person ID that Create fills in on its own, so players can be compared
+// by what the test set. TestPeople covers people.
+func withoutPersonID(p Player) Player {
+	p.PersonID = ""
+	return p
+}
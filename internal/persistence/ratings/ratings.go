@@ -0,0 +1,113 @@
+package ratings
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+// Rating is a player's rating after the games of the player's event.
+type Rating struct {
+	EventID  string
+	PlayerID string
+	Rating   int
+	// Games is the number of rated games the player had played by the end of the event, in this
+	// event and every one before it.
+	Games int
+}
+
+type Repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+// ReplaceAll replaces every stored rating, in every event, with rs.
+func (r Repository) ReplaceAll(ctx context.Context, rs []Rating) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		err := r.db.ExecVoid(ctx, `DELETE FROM PlayerRatings`)
+		if err != nil {
+			return err
+		}
+
+		for _, rating := range rs {
+			err := r.db.ExecVoid(
+				ctx,
+				`INSERT INTO PlayerRatings (EventID, PlayerID, Rating, Games) VALUES (?, ?, ?, ?)`,
+				rating.EventID, rating.PlayerID, rating.Rating, rating.Games,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetForEvent returns the ratings of the current event's players, by player ID.
+func (r Repository) GetForEvent(ctx context.Context) (map[string]Rating, error) {
+	eventID, err := events.CurrentID(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := scanRatings(r.db.QueryContext(
+		ctx,
+		`SELECT EventID, PlayerID, Rating, Games FROM PlayerRatings WHERE EventID = ?`,
+		eventID,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	byPlayer := make(map[string]Rating, len(rs))
+	for _, rating := range rs {
+		byPlayer[rating.PlayerID] = rating
+	}
+	return byPlayer, nil
+}
+
+// GetForPlayers returns the ratings of the given players, whatever their events.
+func (r Repository) GetForPlayers(ctx context.Context, playerIDs ...string) ([]Rating, error) {
+	if len(playerIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]any, 0, len(playerIDs))
+	for _, id := range playerIDs {
+		args = append(args, id)
+	}
+
+	return scanRatings(r.db.QueryContext(
+		ctx,
+		`SELECT EventID, PlayerID, Rating, Games FROM PlayerRatings
+		WHERE PlayerID IN (?`+strings.Repeat(", ?", len(playerIDs)-1)+`)`,
+		args...,
+	))
+}
+
+func scanRatings(rows *sql.Rows, err error) ([]Rating, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rs []Rating
+	for rows.Next() {
+		var rating Rating
+		err := rows.Scan(&rating.EventID, &rating.PlayerID, &rating.Rating, &rating.Games)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, rating)
+	}
+
+	return rs, rows.Err()
+}
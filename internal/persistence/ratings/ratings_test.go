@@ -0,0 +1,47 @@
+package ratings
+
+import (
+	"cmp"
+	"testing"
+	"time"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+func TestRatingsRepo(t *testing.T) {
+	db := database.NewInMemory(t)
+	r := NewRepository(db)
+
+	other, err := events.NewRepository(db).Create(t.Context(), "other", time.Now())
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.ReplaceAll(t.Context(), []Rating{
+		{EventID: events.LegacyID, PlayerID: "a", Rating: 1516, Games: 1},
+		{EventID: other.ID, PlayerID: "b", Rating: 1484, Games: 2},
+	}))
+
+	byPlayer, err := r.GetForEvent(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Rating{
+		"a": {EventID: events.LegacyID, PlayerID: "a", Rating: 1516, Games: 1},
+	}, byPlayer)
+
+	rs, err := r.GetForPlayers(t.Context(), "a", "b", "c")
+	assert.NoError(t, err)
+	assert.SliceElemsMatchFunc(t, []Rating{
+		{EventID: events.LegacyID, PlayerID: "a", Rating: 1516, Games: 1},
+		{EventID: other.ID, PlayerID: "b", Rating: 1484, Games: 2},
+	}, rs, func(x, y Rating) int { return cmp.Compare(x.PlayerID, y.PlayerID) })
+
+	// Replacing drops the old ratings.
+	assert.NoError(t, r.ReplaceAll(t.Context(), []Rating{
+		{EventID: events.LegacyID, PlayerID: "c", Rating: 1500, Games: 0},
+	}))
+
+	rs, err = r.GetForPlayers(t.Context(), "a", "b")
+	assert.NoError(t, err)
+	assert.SliceLen(t, rs, 0)
+}
@@ -25,16 +25,6 @@ func NewRepository(db database.Database) Repository {
 	}
 }
 
-func (r Repository) Init(ctx context.Context) error {
-	_, err := r.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS RoomCodes (
-			Code TEXT,
-			Expires DATETIME,
-
-			PRIMARY KEY (Code)
-		)`)
-	return err
-}
-
 type RoomCode struct {
 	Code    string
 	Expires time.Time
@@ -14,7 +14,6 @@ func newTestRepo(t *testing.T) Repository {
 
 	db := database.NewInMemory(t)
 	repo := NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 
 	return repo
 }
@@ -0,0 +1,122 @@
+package tables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder"
+	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/filter"
+	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/formatter"
+	"github.com/google/uuid"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+var ErrTableNameMissing = errors.New("table must have a name")
+
+// Table is a physical table at the venue that games are played at.
+type Table struct {
+	ID string
+	// Number orders the tables, starting at 1.
+	Number int
+	Name   string
+	// Cluster groups tables that are near each other. Divisions pinned to a cluster only play at
+	// its tables. Empty if the table isn't in a cluster.
+	Cluster string
+}
+
+type Repository struct {
+	db database.Database
+	b  *sqlbuilder.Builder
+}
+
+func NewRepository(db database.Database) Repository {
+	return Repository{
+		db: db,
+		b:  sqlbuilder.New(formatter.Sqlite{}),
+	}
+}
+
+// SetCount adds or removes tables so the current event has n of them. New tables are named after
+// their number; the highest-numbered tables are the ones removed.
+func (s Repository) SetCount(ctx context.Context, n int) error {
+	if n < 0 {
+		return fmt.Errorf("number of tables must not be negative, got %d", n)
+	}
+
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		err := s.db.ExecVoid(ctx, `DELETE FROM Tables WHERE EventID = ? AND Number > ?`, eventID, n)
+		if err != nil {
+			return err
+		}
+
+		row := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(Number), 0) FROM Tables WHERE EventID = ?`, eventID)
+		var have int
+		err = row.Scan(&have)
+		if err != nil {
+			return err
+		}
+
+		for number := have + 1; number <= n; number++ {
+			_, err := s.b.InsertIntoTable("Tables").
+				Fields("ID", "EventID", "Number", "Name").
+				Values(uuid.NewString(), eventID, number, fmt.Sprintf("Table %d", number)).
+				ExecContext(ctx, s.db)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Update renames a table and moves it into a cluster ("" for none).
+func (s Repository) Update(ctx context.Context, id, name, cluster string) error {
+	if name == "" {
+		return ErrTableNameMissing
+	}
+
+	_, err := s.b.UpdateTable("Tables").
+		SetFieldTo("Name", name).
+		SetFieldTo("Cluster", cluster).
+		Where(filter.Equals("ID", id)).
+		ExecContext(ctx, s.db)
+	return err
+}
+
+// GetAll returns the current event's tables in order of their number.
+func (s Repository) GetAll(ctx context.Context) ([]Table, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT ID, Number, Name, Cluster FROM Tables WHERE EventID = ? ORDER BY Number`,
+		eventID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []Table
+	for rows.Next() {
+		var t Table
+		err := rows.Scan(&t.ID, &t.Number, &t.Name, &t.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, t)
+	}
+
+	return tables, nil
+}
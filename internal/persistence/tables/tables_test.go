@@ -0,0 +1,66 @@
+package tables
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+)
+
+func TestSetCount(t *testing.T) {
+	db := database.NewInMemory(t)
+	r := NewRepository(db)
+
+	err := r.SetCount(t.Context(), 3)
+	assert.NoError(t, err)
+
+	ts, err := r.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, ts, 3)
+	for i, tbl := range ts {
+		assert.Equal(t, i+1, tbl.Number)
+	}
+	assert.Equal(t, "Table 1", ts[0].Name)
+	assert.Equal(t, "Table 3", ts[2].Name)
+
+	// Existing tables keep their names when more are added.
+	err = r.Update(t.Context(), ts[1].ID, "By the window", "Upstairs")
+	assert.NoError(t, err)
+
+	err = r.SetCount(t.Context(), 5)
+	assert.NoError(t, err)
+
+	ts, err = r.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, ts, 5)
+	assert.Equal(t, "By the window", ts[1].Name)
+	assert.Equal(t, "Upstairs", ts[1].Cluster)
+	assert.Equal(t, "Table 5", ts[4].Name)
+
+	// Shrinking removes the highest-numbered tables.
+	err = r.SetCount(t.Context(), 2)
+	assert.NoError(t, err)
+
+	ts, err = r.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, ts, 2)
+	assert.Equal(t, "Table 1", ts[0].Name)
+	assert.Equal(t, "By the window", ts[1].Name)
+
+	assert.Error(t, r.SetCount(t.Context(), -1))
+}
+
+func TestUpdate_NameRequired(t *testing.T) {
+	db := database.NewInMemory(t)
+	r := NewRepository(db)
+
+	err := r.SetCount(t.Context(), 1)
+	assert.NoError(t, err)
+
+	ts, err := r.GetAll(t.Context())
+	assert.NoError(t, err)
+
+	err = r.Update(t.Context(), ts[0].ID, "", "")
+	assert.ErrorIs(t, err, ErrTableNameMissing)
+}
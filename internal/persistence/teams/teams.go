@@ -7,7 +7,6 @@ import (
 	"fmt"
 
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder"
-	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/column"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/filter"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/formatter"
 	"github.com/cszczepaniak/go-sqlbuilder/sqlbuilder/sel"
@@ -15,16 +14,22 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 )
 
 var (
 	ErrTeamAlreadyInDivision = errors.New("team was already assigned to a division")
+	ErrKeepApartSameTeam     = errors.New("a team can't be kept apart from itself")
 )
 
 type Team struct {
 	ID         string
 	Name       string
 	DivisionID string
+	// Rating is how strong the team is expected to be; higher is stronger. Admins set it from
+	// whatever they have to go on, like player ratings or last year's finish. It's used to balance
+	// divisions.
+	Rating int
 }
 
 type Repository struct {
@@ -39,27 +44,20 @@ func NewRepository(db database.Database) Repository {
 	}
 }
 
-func (s Repository) Init(ctx context.Context) error {
-	_, err := s.b.CreateTable("Teams").
-		IfNotExists().
-		Columns(
-			column.VarChar("ID", 36).PrimaryKey(),
-			column.VarChar("Name", 255),
-			column.VarChar("DivisionID", 36),
-		).
-		ExecContext(ctx, s.db)
-	return err
-}
-
 func (s Repository) Create(ctx context.Context, name string) (Team, error) {
 	team := Team{
 		ID:   uuid.NewString(),
 		Name: name,
 	}
 
-	_, err := s.b.InsertIntoTable("Teams").
-		Fields("ID", "Name").
-		Values(team.ID, team.Name).
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return Team{}, err
+	}
+
+	_, err = s.b.InsertIntoTable("Teams").
+		Fields("ID", "Name", "EventID").
+		Values(team.ID, team.Name, eventID).
 		ExecContext(ctx, s.db)
 	if err != nil {
 		return Team{}, err
@@ -72,13 +70,27 @@ func (s Repository) Delete(ctx context.Context, id string) error {
 	_, err := s.b.DeleteFromTable("Teams").
 		Where(filter.Equals("ID", id)).
 		ExecContext(ctx, s.db)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecVoid(ctx, `DELETE FROM KeepApart WHERE TeamID1 = ? OR TeamID2 = ?`, id, id)
 }
 
 func (s Repository) DeleteAll(ctx context.Context) error {
-	_, err := s.b.DeleteFromTable("Teams").
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.b.DeleteFromTable("Teams").
+		Where(filter.Equals("EventID", eventID)).
 		ExecContext(ctx, s.db)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecVoid(ctx, `DELETE FROM KeepApart WHERE EventID = ?`, eventID)
 }
 
 func (s Repository) Rename(ctx context.Context, id, newName string) error {
@@ -89,9 +101,77 @@ func (s Repository) Rename(ctx context.Context, id, newName string) error {
 	return err
 }
 
+// SetRating sets how strong the team is expected to be.
+func (s Repository) SetRating(ctx context.Context, id string, rating int) error {
+	_, err := s.b.UpdateTable("Teams").
+		SetFieldTo("Rating", rating).
+		Where(filter.Equals("ID", id)).
+		ExecContext(ctx, s.db)
+	return err
+}
+
+// KeepApart records that two teams must not be put in the same division.
+func (s Repository) KeepApart(ctx context.Context, teamID1, teamID2 string) error {
+	if teamID1 == teamID2 {
+		return ErrKeepApartSameTeam
+	}
+
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	teamID1, teamID2 = min(teamID1, teamID2), max(teamID1, teamID2)
+	return s.db.ExecVoid(
+		ctx,
+		`INSERT INTO KeepApart (EventID, TeamID1, TeamID2) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`,
+		eventID, teamID1, teamID2,
+	)
+}
+
+// AllowTogether removes a KeepApart constraint.
+func (s Repository) AllowTogether(ctx context.Context, teamID1, teamID2 string) error {
+	teamID1, teamID2 = min(teamID1, teamID2), max(teamID1, teamID2)
+	return s.db.ExecVoid(
+		ctx,
+		`DELETE FROM KeepApart WHERE TeamID1 = ? AND TeamID2 = ?`,
+		teamID1, teamID2,
+	)
+}
+
+// GetKeepApart returns the current event's pairs of teams that must not share a division.
+func (s Repository) GetKeepApart(ctx context.Context) ([][2]string, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT TeamID1, TeamID2 FROM KeepApart WHERE EventID = ? ORDER BY TeamID1, TeamID2`,
+		eventID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs [][2]string
+	for rows.Next() {
+		var p [2]string
+		err := rows.Scan(&p[0], &p[1])
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, p)
+	}
+
+	return pairs, rows.Err()
+}
+
 func (s Repository) Get(ctx context.Context, id string) (Team, error) {
 	row, err := s.b.SelectFrom(table.Named("Teams")).
-		Columns("ID", "Name", "DivisionID").
+		Columns("ID", "Name", "DivisionID", "Rating").
 		Where(filter.Equals("ID", id)).
 		QueryRowContext(ctx, s.db)
 	if err != nil {
@@ -100,7 +180,7 @@ func (s Repository) Get(ctx context.Context, id string) (Team, error) {
 
 	var team Team
 	var divisionID sql.Null[string]
-	err = row.Scan(&team.ID, &team.Name, &divisionID)
+	err = row.Scan(&team.ID, &team.Name, &divisionID, &team.Rating)
 	if err != nil {
 		return Team{}, err
 	}
@@ -110,17 +190,31 @@ func (s Repository) Get(ctx context.Context, id string) (Team, error) {
 }
 
 func (s Repository) GetAll(ctx context.Context) ([]Team, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
 	return scanTeams(
 		s.selectTeams().
+			Where(filter.Equals("EventID", eventID)).
 			QueryContext(ctx, s.db),
 	)
 }
 
 // GetWithoutDivision returns all teams that are not assigned to a division.
 func (s Repository) GetWithoutDivision(ctx context.Context) ([]Team, error) {
+	eventID, err := events.CurrentID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
 	return scanTeams(
 		s.selectTeams().
-			Where(filter.IsNull("DivisionID")).
+			WhereAll(
+				filter.Equals("EventID", eventID),
+				filter.IsNull("DivisionID"),
+			).
 			QueryContext(ctx, s.db),
 	)
 }
@@ -166,7 +260,7 @@ func (s Repository) AssignToDivision(ctx context.Context, teamID, divisionID str
 
 func (s Repository) selectTeams() *sel.Builder {
 	return s.b.SelectFrom(table.Named("Teams")).
-		Columns("ID", "Name", "DivisionID")
+		Columns("ID", "Name", "DivisionID", "Rating")
 }
 
 func (s Repository) UnassignFromDivision(ctx context.Context, teams ...Team) error {
@@ -192,7 +286,7 @@ func scanTeams(rows *sql.Rows, err error) ([]Team, error) {
 	for rows.Next() {
 		var t Team
 		var divisionID sql.Null[string]
-		err := rows.Scan(&t.ID, &t.Name, &divisionID)
+		err := rows.Scan(&t.ID, &t.Name, &divisionID, &t.Rating)
 		if err != nil {
 			return nil, err
 		}
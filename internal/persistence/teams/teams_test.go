@@ -15,7 +15,6 @@ import (
 func TestTeamsRepo(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
-	assert.NoError(t, s.Init(t.Context()))
 
 	team1, err := s.Create(t.Context(), "team1")
 	assert.NoError(t, err)
@@ -61,7 +60,6 @@ func TestTeamsRepo(t *testing.T) {
 func TestTeamsRepo_Rename(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
-	assert.NoError(t, s.Init(t.Context()))
 
 	team, err := s.Create(t.Context(), "team")
 	assert.NoError(t, err)
@@ -77,7 +75,6 @@ func TestTeamsRepo_Rename(t *testing.T) {
 func TestTeamsRepo_AssignAndUnassign(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
-	assert.NoError(t, s.Init(t.Context()))
 
 	team1, err := s.Create(t.Context(), "team1")
 	assert.NoError(t, err)
@@ -123,3 +120,40 @@ func TestTeamsRepo_AssignAndUnassign(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, ts, []Team{team1})
 }
+
+func TestTeamsRepo_Rating(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db)
+
+	team, err := s.Create(t.Context(), "team")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, team.Rating)
+
+	assert.NoError(t, s.SetRating(t.Context(), team.ID, 1500))
+
+	gotTeam, err := s.Get(t.Context(), team.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, gotTeam.Rating)
+}
+
+func TestTeamsRepo_KeepApart(t *testing.T) {
+	db := database.NewInMemory(t)
+	s := NewRepository(db)
+
+	assert.ErrorIs(t, s.KeepApart(t.Context(), "a", "a"), ErrKeepApartSameTeam)
+
+	assert.NoError(t, s.KeepApart(t.Context(), "b", "a"))
+	// Adding the same pair again, in either order, is fine.
+	assert.NoError(t, s.KeepApart(t.Context(), "a", "b"))
+	assert.NoError(t, s.KeepApart(t.Context(), "a", "c"))
+
+	pairs, err := s.GetKeepApart(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{{"a", "b"}, {"a", "c"}}, pairs)
+
+	assert.NoError(t, s.AllowTogether(t.Context(), "b", "a"))
+
+	pairs, err = s.GetKeepApart(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{{"a", "c"}}, pairs)
+}
@@ -34,27 +34,6 @@ func NewRepository(db database.Database) Repository {
 	}
 }
 
-func (s Repository) Init(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS Users (
-			Username TEXT,
-			PasswordHash BLOB,
-			
-			PRIMARY KEY (Username)
-		)`)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS Sessions (
-			ID TEXT,
-			Username TEXT,
-			Expires DATETIME,
-			
-			PRIMARY KEY (ID)
-		)`)
-	return err
-}
-
 type User struct {
 	Name string
 }
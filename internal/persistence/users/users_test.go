@@ -12,7 +12,6 @@ import (
 func TestUsers(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
-	assert.NoError(t, s.Init(t.Context()))
 
 	err := s.CreateUser(t.Context(), "mario@mario.com", "secret")
 	assert.NoError(t, err)
@@ -46,7 +45,6 @@ func TestUsers(t *testing.T) {
 func TestSessions(t *testing.T) {
 	db := database.NewInMemory(t)
 	s := NewRepository(db)
-	assert.NoError(t, s.Init(t.Context()))
 
 	// User must exist
 	_, err := s.CreateSession(t.Context(), "who?", time.Hour)
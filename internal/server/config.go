@@ -1,28 +1,36 @@
 package server
 
 import (
-	"github.com/cszczepaniak/cribbly/internal/notifier"
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
 	"github.com/cszczepaniak/cribbly/internal/persistence/divisions"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 	"github.com/cszczepaniak/cribbly/internal/persistence/games"
 	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/ratings"
 	"github.com/cszczepaniak/cribbly/internal/persistence/roomcodes"
+	"github.com/cszczepaniak/cribbly/internal/persistence/tables"
 	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
 	"github.com/cszczepaniak/cribbly/internal/persistence/users"
 	divisionservice "github.com/cszczepaniak/cribbly/internal/service/divisions"
+	historyservice "github.com/cszczepaniak/cribbly/internal/service/history"
+	ratingservice "github.com/cszczepaniak/cribbly/internal/service/ratings"
 	teamservice "github.com/cszczepaniak/cribbly/internal/service/teams"
+	tournamentservice "github.com/cszczepaniak/cribbly/internal/service/tournament"
 )
 
 type Config struct {
 	Transactor          database.Transactor
+	EventRepo           events.Repository
 	PlayerRepo          players.Repository
 	TeamRepo            teams.Repository
 	DivisionRepo        divisions.Repository
 	GameRepo            games.Repository
 	UserRepo            users.Repository
 	RoomCodeRepo        roomcodes.Repository
-	ScoreUpdateNotifier *notifier.Notifier
-	TournamentNotifier  *notifier.Notifier
+	TableRepo           tables.Repository
+	RatingRepo          ratings.Repository
+	ScoreUpdateNotifier *games.ScoreNotifier
+	TournamentNotifier  *tournamentservice.Notifier
 	IsProd              bool
 	// DevAdminSecret enables X-Cribbly-Dev-Admin header bypass for admin checks (non-prod only).
 	DevAdminSecret string
@@ -35,3 +43,30 @@ func (cfg Config) TeamService() teamservice.Service {
 func (cfg Config) DivisionService() divisionservice.Service {
 	return divisionservice.New(cfg.Transactor, cfg.TeamRepo, cfg.DivisionRepo)
 }
+
+func (cfg Config) TournamentService() tournamentservice.Service {
+	return tournamentservice.New(cfg.Transactor, cfg.GameRepo, cfg.TeamRepo, cfg.TournamentNotifier)
+}
+
+func (cfg Config) RatingService() ratingservice.Service {
+	return ratingservice.New(
+		cfg.Transactor,
+		cfg.EventRepo,
+		cfg.PlayerRepo,
+		cfg.GameRepo,
+		cfg.RatingRepo,
+		cfg.TournamentService(),
+	)
+}
+
+func (cfg Config) HistoryService() historyservice.Service {
+	return historyservice.New(
+		cfg.EventRepo,
+		cfg.PlayerRepo,
+		cfg.TeamRepo,
+		cfg.DivisionRepo,
+		cfg.GameRepo,
+		cfg.RatingRepo,
+		cfg.TournamentService(),
+	)
+}
@@ -18,7 +18,6 @@ func newUserRepo(t *testing.T) users.Repository {
 
 	db := database.NewInMemory(t)
 	repo := users.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 
 	return repo
 }
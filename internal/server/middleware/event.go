@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+// EventMiddleware scopes the request to an event. GET requests may browse a past event by passing
+// its ID in the "event" query parameter; everything else (and any unknown event ID) is scoped to the
+// active event. Requests are left unscoped if there is no active event.
+func EventMiddleware(repo events.Repository) middleware {
+	return func(next handler) handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			ctx := r.Context()
+
+			if id := r.URL.Query().Get("event"); id != "" && r.Method == http.MethodGet {
+				e, err := repo.Get(ctx, id)
+				if err == nil {
+					return next(w, r.WithContext(events.WithEvent(ctx, e)))
+				}
+				if !errors.Is(err, events.ErrEventNotFound) {
+					return err
+				}
+			}
+
+			e, err := repo.GetActive(ctx)
+			if err != nil {
+				if errors.Is(err, events.ErrNoActiveEvent) {
+					return next(w, r)
+				}
+				return err
+			}
+
+			return next(w, r.WithContext(events.WithEvent(ctx, e)))
+		}
+	}
+}
+
+// IsViewingPastEvent reports whether the request is browsing an event other than the active one.
+func IsViewingPastEvent(ctx context.Context) bool {
+	e, ok := events.FromContext(ctx)
+	return ok && !e.Active
+}
+
+// CanEditEvent reports whether the request may show controls that modify the event being viewed.
+// Only admins can edit, and only the active event: mutations always apply to the active event, so
+// offering them while browsing a past event would change the wrong one.
+func CanEditEvent(ctx context.Context) bool {
+	return IsAdmin(ctx) && !IsViewingPastEvent(ctx)
+}
+
+// EventQuery returns the query string ("?event=...") needed to keep browsing the request's event
+// when following a link or opening a stream. It is empty for the active event.
+func EventQuery(ctx context.Context) string {
+	if !IsViewingPastEvent(ctx) {
+		return ""
+	}
+	e, _ := events.FromContext(ctx)
+	return "?" + url.Values{"event": {e.ID}}.Encode()
+}
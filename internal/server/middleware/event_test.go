@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+func runEventMiddleware(t *testing.T, repo events.Repository, method, target string) context.Context {
+	t.Helper()
+
+	var ctx context.Context
+	h := EventMiddleware(repo)(func(w http.ResponseWriter, r *http.Request) error {
+		ctx = r.Context()
+		return nil
+	})
+
+	req := httptest.NewRequest(method, target, nil)
+	assert.NoError(t, h(httptest.NewRecorder(), req))
+	return ctx
+}
+
+func TestEventMiddleware(t *testing.T) {
+	repo := events.NewRepository(database.NewInMemory(t))
+
+	past, err := repo.Create(t.Context(), "2024", time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	t.Run("defaults to the active event", func(t *testing.T) {
+		ctx := runEventMiddleware(t, repo, http.MethodGet, "/standings")
+
+		e, ok := events.FromContext(ctx)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, events.LegacyID, e.ID)
+		assert.Equal(t, IsViewingPastEvent(ctx), false)
+		assert.Equal(t, "", EventQuery(ctx))
+	})
+
+	t.Run("browses a past event", func(t *testing.T) {
+		ctx := runEventMiddleware(t, repo, http.MethodGet, "/standings?event="+past.ID)
+
+		e, ok := events.FromContext(ctx)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, past.ID, e.ID)
+		assert.Equal(t, IsViewingPastEvent(ctx), true)
+		assert.Equal(t, "?event="+past.ID, EventQuery(ctx))
+	})
+
+	t.Run("unknown events fall back to the active event", func(t *testing.T) {
+		ctx := runEventMiddleware(t, repo, http.MethodGet, "/standings?event=nope")
+
+		e, ok := events.FromContext(ctx)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, events.LegacyID, e.ID)
+	})
+
+	t.Run("mutations always target the active event", func(t *testing.T) {
+		ctx := runEventMiddleware(t, repo, http.MethodPut, "/games/abc?event="+past.ID)
+
+		e, ok := events.FromContext(ctx)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, events.LegacyID, e.ID)
+	})
+}
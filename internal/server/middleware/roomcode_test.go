@@ -17,7 +17,6 @@ func newRoomCodeRepo(t *testing.T) roomcodes.Repository {
 
 	db := database.NewInMemory(t)
 	repo := roomcodes.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 
 	return repo
 }
@@ -61,3 +61,12 @@ func handleWithError(fn func(w http.ResponseWriter, r *http.Request) error) http
 		slog.Info("http.done", "method", r.Method, "url", r.URL, "dur", time.Since(t0))
 	}
 }
+
+// httpHandler adapts h, which handles its own errors, to a route handler so that it runs behind the
+// router's middleware like any other route.
+func httpHandler(h http.Handler) handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		h.ServeHTTP(w, r)
+		return nil
+	}
+}
@@ -109,3 +109,36 @@ func TestRouterHandlerErrorResultsIn500(t *testing.T) {
 type assertError string
 
 func (e assertError) Error() string { return string(e) }
+
+func TestHTTPHandler_RunsMiddleware(t *testing.T) {
+	var calls []string
+	mw := func(next handler) handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			calls = append(calls, "middleware")
+			return next(w, r)
+		}
+	}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	mux := http.NewServeMux()
+	NewRouter(mux, mw).Handle("GET /stream", httpHandler(h))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	resp, err := srv.Client().Get(srv.URL + "/stream")
+	if err != nil {
+		t.Fatalf("unexpected error performing request: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTeapot {
+		t.Fatalf("expected status %d, got %d", http.StatusTeapot, resp.StatusCode)
+	}
+	if len(calls) != 2 || calls[0] != "middleware" || calls[1] != "handler" {
+		t.Fatalf("expected the middleware and then the handler to run, got %v", calls)
+	}
+}
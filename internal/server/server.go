@@ -6,19 +6,28 @@ import (
 
 	"github.com/cszczepaniak/cribbly/internal/api/playersconnect"
 	"github.com/cszczepaniak/cribbly/internal/api/roomcodeconnect"
+	"github.com/cszczepaniak/cribbly/internal/api/standingsconnect"
+	"github.com/cszczepaniak/cribbly/internal/api/tournamentconnect"
 	cribblyv1connect "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1/cribblyv1connect"
+	cribblyv1sse "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1/cribblyv1sse"
 	mw "github.com/cszczepaniak/cribbly/internal/server/middleware"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin"
+	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/disputes"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/divisions"
+	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/events"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/games"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/players"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/profile"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/roomcodes"
+	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/tables"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/teams"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/admin/users"
 	pubdiv "github.com/cszczepaniak/cribbly/internal/ui/pages/divisions"
+	pubevent "github.com/cszczepaniak/cribbly/internal/ui/pages/events"
 	pubgame "github.com/cszczepaniak/cribbly/internal/ui/pages/games"
+	pubhistory "github.com/cszczepaniak/cribbly/internal/ui/pages/history"
 	"github.com/cszczepaniak/cribbly/internal/ui/pages/index"
+	pubplayer "github.com/cszczepaniak/cribbly/internal/ui/pages/players"
 	pubteam "github.com/cszczepaniak/cribbly/internal/ui/pages/teams"
 	pubtournament "github.com/cszczepaniak/cribbly/internal/ui/pages/tournament"
 	webembed "github.com/cszczepaniak/cribbly/internal/web/embed"
@@ -58,6 +67,7 @@ func Setup(cfg Config) http.Handler {
 		mw.IsProdMiddleware(cfg.IsProd),
 		mw.DevToolsQueryMiddleware(),
 		mw.RoomCodeMiddleware(cfg.RoomCodeRepo),
+		mw.EventMiddleware(cfg.EventRepo),
 	)
 
 	home := index.Handler{
@@ -68,6 +78,11 @@ func Setup(cfg Config) http.Handler {
 
 	setupAdminRoutes(cfg, r)
 
+	eh := pubevent.Handler{
+		EventRepo: cfg.EventRepo,
+	}
+	r.Handle("GET /events", eh.Index)
+
 	dh := pubdiv.Handler{
 		DivisionRepo: cfg.DivisionRepo,
 		TeamRepo:     cfg.TeamRepo,
@@ -76,11 +91,24 @@ func Setup(cfg Config) http.Handler {
 	r.Handle("GET /divisions/{id}", dh.GetDivisions)
 
 	th := pubteam.Handler{
-		GameRepo: cfg.GameRepo,
-		TeamRepo: cfg.TeamRepo,
+		GameRepo:   cfg.GameRepo,
+		TeamRepo:   cfg.TeamRepo,
+		TableRepo:  cfg.TableRepo,
+		PlayerRepo: cfg.PlayerRepo,
 	}
 	r.Handle("GET /teams/{id}/games", th.GetGames)
 
+	plh := pubplayer.Handler{
+		PlayerRepo:     cfg.PlayerRepo,
+		HistoryService: cfg.HistoryService(),
+	}
+	r.Handle("GET /players/{id}", plh.Profile)
+
+	hh := pubhistory.Handler{
+		HistoryService: cfg.HistoryService(),
+	}
+	r.Handle("GET /history", hh.Index)
+
 	gh := pubgame.Handler{
 		GameRepo:            cfg.GameRepo,
 		TeamRepo:            cfg.TeamRepo,
@@ -88,22 +116,53 @@ func Setup(cfg Config) http.Handler {
 	}
 	r.Handle("GET /games/{id}", gh.GetGame)
 	r.Handle("PUT /games/{id}", gh.UpdateGame)
+	r.Handle("POST /games/{id}/confirm", gh.ConfirmGame)
 
 	r.Handle("GET /standings", gh.StandingsPage)
-	r.Handle("GET /standings/stream", gh.StreamStandings)
+	sConnect := &standingsconnect.Server{
+		GameRepo:            cfg.GameRepo,
+		ScoreUpdateNotifier: cfg.ScoreUpdateNotifier,
+	}
+	standingsStreamPath, standingsStream := cribblyv1sse.NewStandingsServiceWatchStandingsSSEHandler(standingsconnect.SSE{Server: sConnect})
+	r.Handle("GET "+standingsStreamPath, httpHandler(standingsStream))
+	r.Handle("GET /standings/patches", gh.StreamStandings)
 
 	tourneyHandler := pubtournament.Handler{
-		GameRepo:           cfg.GameRepo,
-		TeamRepo:           cfg.TeamRepo,
+		TournamentService:  cfg.TournamentService(),
 		TournamentNotifier: cfg.TournamentNotifier,
-		Transactor:         cfg.Transactor,
 	}
 	r.Handle("GET /tournament", tourneyHandler.Index)
-	r.Handle("GET /tournament/stream", tourneyHandler.Stream)
+	tConnect := &tournamentconnect.Server{
+		TournamentService:  cfg.TournamentService(),
+		TournamentNotifier: cfg.TournamentNotifier,
+	}
+	tournamentStreamPath, tournamentStream := cribblyv1sse.NewTournamentServiceWatchBracketSSEHandler(tournamentconnect.SSE{Server: tConnect})
+	r.Handle("GET "+tournamentStreamPath, httpHandler(tournamentStream))
+	r.Handle("GET /tournament/patches", tourneyHandler.Stream)
 	r.Handle("POST /tournament", tourneyHandler.Generate, mw.ErrorIfNotAdmin())
 	r.Handle("DELETE /tournament", tourneyHandler.Delete, mw.ErrorIfNotAdmin())
 	r.Handle("POST /tournament/team/{id}/advance", tourneyHandler.AdvanceTeam, mw.ErrorIfNotAdmin())
 	r.Handle("POST /tournament/team/{id}/revert", tourneyHandler.RevertAdvance, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/series/length", tourneyHandler.SetSeriesLength, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/series/team/{id}/win", tourneyHandler.RecordSeriesGame, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/series/undo", tourneyHandler.UndoSeriesGame, mw.ErrorIfNotAdmin())
+	r.Handle("GET /tournament/games/report", tourneyHandler.ReportPage, mw.RedirectToLoginIfNotAdmin())
+	r.Handle("POST /tournament/games/report", tourneyHandler.Report, mw.ErrorIfNotAdmin())
+	r.Handle("GET /tournament/team/{id}", tourneyHandler.TeamPage)
+	r.Handle("POST /tournament/team/{id}/report", tourneyHandler.ReportResult)
+
+	consolationHandler := tourneyHandler.Consolation()
+	r.Handle("GET /tournament/consolation/patches", consolationHandler.Stream)
+	r.Handle("POST /tournament/consolation", consolationHandler.Generate, mw.ErrorIfNotAdmin())
+	r.Handle("DELETE /tournament/consolation", consolationHandler.Delete, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/consolation/team/{id}/advance", consolationHandler.AdvanceTeam, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/consolation/team/{id}/revert", consolationHandler.RevertAdvance, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/consolation/series/length", consolationHandler.SetSeriesLength, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/consolation/series/team/{id}/win", consolationHandler.RecordSeriesGame, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/consolation/series/undo", consolationHandler.UndoSeriesGame, mw.ErrorIfNotAdmin())
+	r.Handle("GET /tournament/consolation/games/report", consolationHandler.ReportPage, mw.RedirectToLoginIfNotAdmin())
+	r.Handle("POST /tournament/consolation/games/report", consolationHandler.Report, mw.ErrorIfNotAdmin())
+	r.Handle("POST /tournament/consolation/team/{id}/report", consolationHandler.ReportResult)
 
 	rcConnect := &roomcodeconnect.Server{Repo: cfg.RoomCodeRepo, UserRepo: cfg.UserRepo}
 	connectMountPath, roomCodeConnectHandler := cribblyv1connect.NewRoomCodeServiceHandler(rcConnect)
@@ -114,10 +173,19 @@ func Setup(cfg Config) http.Handler {
 	roomCodeConnect := http.StripPrefix("/api", connectWithAdminContext(cfg, roomCodeConnectHandler))
 	mux.Handle("POST /api"+connectMountPath, roomCodeConnect)
 
-	plConnect := &playersconnect.Server{PlayerRepo: cfg.PlayerRepo}
+	plConnect := &playersconnect.Server{
+		PlayerRepo:     cfg.PlayerRepo,
+		HistoryService: cfg.HistoryService(),
+	}
 	playerMountPath, playerConnectHandler := cribblyv1connect.NewPlayerServiceHandler(plConnect)
 	mux.Handle("POST /api"+playerMountPath, http.StripPrefix("/api", connectWithAdminContext(cfg, playerConnectHandler)))
 
+	tournamentMountPath, tournamentConnectHandler := cribblyv1connect.NewTournamentServiceHandler(tConnect)
+	mux.Handle("POST /api"+tournamentMountPath, http.StripPrefix("/api", connectWithAdminContext(cfg, tournamentConnectHandler)))
+
+	standingsMountPath, standingsConnectHandler := cribblyv1connect.NewStandingsServiceHandler(sConnect)
+	mux.Handle("POST /api"+standingsMountPath, http.StripPrefix("/api", connectWithAdminContext(cfg, standingsConnectHandler)))
+
 	return mw.ReactQueryMiddleware(sync.OnceValue(webembed.MustReadIndexHTML), cfg.IsProd, mux)
 }
 
@@ -150,8 +218,24 @@ func setupAdminRoutes(cfg Config, r *router) {
 	adminRouter := r.Group("/admin", mw.RedirectToLoginIfNotAdmin())
 	adminRouter.Handle("GET /", admin.Index)
 
+	eh := events.Handler{
+		EventRepo:     cfg.EventRepo,
+		RatingService: cfg.RatingService(),
+	}
+	eventsRouter := adminRouter.Group("/events")
+	eventsRouter.Handle("GET /", eh.Index)
+	eventsRouter.Handle("POST /", eh.Create)
+	eventsRouter.Handle("POST /ratings", eh.RecomputeRatings)
+	eventsRouter.Handle("POST /{id}/activate", eh.Activate)
+	eventsRouter.Handle("POST /{id}/archive", eh.Archive)
+	eventsRouter.Handle("POST /{id}/points", eh.SetPoints)
+	eventsRouter.Handle("POST /{id}/tiebreakers", eh.SetTiebreakers)
+	eventsRouter.Handle("POST /{id}/confirm-scores", eh.SetConfirmScores)
+	eventsRouter.Handle("POST /{id}/swiss", eh.SetSwissRounds)
+
 	ph := players.PlayersHandler{
 		PlayerRepo: cfg.PlayerRepo,
+		EventRepo:  cfg.EventRepo,
 	}
 	playersRouter := adminRouter.Group("/players")
 	playersRouter.Handle("GET /", ph.RegistrationPage)
@@ -161,11 +245,15 @@ func setupAdminRoutes(cfg Config, r *router) {
 	playersRouter.Handle("DELETE /", ph.DeleteAllPlayers)
 	playersRouter.Handle("POST /excel", ph.UploadExcel)
 	playersRouter.Handle("POST /excel/import", ph.ImportExcel)
+	playersRouter.Handle("GET /{id}/person", ph.PersonPage)
+	playersRouter.Handle("POST /{id}/person", ph.LinkPerson)
+	playersRouter.Handle("POST /{id}/person/unlink", ph.UnlinkPerson)
 
 	th := teams.TeamsHandler{
-		PlayerRepo:  cfg.PlayerRepo,
-		TeamRepo:    cfg.TeamRepo,
-		TeamService: cfg.TeamService(),
+		PlayerRepo:    cfg.PlayerRepo,
+		TeamRepo:      cfg.TeamRepo,
+		TeamService:   cfg.TeamService(),
+		RatingService: cfg.RatingService(),
 	}
 	teamsRouter := adminRouter.Group("/teams")
 	teamsRouter.Handle("GET /", th.Index)
@@ -173,6 +261,13 @@ func setupAdminRoutes(cfg Config, r *router) {
 	teamsRouter.Handle("GET /delete/{id}", th.ConfirmDelete)
 	teamsRouter.Handle("POST /", th.Create)
 	teamsRouter.Handle("POST /generate", th.Generate)
+	teamsRouter.Handle("GET /formation", th.Formation)
+	teamsRouter.Handle("POST /formation", th.AcceptFormation)
+	teamsRouter.Handle("POST /formation/ratings", th.UseComputedRatings)
+	teamsRouter.Handle("POST /formation/ratings/{id}", th.SetRating)
+	teamsRouter.Handle("POST /formation/requests/{id}", th.RequestPartner)
+	teamsRouter.Handle("POST /formation/conflicts", th.AddConflict)
+	teamsRouter.Handle("POST /formation/conflicts/delete", th.RemoveConflict)
 	teamsRouter.Handle("PUT /{id}", th.Save)
 	teamsRouter.Handle("DELETE /{id}", th.Delete)
 	teamsRouter.Handle("DELETE /", th.DeleteAll)
@@ -196,21 +291,52 @@ func setupAdminRoutes(cfg Config, r *router) {
 	divisionsRouter.Handle("POST /generate", dh.Generate)
 	divisionsRouter.Handle("DELETE /", dh.DeleteAll)
 	divisionsRouter.Handle("GET /qrs", dh.GenerateQRCodes)
+	divisionsRouter.Handle("GET /balance", dh.Balance)
+	divisionsRouter.Handle("POST /balance", dh.CreateBalanced)
+	divisionsRouter.Handle("POST /balance/ratings/{id}", dh.SetRating)
+	divisionsRouter.Handle("POST /balance/apart", dh.KeepApart)
+	divisionsRouter.Handle("POST /balance/apart/delete", dh.AllowTogether)
 
 	gh := games.Handler{
 		Transactor:   cfg.Transactor,
 		DivisionRepo: cfg.DivisionRepo,
 		TeamRepo:     cfg.TeamRepo,
 		GameRepo:     cfg.GameRepo,
+		TableRepo:    cfg.TableRepo,
+		EventRepo:    cfg.EventRepo,
 	}
 	gamesRouter := adminRouter.Group("/games")
 	gamesRouter.Handle("GET /", gh.Index)
 	gamesRouter.Handle("POST /generate", gh.Generate)
+	gamesRouter.Handle("POST /crossover", gh.GenerateCrossover)
+	gamesRouter.Handle("POST /swiss", gh.GenerateSwissRound)
+	gamesRouter.Handle("POST /crossover/pairs/{id}", gh.PairDivisions)
 	gamesRouter.Handle("DELETE /", gh.DeleteAll)
 	gamesRouter.Handle("GET /scores/edit", gh.Edit)
 	gamesRouter.Handle("PUT /scores/save", gh.Save)
 	gamesRouter.Handle("PUT /scores/reset", gh.ResetScores)
 
+	tbh := tables.Handler{
+		TableRepo:    cfg.TableRepo,
+		DivisionRepo: cfg.DivisionRepo,
+		GameRepo:     cfg.GameRepo,
+		TeamRepo:     cfg.TeamRepo,
+	}
+	tablesRouter := adminRouter.Group("/tables")
+	tablesRouter.Handle("GET /", tbh.Index)
+	tablesRouter.Handle("GET /sheets", tbh.Sheets)
+	tablesRouter.Handle("POST /count", tbh.SetCount)
+	tablesRouter.Handle("POST /{id}", tbh.Update)
+	tablesRouter.Handle("POST /divisions/{id}", tbh.PinDivision)
+
+	dsh := disputes.Handler{
+		GameRepo: cfg.GameRepo,
+		TeamRepo: cfg.TeamRepo,
+	}
+	disputesRouter := adminRouter.Group("/disputes")
+	disputesRouter.Handle("GET /", dsh.Index)
+	disputesRouter.Handle("POST /{id}", dsh.Resolve)
+
 	rcHandler := roomcodes.Handler{
 		RoomCodeRepo: cfg.RoomCodeRepo,
 	}
@@ -3,61 +3,52 @@ package server
 import (
 	"context"
 
-	"github.com/cszczepaniak/cribbly/internal/notifier"
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
 	"github.com/cszczepaniak/cribbly/internal/persistence/divisions"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 	"github.com/cszczepaniak/cribbly/internal/persistence/games"
 	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/ratings"
 	"github.com/cszczepaniak/cribbly/internal/persistence/roomcodes"
+	"github.com/cszczepaniak/cribbly/internal/persistence/tables"
 	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
 	"github.com/cszczepaniak/cribbly/internal/persistence/users"
+	tournamentservice "github.com/cszczepaniak/cribbly/internal/service/tournament"
 )
 
-// SetupFromDB creates all repositories from the given database, initializes them,
+// SetupFromDB migrates the given database to the latest schema, creates all repositories from it,
 // and returns a server Config and HTTP handler. Callers can use the handler to
-// serve traffic and the config to access repos (e.g. for seeding or tests).
+// serve traffic and the config to access repos (e.g. for seeding or tests). It refuses to set up
+// against a database whose schema is newer than this binary knows about.
 func SetupFromDB(ctx context.Context, db database.Database, isProd bool) (Config, error) {
-	scoreUpdateNotifier := &notifier.Notifier{}
-	tournamentNotifier := &notifier.Notifier{}
-
-	playerRepo := players.NewRepository(db)
-	if err := playerRepo.Init(ctx); err != nil {
+	if err := db.Migrate(ctx); err != nil {
 		return Config{}, err
 	}
 
-	teamRepo := teams.NewRepository(db)
-	if err := teamRepo.Init(ctx); err != nil {
-		return Config{}, err
-	}
+	scoreUpdateNotifier := &games.ScoreNotifier{}
+	tournamentNotifier := &tournamentservice.Notifier{}
 
+	eventRepo := events.NewRepository(db)
+	playerRepo := players.NewRepository(db)
+	teamRepo := teams.NewRepository(db)
 	divisionRepo := divisions.NewRepository(db)
-	if err := divisionRepo.Init(ctx); err != nil {
-		return Config{}, err
-	}
-
 	gameRepo := games.NewRepository(db, scoreUpdateNotifier)
-	if err := gameRepo.Init(ctx); err != nil {
-		return Config{}, err
-	}
-
 	roomCodeRepo := roomcodes.NewRepository(db)
-	if err := roomCodeRepo.Init(ctx); err != nil {
-		return Config{}, err
-	}
-
 	userRepo := users.NewRepository(db)
-	if err := userRepo.Init(ctx); err != nil {
-		return Config{}, err
-	}
+	tableRepo := tables.NewRepository(db)
+	ratingRepo := ratings.NewRepository(db)
 
 	cfg := Config{
 		Transactor:          database.NewTransactor(db),
+		EventRepo:           eventRepo,
 		PlayerRepo:          playerRepo,
 		TeamRepo:            teamRepo,
 		DivisionRepo:        divisionRepo,
 		GameRepo:            gameRepo,
 		UserRepo:            userRepo,
 		RoomCodeRepo:        roomCodeRepo,
+		TableRepo:           tableRepo,
+		RatingRepo:          ratingRepo,
 		ScoreUpdateNotifier: scoreUpdateNotifier,
 		TournamentNotifier:  tournamentNotifier,
 		IsProd:              isProd,
@@ -0,0 +1,188 @@
+package divisions
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cszczepaniak/cribbly/internal/fake"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+var ErrCantKeepApart = errors.New("teams that must be kept apart can't all be put in different divisions")
+
+// PlannedDivision is a division that Plan would create.
+type PlannedDivision struct {
+	Name  string
+	Teams []teams.Team
+}
+
+// Sizes splits n teams into divisions of 4. The last division has 3, 5 or 6 teams instead if that
+// avoids leaving 1 or 2 teams over. Fewer than 3 teams all go in one division.
+func Sizes(n int) []int {
+	var sizes []int
+	for n > 0 {
+		size := 4
+		switch n {
+		case 1, 2, 3, 5, 6:
+			size = n
+		}
+		sizes = append(sizes, size)
+		n -= size
+	}
+	return sizes
+}
+
+// Balance snake-drafts the teams into divisions sized by Sizes: the strongest team goes to the
+// first division, the next to the second, and so on, then back the other way, skipping divisions
+// that are full.
+//
+// apart lists pairs of team IDs that must not share a division. A team that would land in a
+// division with a team it must be kept apart from takes the next pick in the draft that it can
+// take instead. If there's no such pick, Balance returns ErrCantKeepApart.
+func Balance(ts []teams.Team, apart [][2]string) ([][]teams.Team, error) {
+	ts = slices.Clone(ts)
+	slices.SortStableFunc(ts, func(a, b teams.Team) int {
+		return cmp.Or(cmp.Compare(b.Rating, a.Rating), cmp.Compare(a.Name, b.Name))
+	})
+
+	sizes := Sizes(len(ts))
+	picks := draftOrder(sizes)
+
+	keepApart := make(map[[2]string]bool, len(apart))
+	for _, p := range apart {
+		keepApart[[2]string{p[0], p[1]}] = true
+		keepApart[[2]string{p[1], p[0]}] = true
+	}
+
+	divs := make([][]teams.Team, len(sizes))
+	for _, t := range ts {
+		i := slices.IndexFunc(picks, func(div int) bool {
+			return !slices.ContainsFunc(divs[div], func(other teams.Team) bool {
+				return keepApart[[2]string{t.ID, other.ID}]
+			})
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s has nowhere to go", ErrCantKeepApart, t.Name)
+		}
+
+		div := picks[i]
+		picks = slices.Delete(picks, i, i+1)
+		divs[div] = append(divs[div], t)
+	}
+
+	return divs, nil
+}
+
+// draftOrder returns the division index of each pick in a snake draft into divisions of the given
+// sizes.
+func draftOrder(sizes []int) []int {
+	left := slices.Clone(sizes)
+	total := 0
+	for _, s := range sizes {
+		total += s
+	}
+
+	picks := make([]int, 0, total)
+	for forward := true; len(picks) < total; forward = !forward {
+		for j := range left {
+			div := j
+			if !forward {
+				div = len(left) - 1 - j
+			}
+			if left[div] > 0 {
+				picks = append(picks, div)
+				left[div]--
+			}
+		}
+	}
+	return picks
+}
+
+// Plan balances the teams that aren't in a division yet into new divisions, each with its own
+// name. Nothing is saved; pass the result to Create to do that.
+func (s Service) Plan(ctx context.Context) ([]PlannedDivision, error) {
+	ts, err := s.teamRepo.GetWithoutDivision(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apart, err := s.teamRepo.GetKeepApart(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balanced, err := Balance(ts, apart)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.divisionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool, len(existing)+len(balanced))
+	for _, d := range existing {
+		used[d.Name] = true
+	}
+
+	planned := make([]PlannedDivision, 0, len(balanced))
+	for i, divTeams := range balanced {
+		name := uniqueName(used, i)
+		used[name] = true
+		planned = append(planned, PlannedDivision{Name: name, Teams: divTeams})
+	}
+
+	return planned, nil
+}
+
+// uniqueName picks a state name that isn't in used yet, falling back to a numbered name once
+// they're all taken.
+func uniqueName(used map[string]bool, i int) string {
+	for range 100 {
+		name := fake.USState()
+		if !used[name] {
+			return name
+		}
+	}
+
+	name := fmt.Sprintf("Division %d", i+1)
+	for n := i + 2; used[name]; n++ {
+		name = fmt.Sprintf("Division %d", n)
+	}
+	return name
+}
+
+// Create saves the planned divisions and assigns their teams, all or nothing.
+func (s Service) Create(ctx context.Context, planned []PlannedDivision) error {
+	return s.txer.WithTx(ctx, func(ctx context.Context) error {
+		for _, p := range planned {
+			division, err := s.divisionRepo.Create(ctx)
+			if err != nil {
+				return err
+			}
+
+			err = s.divisionRepo.Rename(ctx, division.ID, p.Name)
+			if err != nil {
+				return err
+			}
+
+			if len(p.Teams) != division.Size {
+				err = s.divisionRepo.UpdateSize(ctx, division.ID, len(p.Teams))
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, t := range p.Teams {
+				err := s.teamRepo.AssignToDivision(ctx, t.ID, division.ID)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,109 @@
+package divisions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+func TestSizes(t *testing.T) {
+	assert.SliceLen(t, Sizes(0), 0)
+	assert.Equal(t, []int{1}, Sizes(1))
+	assert.Equal(t, []int{2}, Sizes(2))
+	assert.Equal(t, []int{3}, Sizes(3))
+	assert.Equal(t, []int{4, 3}, Sizes(7))
+	assert.Equal(t, []int{4, 4}, Sizes(8))
+	assert.Equal(t, []int{4, 5}, Sizes(9))
+	assert.Equal(t, []int{4, 6}, Sizes(10))
+	assert.Equal(t, []int{4, 4, 3}, Sizes(11))
+}
+
+// ratedTeams makes teams named after their ratings, given from weakest to strongest.
+func ratedTeams(ratings ...int) []teams.Team {
+	ts := make([]teams.Team, 0, len(ratings))
+	for _, r := range ratings {
+		ts = append(ts, teams.Team{ID: fmt.Sprint(r), Name: fmt.Sprintf("t%d", r), Rating: r})
+	}
+	return ts
+}
+
+func ratings(divs [][]teams.Team) [][]int {
+	res := make([][]int, 0, len(divs))
+	for _, div := range divs {
+		var rs []int
+		for _, t := range div {
+			rs = append(rs, t.Rating)
+		}
+		res = append(res, rs)
+	}
+	return res
+}
+
+func TestBalance_SnakeDrafts(t *testing.T) {
+	divs, err := Balance(ratedTeams(1, 2, 3, 4, 5, 6, 7, 8), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{8, 5, 4, 1}, {7, 6, 3, 2}}, ratings(divs))
+}
+
+func TestBalance_FewTeams(t *testing.T) {
+	divs, err := Balance(ratedTeams(1, 2), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{2, 1}}, ratings(divs))
+}
+
+func TestBalance_KeepsTeamsApart(t *testing.T) {
+	// 5 would go to 8's division, so it takes 1's next pick instead.
+	divs, err := Balance(ratedTeams(1, 2, 3, 4, 5, 6, 7, 8), [][2]string{{"8", "5"}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{8, 4, 3, 1}, {7, 6, 5, 2}}, ratings(divs))
+}
+
+func TestBalance_CantKeepApart(t *testing.T) {
+	_, err := Balance(ratedTeams(1, 2, 3), [][2]string{{"1", "3"}})
+	assert.ErrorIs(t, err, ErrCantKeepApart)
+}
+
+func TestPlanAndCreate(t *testing.T) {
+	svc, dr, tr := newDivisionService(t)
+	ctx := t.Context()
+
+	for i := range 7 {
+		team, err := tr.Create(ctx, fmt.Sprintf("team%d", i))
+		assert.NoError(t, err)
+		assert.NoError(t, tr.SetRating(ctx, team.ID, i))
+	}
+
+	planned, err := svc.Plan(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, planned, 2)
+	assert.SliceLen(t, planned[0].Teams, 4)
+	assert.SliceLen(t, planned[1].Teams, 3)
+	if planned[0].Name == planned[1].Name {
+		t.Errorf("divisions should have different names, both are %q", planned[0].Name)
+	}
+
+	// Nothing is saved until the plan is created.
+	divs, err := dr.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, divs, 0)
+
+	assert.NoError(t, svc.Create(ctx, planned))
+
+	divs, err = dr.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, divs, 2)
+
+	left, err := tr.GetWithoutDivision(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, left, 0)
+
+	// Creating the same plan again fails without creating any divisions.
+	assert.ErrorIs(t, svc.Create(ctx, planned), teams.ErrTeamAlreadyInDivision)
+
+	divs, err = dr.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, divs, 2)
+}
@@ -18,9 +18,6 @@ func newDivisionService(t *testing.T) (Service, divisions.Repository, teams.Repo
 	dr := divisions.NewRepository(db)
 	tr := teams.NewRepository(db)
 
-	assert.NoError(t, dr.Init(t.Context()))
-	assert.NoError(t, tr.Init(t.Context()))
-
 	return New(txer, tr, dr), dr, tr
 }
 
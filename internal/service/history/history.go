@@ -0,0 +1,371 @@
+package history
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/divisions"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/ratings"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	"github.com/cszczepaniak/cribbly/internal/service/results"
+	"github.com/cszczepaniak/cribbly/internal/service/tournament"
+)
+
+// maxOpponents is how many of a player's most frequent opponents their history lists.
+const maxOpponents = 10
+
+// Record is a number of games won and lost.
+type Record struct {
+	Wins   int
+	Losses int
+}
+
+func (r *Record) add(other Record) {
+	r.Wins += other.Wins
+	r.Losses += other.Losses
+}
+
+// HeadToHead is how a player has done against one opponent, across every event.
+type HeadToHead struct {
+	Opponent players.Person
+	Record   Record
+}
+
+// EventHistory is how a player did at one event.
+type EventHistory struct {
+	Event  events.Event
+	Player players.Player
+	// Team is empty if the player wasn't on a team.
+	Team     teams.Team
+	Partners []players.Player
+	// Division is the team's division, or empty if it wasn't in one.
+	Division divisions.Division
+	// Finish is the team's place in its division's prelim standings, starting at 1, out of FinishOf
+	// teams. Without a division it's the team's place in the whole event. It's 0 if the team
+	// hasn't played.
+	Finish   int
+	FinishOf int
+	// Bracket describes how far the team got in the brackets, like "Champion" or "Quarterfinals". It's
+	// empty if the team wasn't in a bracket.
+	Bracket string
+	// Record counts every game the team played, prelims and brackets.
+	Record Record
+	// Rating is the player's rating after the event, or 0 if ratings haven't been computed.
+	Rating int
+}
+
+// PlayerHistory is everything a person has done, across every event they've played in.
+type PlayerHistory struct {
+	Person players.Person
+	// Events are most recent first.
+	Events []EventHistory
+	Record Record
+	// Opponents are the people the player has played most, most games first.
+	Opponents []HeadToHead
+}
+
+// Service puts together players' results across events.
+type Service struct {
+	eventRepo    events.Repository
+	playerRepo   players.Repository
+	teamRepo     teams.Repository
+	divisionRepo divisions.Repository
+	gameRepo     games.Repository
+	ratingRepo   ratings.Repository
+	tournaments  tournament.Service
+	results      results.Service
+}
+
+func New(
+	eventRepo events.Repository,
+	playerRepo players.Repository,
+	teamRepo teams.Repository,
+	divisionRepo divisions.Repository,
+	gameRepo games.Repository,
+	ratingRepo ratings.Repository,
+	tournaments tournament.Service,
+) Service {
+	return Service{
+		eventRepo:    eventRepo,
+		playerRepo:   playerRepo,
+		teamRepo:     teamRepo,
+		divisionRepo: divisionRepo,
+		gameRepo:     gameRepo,
+		ratingRepo:   ratingRepo,
+		tournaments:  tournaments,
+		results:      results.New(gameRepo, tournaments),
+	}
+}
+
+// GetPlayerHistory returns the person's teams, finishes and records in every event they've played
+// in. It returns sql.ErrNoRows if there's no such person.
+func (s Service) GetPlayerHistory(ctx context.Context, personID string) (PlayerHistory, error) {
+	person, err := s.playerRepo.GetPerson(ctx, personID)
+	if err != nil {
+		return PlayerHistory{}, err
+	}
+
+	ps, err := s.playerRepo.GetForPerson(ctx, personID)
+	if err != nil {
+		return PlayerHistory{}, err
+	}
+
+	es, err := s.eventRepo.GetAll(ctx)
+	if err != nil {
+		return PlayerHistory{}, err
+	}
+	eventsByID := make(map[string]events.Event, len(es))
+	for _, e := range es {
+		eventsByID[e.ID] = e
+	}
+
+	playerIDs := make([]string, 0, len(ps))
+	for _, p := range ps {
+		playerIDs = append(playerIDs, p.ID)
+	}
+	rs, err := s.ratingRepo.GetForPlayers(ctx, playerIDs...)
+	if err != nil {
+		return PlayerHistory{}, err
+	}
+	ratingsByPlayer := make(map[string]int, len(rs))
+	for _, r := range rs {
+		ratingsByPlayer[r.PlayerID] = r.Rating
+	}
+
+	history := PlayerHistory{Person: person}
+	opponents := make(map[string]*HeadToHead)
+	for _, p := range ps {
+		e, ok := eventsByID[p.EventID]
+		if !ok {
+			continue
+		}
+
+		eh := EventHistory{
+			Event:  e,
+			Player: p,
+			Rating: ratingsByPlayer[p.ID],
+		}
+		if p.TeamID != "" {
+			err := s.teamHistory(events.WithEvent(ctx, e), &eh, opponents)
+			if err != nil {
+				return PlayerHistory{}, err
+			}
+		}
+
+		history.Events = append(history.Events, eh)
+		history.Record.add(eh.Record)
+	}
+
+	slices.SortFunc(history.Events, func(a, b EventHistory) int {
+		return cmp.Or(b.Event.Date.Compare(a.Event.Date), cmp.Compare(a.Event.Name, b.Event.Name))
+	})
+
+	for _, h := range opponents {
+		history.Opponents = append(history.Opponents, *h)
+	}
+	slices.SortFunc(history.Opponents, func(a, b HeadToHead) int {
+		return cmp.Or(
+			cmp.Compare(b.Record.Wins+b.Record.Losses, a.Record.Wins+a.Record.Losses),
+			cmp.Compare(b.Record.Wins, a.Record.Wins),
+			cmp.Compare(a.Opponent.Name(), b.Opponent.Name()),
+		)
+	})
+	history.Opponents = history.Opponents[:min(len(history.Opponents), maxOpponents)]
+
+	return history, nil
+}
+
+// teamHistory fills in how the player's team did at the event ctx is scoped to, and counts their
+// games against each opponent.
+func (s Service) teamHistory(ctx context.Context, eh *EventHistory, opponents map[string]*HeadToHead) error {
+	teamID := eh.Player.TeamID
+
+	ts, err := s.teamRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	teamsByID := make(map[string]teams.Team, len(ts))
+	for _, t := range ts {
+		teamsByID[t.ID] = t
+	}
+	eh.Team = teamsByID[teamID]
+
+	ps, err := s.playerRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	playersByTeam := make(map[string][]players.Player)
+	for _, p := range ps {
+		if p.TeamID == "" {
+			continue
+		}
+		playersByTeam[p.TeamID] = append(playersByTeam[p.TeamID], p)
+		if p.TeamID == teamID && p.ID != eh.Player.ID {
+			eh.Partners = append(eh.Partners, p)
+		}
+	}
+
+	if eh.Team.DivisionID != "" {
+		eh.Division, err = s.divisionRepo.Get(ctx, eh.Team.DivisionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	standings, err := s.gameRepo.GetStandings(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range ts {
+		if t.DivisionID == eh.Team.DivisionID {
+			eh.FinishOf++
+		}
+	}
+	place := 0
+	for _, st := range standings {
+		if teamsByID[st.TeamID].DivisionID != eh.Team.DivisionID {
+			continue
+		}
+		place++
+		if st.TeamID == teamID {
+			eh.Finish = place
+			break
+		}
+	}
+
+	main, err := s.tournaments.Get(ctx)
+	if err != nil {
+		return err
+	}
+	consolation, err := s.tournaments.Consolation().Get(ctx)
+	if err != nil {
+		return err
+	}
+	eh.Bracket = bracketResult(main, consolation, teamID)
+
+	gs, err := s.results.ForEvent(ctx)
+	if err != nil {
+		return err
+	}
+	for _, g := range gs {
+		var opponentID string
+		var r Record
+		switch teamID {
+		case g.WinnerID:
+			opponentID = g.LoserID
+			r.Wins++
+		case g.LoserID:
+			opponentID = g.WinnerID
+			r.Losses++
+		default:
+			continue
+		}
+		eh.Record.add(r)
+
+		for _, o := range playersByTeam[opponentID] {
+			h, ok := opponents[o.PersonID]
+			if !ok {
+				h = &HeadToHead{}
+				opponents[o.PersonID] = h
+			}
+			// A person's players are usually named alike; whichever is seen last names them.
+			h.Opponent = players.Person{ID: o.PersonID, FirstName: o.FirstName, LastName: o.LastName}
+			h.Record.add(r)
+		}
+	}
+
+	return nil
+}
+
+// bracketResult describes how far a team got in an event's brackets, or returns "" if it wasn't in
+// either of them.
+func bracketResult(main, consolation tournament.Bracket, teamID string) string {
+	if r := finish(main, teamID); r != "" {
+		return r
+	}
+	switch finish(consolation, teamID) {
+	case "":
+		return ""
+	case "Champion":
+		return "Consolation champion"
+	default:
+		return "Consolation bracket"
+	}
+}
+
+// finish describes how far a team got in a bracket, or returns "" if it wasn't in it.
+func finish(b tournament.Bracket, teamID string) string {
+	if !slices.ContainsFunc(allGames(b), func(g tournament.Game) bool { return g.HasTeam(teamID) }) {
+		return ""
+	}
+
+	champion := b.Champion()
+	if champion.ID == teamID {
+		return "Champion"
+	}
+
+	if b.Format == tournament.FormatDoubleElimination {
+		if champion.ID != "" && slices.ContainsFunc(b.Final, func(r tournament.Round) bool {
+			return slices.ContainsFunc(r.Games, func(g tournament.Game) bool { return g.HasTeam(teamID) })
+		}) {
+			return "Runner-up"
+		}
+		if i, ok := lostIn(b.Losers, teamID); ok {
+			if i == len(b.Losers)-1 {
+				return "Third place"
+			}
+			return fmt.Sprintf("Losers round %d", i+1)
+		}
+		return "Still playing"
+	}
+
+	if g := b.ThirdPlace; g != nil && g.Decided() && g.HasTeam(teamID) {
+		if g.Winner.ID == teamID {
+			return "Third place"
+		}
+		return "Fourth place"
+	}
+	if i, ok := lostIn(b.Rounds, teamID); ok {
+		switch len(b.Rounds) - i {
+		case 1:
+			return "Runner-up"
+		case 2:
+			return "Semifinals"
+		case 3:
+			return "Quarterfinals"
+		}
+		return fmt.Sprintf("Round %d", i+1)
+	}
+	return "Still playing"
+}
+
+// allGames returns every game in the bracket.
+func allGames(b tournament.Bracket) []tournament.Game {
+	var gs []tournament.Game
+	for _, rounds := range [][]tournament.Round{b.Rounds, b.Losers, b.Final} {
+		for _, r := range rounds {
+			gs = append(gs, r.Games...)
+		}
+	}
+	if b.ThirdPlace != nil {
+		gs = append(gs, *b.ThirdPlace)
+	}
+	return gs
+}
+
+// lostIn returns the round in which the team lost a decided game.
+func lostIn(rounds []tournament.Round, teamID string) (int, bool) {
+	for i, r := range rounds {
+		for _, g := range r.Games {
+			if g.HasTeam(teamID) && g.Decided() && g.Winner.ID != teamID {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,230 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/divisions"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/ratings"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	"github.com/cszczepaniak/cribbly/internal/service/tournament"
+)
+
+func TestGetPlayerHistory(t *testing.T) {
+	db := database.NewInMemory(t)
+	txer := database.NewTransactor(db)
+	er := events.NewRepository(db)
+	pr := players.NewRepository(db)
+	tr := teams.NewRepository(db)
+	dr := divisions.NewRepository(db)
+	gr := games.NewRepository(db, &games.ScoreNotifier{})
+	tournaments := tournament.New(txer, gr, tr, &tournament.Notifier{})
+	svc := New(er, pr, tr, dr, gr, ratings.NewRepository(db), tournaments)
+
+	// newTeam creates a team of two players in the event ctx is scoped to, in the given division.
+	newTeam := func(ctx context.Context, divisionID, first1, first2 string) teams.Team {
+		team, err := tr.Create(ctx, first1+" & "+first2)
+		assert.NoError(t, err)
+		assert.NoError(t, tr.AssignToDivision(ctx, team.ID, divisionID))
+
+		for _, first := range []string{first1, first2} {
+			id, err := pr.Create(ctx, first, "Smith")
+			assert.NoError(t, err)
+			assert.NoError(t, pr.AssignToTeam(ctx, id, team.ID))
+		}
+		return team
+	}
+	play := func(ctx context.Context, winner, loser teams.Team, loserScore int) {
+		gameID, err := gr.Create(ctx, winner.ID, loser.ID)
+		assert.NoError(t, err)
+		assert.NoError(t, gr.UpdateScores(ctx, gameID, winner.ID, 121, loser.ID, loserScore))
+	}
+
+	// Last year, Alice and Bob won their division and then the final against Carol and Dave.
+	lastYear := t.Context()
+	div, err := dr.Create(lastYear)
+	assert.NoError(t, err)
+	ab := newTeam(lastYear, div.ID, "Alice", "Bob")
+	cd := newTeam(lastYear, div.ID, "Carol", "Dave")
+	play(lastYear, ab, cd, 100)
+	assert.NoError(t, tournaments.Seed(lastYear, 2, false))
+	assert.NoError(t, tournaments.Advance(lastYear, tournament.SideWinners, 0, 0, ab.ID))
+
+	// This year, Alice is playing with Carol and they lost to Dave and Erin.
+	thisYear, err := er.Create(lastYear, "This Year", time.Now().AddDate(1, 0, 0))
+	assert.NoError(t, err)
+	thisYearCtx := events.WithEvent(lastYear, thisYear)
+	div, err = dr.Create(thisYearCtx)
+	assert.NoError(t, err)
+	ac := newTeam(thisYearCtx, div.ID, "Alice", "Carol")
+	de := newTeam(thisYearCtx, div.ID, "Dave", "Erin")
+	play(thisYearCtx, de, ac, 90)
+
+	ps, err := pr.GetForTeam(lastYear, ab.ID)
+	assert.NoError(t, err)
+	alice := ps[0]
+	if alice.FirstName != "Alice" {
+		alice = ps[1]
+	}
+
+	h, err := svc.GetPlayerHistory(lastYear, alice.PersonID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice Smith", h.Person.Name())
+	assert.Equal(t, Record{Wins: 2, Losses: 1}, h.Record)
+
+	// Most recent first.
+	assert.SliceLen(t, h.Events, 2)
+	assert.Equal(t, "This Year", h.Events[0].Event.Name)
+	assert.Equal(t, ac.ID, h.Events[0].Team.ID)
+	assert.SliceLen(t, h.Events[0].Partners, 1)
+	assert.Equal(t, "Carol", h.Events[0].Partners[0].FirstName)
+	assert.Equal(t, 2, h.Events[0].Finish)
+	assert.Equal(t, 2, h.Events[0].FinishOf)
+	assert.Equal(t, "", h.Events[0].Bracket)
+	assert.Equal(t, Record{Losses: 1}, h.Events[0].Record)
+
+	assert.Equal(t, ab.ID, h.Events[1].Team.ID)
+	assert.Equal(t, 1, h.Events[1].Finish)
+	assert.Equal(t, "Champion", h.Events[1].Bracket)
+	assert.Equal(t, Record{Wins: 2}, h.Events[1].Record)
+
+	// Dave was on the other side in all three games; Carol in two, and Erin in one.
+	assert.SliceLen(t, h.Opponents, 3)
+	assert.Equal(t, "Dave Smith", h.Opponents[0].Opponent.Name())
+	assert.Equal(t, Record{Wins: 2, Losses: 1}, h.Opponents[0].Record)
+	assert.Equal(t, "Carol Smith", h.Opponents[1].Opponent.Name())
+	assert.Equal(t, Record{Wins: 2}, h.Opponents[1].Record)
+	assert.Equal(t, "Erin Smith", h.Opponents[2].Opponent.Name())
+	assert.Equal(t, Record{Losses: 1}, h.Opponents[2].Record)
+
+	_, err = svc.GetPlayerHistory(lastYear, "nobody")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestFinish(t *testing.T) {
+	team := func(id string) tournament.Team { return tournament.Team{ID: id, Name: id} }
+	game := func(a, b, winner string) tournament.Game {
+		g := tournament.Game{Teams: [2]tournament.Team{team(a), team(b)}}
+		if winner != "" {
+			g.Winner = team(winner)
+		}
+		return g
+	}
+
+	b := tournament.Bracket{
+		Rounds: []tournament.Round{
+			{Games: []tournament.Game{game("a", "h", "a"), game("d", "e", "e"), game("b", "g", "b"), game("c", "f", "c")}},
+			{Games: []tournament.Game{game("a", "e", "a"), game("b", "c", "c")}},
+			{Games: []tournament.Game{game("a", "c", "")}},
+		},
+	}
+	assert.Equal(t, "Quarterfinals", finish(b, "h"))
+	assert.Equal(t, "Semifinals", finish(b, "b"))
+	assert.Equal(t, "Still playing", finish(b, "a"))
+	assert.Equal(t, "", finish(b, "z"))
+
+	b.Rounds[2].Games[0].Winner = team("c")
+	assert.Equal(t, "Champion", finish(b, "c"))
+	assert.Equal(t, "Runner-up", finish(b, "a"))
+
+	third := game("e", "b", "b")
+	b.ThirdPlace = &third
+	assert.Equal(t, "Third place", finish(b, "b"))
+	assert.Equal(t, "Fourth place", finish(b, "e"))
+
+	assert.Equal(t, "Consolation champion", bracketResult(tournament.Bracket{}, b, "c"))
+	assert.Equal(t, "Consolation bracket", bracketResult(tournament.Bracket{}, b, "h"))
+}
+
+func TestGetRecords(t *testing.T) {
+	db := database.NewInMemory(t)
+	txer := database.NewTransactor(db)
+	er := events.NewRepository(db)
+	pr := players.NewRepository(db)
+	tr := teams.NewRepository(db)
+	gr := games.NewRepository(db, &games.ScoreNotifier{})
+	tournaments := tournament.New(txer, gr, tr, &tournament.Notifier{})
+	svc := New(er, pr, tr, divisions.NewRepository(db), gr, ratings.NewRepository(db), tournaments)
+
+	newTeam := func(ctx context.Context, first1, first2 string) teams.Team {
+		team, err := tr.Create(ctx, first1+" & "+first2)
+		assert.NoError(t, err)
+		for _, first := range []string{first1, first2} {
+			id, err := pr.Create(ctx, first, "Smith")
+			assert.NoError(t, err)
+			assert.NoError(t, pr.AssignToTeam(ctx, id, team.ID))
+		}
+		return team
+	}
+	// Games are replayed by round, so each game gets its own.
+	round := 0
+	play := func(ctx context.Context, winner, loser teams.Team, loserScore int) {
+		round++
+		gameID, err := gr.CreateInRound(ctx, round, winner.ID, loser.ID)
+		assert.NoError(t, err)
+		assert.NoError(t, gr.UpdateScores(ctx, gameID, winner.ID, 121, loser.ID, loserScore))
+	}
+
+	r, err := svc.GetRecords(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, Records{}, r)
+
+	// Last year, Alice and Bob won two prelim games, one of them by a skunk, and then the final.
+	lastYear := t.Context()
+	ab := newTeam(lastYear, "Alice", "Bob")
+	cd := newTeam(lastYear, "Carol", "Dave")
+	play(lastYear, ab, cd, 80)
+	play(lastYear, ab, cd, 100)
+	assert.NoError(t, tournaments.Seed(lastYear, 2, false))
+	assert.NoError(t, tournaments.Advance(lastYear, tournament.SideWinners, 0, 0, ab.ID))
+	assert.NoError(t, tournaments.Delete(lastYear))
+
+	// This year, Alice won another prelim game with Carol before they lost, and Bob won with Dave,
+	// who won the final.
+	thisYear, err := er.Create(lastYear, "This Year", time.Now().AddDate(1, 0, 0))
+	assert.NoError(t, err)
+	thisYearCtx := events.WithEvent(lastYear, thisYear)
+	ac := newTeam(thisYearCtx, "Alice", "Carol")
+	bd := newTeam(thisYearCtx, "Bob", "Dave")
+	play(thisYearCtx, ac, bd, 50)
+	play(thisYearCtx, bd, ac, 110)
+	assert.NoError(t, tournaments.Seed(thisYearCtx, 2, false))
+	assert.NoError(t, tournaments.Advance(thisYearCtx, tournament.SideWinners, 0, 0, bd.ID))
+
+	r, err = svc.GetRecords(lastYear)
+	assert.NoError(t, err)
+
+	assert.SliceLen(t, r.Finals, 2)
+	assert.Equal(t, "This Year", r.Finals[0].Champion.EventName)
+	assert.Equal(t, bd.ID, r.Finals[0].Champion.TeamID)
+	assert.Equal(t, ac.ID, r.Finals[0].RunnerUp.TeamID)
+	assert.Equal(t, ab.ID, r.Finals[1].Champion.TeamID)
+	assert.Equal(t, cd.ID, r.Finals[1].RunnerUp.TeamID)
+
+	// Bob is the only two-time champion.
+	assert.SliceLen(t, r.MostTitles, 3)
+	assert.Equal(t, "Bob Smith", r.MostTitles[0].Person.Name())
+	assert.Equal(t, 2, r.MostTitles[0].Titles)
+	assert.Equal(t, "Alice Smith", r.MostTitles[1].Person.Name())
+	assert.Equal(t, 1, r.MostTitles[1].Titles)
+	assert.Equal(t, "Dave Smith", r.MostTitles[2].Person.Name())
+
+	// Alice's streak carried over into this year; Bob's ended in its first game.
+	assert.Equal(t, "Alice Smith", r.LongestStreak.Person.Name())
+	assert.Equal(t, 3, r.LongestStreak.Games)
+	assert.Equal(t, events.LegacyID, r.LongestStreak.From.ID)
+	assert.Equal(t, thisYear.ID, r.LongestStreak.To.ID)
+
+	assert.Equal(t, thisYear.ID, r.BiggestSkunk.Event.ID)
+	assert.Equal(t, ac.ID, r.BiggestSkunk.Winner.ID)
+	assert.Equal(t, bd.ID, r.BiggestSkunk.Loser.ID)
+	assert.Equal(t, 71, r.BiggestSkunk.Margin())
+}
@@ -0,0 +1,188 @@
+package history
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+// maxTitleHolders is how many people the most-titles record lists.
+const maxTitleHolders = 5
+
+// Final is the final of one event's main bracket.
+type Final struct {
+	Champion games.Finalist
+	// RunnerUp is empty if the runner-up's team was gone before the final was recorded.
+	RunnerUp games.Finalist
+}
+
+// Titles is how many events a person has won.
+type Titles struct {
+	Person players.Person
+	Titles int
+}
+
+// Streak is a run of prelim games a person won in a row, which may span events.
+type Streak struct {
+	Person players.Person
+	Games  int
+	// From and To are the events of the first and last games of the streak.
+	From events.Event
+	To   events.Event
+}
+
+// Skunk is a skunked game.
+type Skunk struct {
+	Event       events.Event
+	Winner      teams.Team
+	Loser       teams.Team
+	WinnerScore int
+	LoserScore  int
+}
+
+func (s Skunk) Margin() int {
+	return s.WinnerScore - s.LoserScore
+}
+
+// Records are the champions of every event and records set across all of them.
+type Records struct {
+	// Finals are most recent first.
+	Finals []Final
+	// MostTitles are the people who have won the most events, most first.
+	MostTitles []Titles
+	// LongestStreak is the longest unbeaten run of prelim games; it's empty if no prelim game has
+	// been won.
+	LongestStreak Streak
+	// BiggestSkunk is the skunk with the biggest winning margin; it's empty if no game has been
+	// skunked.
+	BiggestSkunk Skunk
+}
+
+// GetRecords returns every event's champion and runner-up, as recorded when their finals were
+// decided, and the records computed from every event's results.
+func (s Service) GetRecords(ctx context.Context) (Records, error) {
+	fs, err := s.gameRepo.GetAllFinalists(ctx)
+	if err != nil {
+		return Records{}, err
+	}
+
+	var records Records
+	titles := make(map[string]*Titles)
+	for _, f := range fs {
+		if f.Place == games.PlaceRunnerUp {
+			// Finalists are sorted champion first, so the runner-up belongs to the last final.
+			if n := len(records.Finals); n > 0 && records.Finals[n-1].Champion.EventID == f.EventID {
+				records.Finals[n-1].RunnerUp = f
+			}
+			continue
+		}
+
+		records.Finals = append(records.Finals, Final{Champion: f})
+		for _, p := range f.Players {
+			t, ok := titles[p.PersonID]
+			if !ok {
+				t = &Titles{Person: players.Person{ID: p.PersonID, FirstName: p.FirstName, LastName: p.LastName}}
+				titles[p.PersonID] = t
+			}
+			t.Titles++
+		}
+	}
+
+	for _, t := range titles {
+		records.MostTitles = append(records.MostTitles, *t)
+	}
+	slices.SortFunc(records.MostTitles, func(a, b Titles) int {
+		return cmp.Or(cmp.Compare(b.Titles, a.Titles), cmp.Compare(a.Person.Name(), b.Person.Name()))
+	})
+	records.MostTitles = records.MostTitles[:min(len(records.MostTitles), maxTitleHolders)]
+
+	err = s.resultRecords(ctx, &records)
+	if err != nil {
+		return Records{}, err
+	}
+	return records, nil
+}
+
+// resultRecords fills in the records that come from replaying every event's games, oldest event
+// first.
+func (s Service) resultRecords(ctx context.Context, records *Records) error {
+	es, err := s.eventRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(es, func(a, b events.Event) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	// streaks are each person's current streak.
+	streaks := make(map[string]*Streak)
+	for _, e := range es {
+		ctx := events.WithEvent(ctx, e)
+
+		ts, err := s.teamRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		teamsByID := make(map[string]teams.Team, len(ts))
+		for _, t := range ts {
+			teamsByID[t.ID] = t
+		}
+
+		ps, err := s.playerRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		playersByTeam := make(map[string][]players.Player)
+		for _, p := range ps {
+			if p.TeamID != "" {
+				playersByTeam[p.TeamID] = append(playersByTeam[p.TeamID], p)
+			}
+		}
+
+		gs, err := s.results.ForEvent(ctx)
+		if err != nil {
+			return err
+		}
+		for _, g := range gs {
+			if games.GameResult(g.WinnerScore, g.LoserScore) >= games.ResultSkunk &&
+				g.WinnerScore-g.LoserScore > records.BiggestSkunk.Margin() {
+				records.BiggestSkunk = Skunk{
+					Event:       e,
+					Winner:      teamsByID[g.WinnerID],
+					Loser:       teamsByID[g.LoserID],
+					WinnerScore: g.WinnerScore,
+					LoserScore:  g.LoserScore,
+				}
+			}
+
+			if !g.Prelim {
+				continue
+			}
+			for _, p := range playersByTeam[g.LoserID] {
+				delete(streaks, p.PersonID)
+			}
+			for _, p := range playersByTeam[g.WinnerID] {
+				st, ok := streaks[p.PersonID]
+				if !ok {
+					st = &Streak{
+						Person: players.Person{ID: p.PersonID, FirstName: p.FirstName, LastName: p.LastName},
+						From:   e,
+					}
+					streaks[p.PersonID] = st
+				}
+				st.Games++
+				st.To = e
+				if st.Games > records.LongestStreak.Games {
+					records.LongestStreak = *st
+				}
+			}
+		}
+	}
+
+	return nil
+}
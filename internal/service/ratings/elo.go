@@ -0,0 +1,75 @@
+package ratings
+
+import (
+	"math"
+)
+
+const (
+	// Initial is the rating of a player who hasn't played a rated game.
+	Initial = 1500
+	// K is the most one game can move a player's rating.
+	K = 32
+)
+
+// Expected is the chance that a team rated a beats a team rated b.
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// Game is a finished game, given as the people (by person ID) on each team.
+type Game struct {
+	Winners []string
+	Losers  []string
+}
+
+// Ratings holds everyone's ratings as games are replayed.
+type Ratings struct {
+	ratings map[string]float64
+	games   map[string]int
+}
+
+func NewRatings() *Ratings {
+	return &Ratings{
+		ratings: make(map[string]float64),
+		games:   make(map[string]int),
+	}
+}
+
+// Get returns a person's rating.
+func (r *Ratings) Get(person string) float64 {
+	if rating, ok := r.ratings[person]; ok {
+		return rating
+	}
+	return Initial
+}
+
+// Games returns the number of games a person has played.
+func (r *Ratings) Games(person string) int {
+	return r.games[person]
+}
+
+// Play updates the ratings of everyone in the game. A team is rated as the average of its players,
+// and each player on it gains or loses what the team would under Elo.
+func (r *Ratings) Play(g Game) {
+	if len(g.Winners) == 0 || len(g.Losers) == 0 {
+		return
+	}
+
+	change := K * (1 - Expected(r.team(g.Winners), r.team(g.Losers)))
+	for _, p := range g.Winners {
+		r.ratings[p] = r.Get(p) + change
+		r.games[p]++
+	}
+	for _, p := range g.Losers {
+		r.ratings[p] = r.Get(p) - change
+		r.games[p]++
+	}
+}
+
+func (r *Ratings) team(people []string) float64 {
+	total := 0.0
+	for _, p := range people {
+		total += r.Get(p)
+	}
+	return total / float64(len(people))
+}
@@ -0,0 +1,37 @@
+package ratings
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+)
+
+func TestExpected(t *testing.T) {
+	assert.Equal(t, 0.5, Expected(1500, 1500))
+	// 400 points is 10-to-1.
+	assert.Equal(t, 10.0/11, Expected(1900, 1500))
+}
+
+func TestRatings_Play(t *testing.T) {
+	r := NewRatings()
+	r.Play(Game{Winners: []string{"a", "b"}, Losers: []string{"c", "d"}})
+
+	// Evenly matched teams swing by half of K.
+	assert.Equal(t, Initial+K/2.0, r.Get("a"))
+	assert.Equal(t, Initial+K/2.0, r.Get("b"))
+	assert.Equal(t, Initial-K/2.0, r.Get("c"))
+	assert.Equal(t, Initial-K/2.0, r.Get("d"))
+	assert.Equal(t, 1, r.Games("a"))
+	assert.Equal(t, 0, r.Games("nobody"))
+
+	// An upset moves ratings further than the favourite winning would.
+	before := r.Get("c")
+	r.Play(Game{Winners: []string{"c", "d"}, Losers: []string{"a", "b"}})
+	upset := r.Get("c") - before
+	if upset <= K/2.0 {
+		t.Errorf("an upset should move ratings by more than K/2, got %f", upset)
+	}
+	// Whatever one team gains, the other loses.
+	assert.Equal(t, 0.0, math.Round(r.Get("a")+r.Get("c")-2*Initial))
+}
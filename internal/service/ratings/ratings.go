@@ -0,0 +1,115 @@
+package ratings
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/ratings"
+	"github.com/cszczepaniak/cribbly/internal/service/results"
+	"github.com/cszczepaniak/cribbly/internal/service/tournament"
+)
+
+// Service computes player ratings from every event's results.
+type Service struct {
+	txer       database.Transactor
+	eventRepo  events.Repository
+	playerRepo players.Repository
+	ratingRepo ratings.Repository
+	results    results.Service
+}
+
+func New(
+	txer database.Transactor,
+	eventRepo events.Repository,
+	playerRepo players.Repository,
+	gameRepo games.Repository,
+	ratingRepo ratings.Repository,
+	tournaments tournament.Service,
+) Service {
+	return Service{
+		txer:       txer,
+		eventRepo:  eventRepo,
+		playerRepo: playerRepo,
+		ratingRepo: ratingRepo,
+		results:    results.New(gameRepo, tournaments),
+	}
+}
+
+// Recompute replays every finished game of every event, oldest event first, and stores each
+// player's rating after their event. Within an event, games are replayed in the order
+// results.Service.ForEvent returns them.
+func (s Service) Recompute(ctx context.Context) error {
+	es, err := s.eventRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(es, func(a, b events.Event) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	r := NewRatings()
+	var stored []ratings.Rating
+	for _, e := range es {
+		ctx := events.WithEvent(ctx, e)
+
+		ps, err := s.playerRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		peopleByTeam := make(map[string][]string)
+		for _, p := range ps {
+			if p.TeamID != "" {
+				peopleByTeam[p.TeamID] = append(peopleByTeam[p.TeamID], p.PersonID)
+			}
+		}
+
+		gs, err := s.results.ForEvent(ctx)
+		if err != nil {
+			return err
+		}
+		for _, g := range gs {
+			r.Play(Game{Winners: peopleByTeam[g.WinnerID], Losers: peopleByTeam[g.LoserID]})
+		}
+
+		for _, p := range ps {
+			stored = append(stored, ratings.Rating{
+				EventID:  e.ID,
+				PlayerID: p.ID,
+				Rating:   int(math.Round(r.Get(p.PersonID))),
+				Games:    r.Games(p.PersonID),
+			})
+		}
+	}
+
+	return s.ratingRepo.ReplaceAll(ctx, stored)
+}
+
+// ApplyToPlayers recomputes ratings, then sets each of the current event's players' ratings (the
+// ones used to balance teams) to their computed rating.
+func (s Service) ApplyToPlayers(ctx context.Context) error {
+	err := s.Recompute(ctx)
+	if err != nil {
+		return err
+	}
+
+	rs, err := s.ratingRepo.GetForEvent(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.txer.WithTx(ctx, func(ctx context.Context) error {
+		for playerID, rating := range rs {
+			err := s.playerRepo.SetRating(ctx, playerID, rating.Rating)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,78 @@
+package ratings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/ratings"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	"github.com/cszczepaniak/cribbly/internal/service/tournament"
+)
+
+func TestRecompute(t *testing.T) {
+	db := database.NewInMemory(t)
+	txer := database.NewTransactor(db)
+	er := events.NewRepository(db)
+	pr := players.NewRepository(db)
+	tr := teams.NewRepository(db)
+	gr := games.NewRepository(db, &games.ScoreNotifier{})
+	rr := ratings.NewRepository(db)
+	svc := New(txer, er, pr, gr, rr, tournament.New(txer, gr, tr, &tournament.Notifier{}))
+
+	// newTeam creates a team of two players in the event ctx is scoped to.
+	newTeam := func(ctx context.Context, first1, first2 string) (teams.Team, [2]string) {
+		team, err := tr.Create(ctx, first1+" & "+first2)
+		assert.NoError(t, err)
+
+		var ids [2]string
+		for i, first := range []string{first1, first2} {
+			id, err := pr.Create(ctx, first, "Smith")
+			assert.NoError(t, err)
+			assert.NoError(t, pr.AssignToTeam(ctx, id, team.ID))
+			ids[i] = id
+		}
+		return team, ids
+	}
+
+	// Last year, Alice and Bob beat Carol and Dave.
+	ctx := t.Context()
+	ab, abIDs := newTeam(ctx, "Alice", "Bob")
+	cd, cdIDs := newTeam(ctx, "Carol", "Dave")
+	gameID, err := gr.Create(ctx, ab.ID, cd.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, gr.UpdateScores(ctx, gameID, ab.ID, 121, cd.ID, 100))
+
+	// This year, they've swapped partners and haven't played yet.
+	thisYear, err := er.Create(ctx, "This Year", time.Now().AddDate(1, 0, 0))
+	assert.NoError(t, err)
+	thisYearCtx := events.WithEvent(ctx, thisYear)
+	_, acIDs := newTeam(thisYearCtx, "Alice", "Carol")
+
+	assert.NoError(t, svc.Recompute(ctx))
+
+	lastYearRatings, err := rr.GetForEvent(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Initial+K/2, lastYearRatings[abIDs[0]].Rating)
+	assert.Equal(t, Initial+K/2, lastYearRatings[abIDs[1]].Rating)
+	assert.Equal(t, Initial-K/2, lastYearRatings[cdIDs[0]].Rating)
+	assert.Equal(t, 1, lastYearRatings[cdIDs[0]].Games)
+
+	// Ratings carry over to the same people's players in the next event.
+	thisYearRatings, err := rr.GetForEvent(thisYearCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, Initial+K/2, thisYearRatings[acIDs[0]].Rating)
+	assert.Equal(t, Initial-K/2, thisYearRatings[acIDs[1]].Rating)
+
+	// And can be copied into the players' own ratings for balancing teams.
+	assert.NoError(t, svc.ApplyToPlayers(thisYearCtx))
+	alice, err := pr.Get(ctx, acIDs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, Initial+K/2, alice.Rating)
+}
@@ -0,0 +1,148 @@
+package results
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/service/tournament"
+)
+
+// Game is a finished game between two teams.
+type Game struct {
+	WinnerID string
+	LoserID  string
+	// WinnerScore and LoserScore are the game's final scores. They're 0 for a bracket game that was
+	// decided without its scores being recorded.
+	WinnerScore int
+	LoserScore  int
+	// Prelim is true for prelim games and false for bracket games.
+	Prelim bool
+}
+
+// Service reads the results of an event's games, prelims and brackets alike.
+type Service struct {
+	gameRepo    games.Repository
+	tournaments tournament.Service
+}
+
+func New(gameRepo games.Repository, tournaments tournament.Service) Service {
+	return Service{
+		gameRepo:    gameRepo,
+		tournaments: tournaments,
+	}
+}
+
+// ForEvent returns the finished games of the event ctx is scoped to, in roughly the order they were
+// played: prelim games by round, then bracket games by round, main bracket first. Each game of a
+// series is returned on its own. Byes and disputed scores aren't games.
+func (s Service) ForEvent(ctx context.Context) ([]Game, error) {
+	scores, err := s.gameRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.gameRepo.GetPendingScores(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scoresByGame := make(map[string][]games.Score)
+	for _, sc := range scores {
+		if p, ok := pending[sc.GameID]; ok && p.Disputed() {
+			continue
+		}
+		scoresByGame[sc.GameID] = append(scoresByGame[sc.GameID], sc)
+	}
+
+	var prelims [][]games.Score
+	for _, ss := range scoresByGame {
+		if len(ss) == 2 && games.GameResult(ss[0].Score, ss[1].Score) != games.ResultUndecided {
+			prelims = append(prelims, ss)
+		}
+	}
+	slices.SortFunc(prelims, func(a, b []games.Score) int {
+		return cmp.Or(cmp.Compare(a[0].Round, b[0].Round), cmp.Compare(a[0].GameID, b[0].GameID))
+	})
+
+	var gs []Game
+	for _, ss := range prelims {
+		winner, loser := ss[0], ss[1]
+		if loser.Score > winner.Score {
+			winner, loser = loser, winner
+		}
+		gs = append(gs, Game{
+			WinnerID:    winner.TeamID,
+			LoserID:     loser.TeamID,
+			WinnerScore: winner.Score,
+			LoserScore:  loser.Score,
+			Prelim:      true,
+		})
+	}
+
+	for _, svc := range []tournament.Service{s.tournaments, s.tournaments.Consolation()} {
+		b, err := svc.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range bracketOrder(b) {
+			gs = append(gs, bracketGames(g)...)
+		}
+	}
+
+	return gs, nil
+}
+
+// bracketOrder returns the games of a bracket in roughly the order they're played: round by round,
+// with each losers-side round after the winners-side round of the same number, then the
+// third-place game and the grand final.
+func bracketOrder(b tournament.Bracket) []tournament.Game {
+	var gs []tournament.Game
+	for i := range max(len(b.Rounds), len(b.Losers)) {
+		if i < len(b.Rounds) {
+			gs = append(gs, b.Rounds[i].Games...)
+		}
+		if i < len(b.Losers) {
+			gs = append(gs, b.Losers[i].Games...)
+		}
+	}
+	if b.ThirdPlace != nil {
+		gs = append(gs, *b.ThirdPlace)
+	}
+	for _, r := range b.Final {
+		gs = append(gs, r.Games...)
+	}
+	return gs
+}
+
+// bracketGames returns the games played in a bracket game: one per game of a series whose scores
+// were recorded, otherwise one for the decided game.
+func bracketGames(g tournament.Game) []Game {
+	if g.Bye || !g.Ready() {
+		return nil
+	}
+
+	team1, team2 := g.Teams[0].ID, g.Teams[1].ID
+	if len(g.Series) > 0 {
+		var gs []Game
+		for _, sg := range g.Series {
+			switch {
+			case sg.Scores[0] >= games.WinningScore:
+				gs = append(gs, Game{WinnerID: team1, LoserID: team2, WinnerScore: sg.Scores[0], LoserScore: sg.Scores[1]})
+			case sg.Scores[1] >= games.WinningScore:
+				gs = append(gs, Game{WinnerID: team2, LoserID: team1, WinnerScore: sg.Scores[1], LoserScore: sg.Scores[0]})
+			}
+		}
+		return gs
+	}
+
+	switch g.Winner.ID {
+	case team1:
+		return []Game{{WinnerID: team1, LoserID: team2}}
+	case team2:
+		return []Game{{WinnerID: team2, LoserID: team1}}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Package schedule builds prelim schedules: which teams play each other, and in what order.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrTooFewTeams = errors.New("need at least two teams to make a schedule")
+	ErrUnevenGames = errors.New("not every team can play that many games")
+)
+
+// Pairing is a game between two teams, given by their indices in the list being scheduled.
+type Pairing [2]int
+
+// Round is a set of games that can all be played at the same time; no team is in more than one.
+type Round struct {
+	Games []Pairing
+	// Bye is the team that sits the round out, or -1 if every team plays.
+	Bye int
+}
+
+// RoundRobin schedules n teams using the circle method. Rounds are in the order they should be
+// played.
+//
+// If gamesPerTeam is zero, every team plays every other team once. Otherwise the round robin is
+// repeated (with rematches) or cut short so every team plays exactly gamesPerTeam games. With an odd
+// number of teams one team sits out each round, so only multiples of n-1 games can be played
+// evenly; anything else returns ErrUnevenGames.
+func RoundRobin(n, gamesPerTeam int) ([]Round, error) {
+	if n < 2 {
+		return nil, ErrTooFewTeams
+	}
+	if gamesPerTeam < 0 {
+		return nil, fmt.Errorf("games per team must not be negative, got %d", gamesPerTeam)
+	}
+	if gamesPerTeam == 0 {
+		gamesPerTeam = n - 1
+	}
+
+	// With an odd number of teams we add a phantom team; whoever plays it has a bye.
+	slots := n + n%2
+	perCycle := slots - 1
+
+	numRounds := gamesPerTeam
+	if n%2 == 1 {
+		if gamesPerTeam%(n-1) != 0 {
+			return nil, fmt.Errorf("%w: %d teams can only play a multiple of %d games each", ErrUnevenGames, n, n-1)
+		}
+		// Each team sits out once per cycle of n rounds.
+		numRounds = gamesPerTeam / (n - 1) * perCycle
+	}
+
+	rounds := make([]Round, 0, numRounds)
+	for r := range numRounds {
+		rounds = append(rounds, circleRound(n, slots, r%perCycle))
+	}
+	return rounds, nil
+}
+
+// circleRound returns round r of the circle method. Imagine the slots sitting in a circle: slot 0
+// stays fixed and the rest rotate one position each round. Teams are paired "across" the circle:
+// first with last, second with second-last, and so on. After slots-1 rounds every pair has met once.
+func circleRound(n, slots, r int) Round {
+	others := slots - 1
+	order := make([]int, slots)
+	for i := range others {
+		order[i+1] = 1 + ((i-r)%others+others)%others
+	}
+
+	round := Round{Bye: -1}
+	for i := range slots / 2 {
+		a, b := order[i], order[slots-1-i]
+		switch {
+		case a >= n:
+			round.Bye = b
+		case b >= n:
+			round.Bye = a
+		default:
+			round.Games = append(round.Games, Pairing{a, b})
+		}
+	}
+	return round
+}
+
+// CrossoverRule decides which team of one division plays which team of its paired division in the
+// crossover phase. Both divisions' teams are ranked by their standing.
+type CrossoverRule string
+
+const (
+	// CrossoverByStanding matches teams of the same standing: first plays first, second plays
+	// second, and so on.
+	CrossoverByStanding CrossoverRule = "by-standing"
+	// CrossoverFolded matches the top of one division with the bottom of the other: first plays
+	// last, second plays second-last, and so on.
+	CrossoverFolded CrossoverRule = "folded"
+)
+
+var CrossoverRules = []CrossoverRule{CrossoverByStanding, CrossoverFolded}
+
+func (r CrossoverRule) String() string {
+	switch r {
+	case CrossoverByStanding:
+		return "Same standing"
+	case CrossoverFolded:
+		return "Top vs. bottom"
+	default:
+		return string(r)
+	}
+}
+
+// Crossover schedules the crossover games between two divisions of n teams each, ranked by their
+// standing. In each Pairing the first index is a team of the first division and the second index a
+// team of the second. The first round follows rule; each later round shifts every team to the
+// next-ranked opponent, so nobody gets a rematch until they've played the whole other division.
+func Crossover(n, gamesPerTeam int, rule CrossoverRule) ([]Round, error) {
+	if n < 1 {
+		return nil, ErrTooFewTeams
+	}
+	if gamesPerTeam < 1 {
+		return nil, fmt.Errorf("games per team must be positive, got %d", gamesPerTeam)
+	}
+
+	var first func(i int) int
+	switch rule {
+	case CrossoverByStanding:
+		first = func(i int) int { return i }
+	case CrossoverFolded:
+		first = func(i int) int { return n - 1 - i }
+	default:
+		return nil, fmt.Errorf("unknown crossover rule %q", rule)
+	}
+
+	rounds := make([]Round, 0, gamesPerTeam)
+	for r := range gamesPerTeam {
+		round := Round{Bye: -1}
+		for i := range n {
+			round.Games = append(round.Games, Pairing{i, (first(i) + r) % n})
+		}
+		rounds = append(rounds, round)
+	}
+	return rounds, nil
+}
+
+var ErrNotEnoughTables = errors.New("not enough tables")
+
+// Table is somewhere a game can be played.
+type Table struct {
+	ID string
+	// Cluster is the group of tables this one belongs to, or empty if it isn't in one.
+	Cluster string
+}
+
+// AssignTables picks a table for each game of a round so no table is used twice. clusters[i] is the
+// cluster game i has to be played in, or empty if it can be played anywhere. It returns the ID of
+// each game's table, in the same order as the games.
+//
+// Games pinned to a cluster are seated first. The rest go to tables outside of any cluster first,
+// and only spill into clustered tables when those run out.
+func AssignTables(clusters []string, tables []Table) ([]string, error) {
+	used := make([]bool, len(tables))
+	take := func(ok func(Table) bool) (string, bool) {
+		for i, t := range tables {
+			if !used[i] && ok(t) {
+				used[i] = true
+				return t.ID, true
+			}
+		}
+		return "", false
+	}
+
+	res := make([]string, len(clusters))
+	for i, c := range clusters {
+		if c == "" {
+			continue
+		}
+		id, ok := take(func(t Table) bool { return t.Cluster == c })
+		if !ok {
+			return nil, fmt.Errorf("%w in cluster %q", ErrNotEnoughTables, c)
+		}
+		res[i] = id
+	}
+
+	for i, c := range clusters {
+		if c != "" {
+			continue
+		}
+		id, ok := take(func(t Table) bool { return t.Cluster == "" })
+		if !ok {
+			id, ok = take(func(Table) bool { return true })
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %d games but %d tables", ErrNotEnoughTables, len(clusters), len(tables))
+		}
+		res[i] = id
+	}
+
+	return res, nil
+}
@@ -0,0 +1,216 @@
+package schedule
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+)
+
+func TestRoundRobin_EveryPairMeetsOnce(t *testing.T) {
+	for n := 2; n <= 12; n++ {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			rounds, err := RoundRobin(n, 0)
+			assert.NoError(t, err)
+
+			if n%2 == 0 {
+				assert.SliceLen(t, rounds, n-1)
+			} else {
+				assert.SliceLen(t, rounds, n)
+			}
+
+			met := make(map[Pairing]int)
+			for _, r := range rounds {
+				assertValidRound(t, n, r)
+				for _, g := range r.Games {
+					met[Pairing{min(g[0], g[1]), max(g[0], g[1])}]++
+				}
+			}
+			assert.MapLen(t, met, n*(n-1)/2)
+			for p, count := range met {
+				if count != 1 {
+					t.Errorf("pair %v met %d times", p, count)
+				}
+			}
+		})
+	}
+}
+
+func TestRoundRobin_OddTeamsGetOneByeEach(t *testing.T) {
+	rounds, err := RoundRobin(5, 0)
+	assert.NoError(t, err)
+
+	byes := make(map[int]int)
+	for _, r := range rounds {
+		assert.SliceLen(t, r.Games, 2)
+		byes[r.Bye]++
+	}
+	assert.Equal(t, map[int]int{0: 1, 1: 1, 2: 1, 3: 1, 4: 1}, byes)
+}
+
+func TestRoundRobin_GamesPerTeam(t *testing.T) {
+	tests := []struct {
+		n, gamesPerTeam, rounds int
+	}{
+		{n: 6, gamesPerTeam: 3, rounds: 3},
+		{n: 4, gamesPerTeam: 5, rounds: 5},
+		{n: 3, gamesPerTeam: 4, rounds: 6},
+		{n: 5, gamesPerTeam: 8, rounds: 10},
+		{n: 2, gamesPerTeam: 3, rounds: 3},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%d teams %d games", tc.n, tc.gamesPerTeam), func(t *testing.T) {
+			rounds, err := RoundRobin(tc.n, tc.gamesPerTeam)
+			assert.NoError(t, err)
+			assert.SliceLen(t, rounds, tc.rounds)
+
+			played := make(map[int]int)
+			for _, r := range rounds {
+				assertValidRound(t, tc.n, r)
+				for _, g := range r.Games {
+					played[g[0]]++
+					played[g[1]]++
+				}
+			}
+			for i := range tc.n {
+				if played[i] != tc.gamesPerTeam {
+					t.Errorf("team %d: expected %d games, got %d", i, tc.gamesPerTeam, played[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoundRobin_ShortScheduleHasNoRematches(t *testing.T) {
+	rounds, err := RoundRobin(8, 4)
+	assert.NoError(t, err)
+
+	met := make(map[Pairing]bool)
+	for _, r := range rounds {
+		for _, g := range r.Games {
+			p := Pairing{min(g[0], g[1]), max(g[0], g[1])}
+			if met[p] {
+				t.Errorf("rematch %v", p)
+			}
+			met[p] = true
+		}
+	}
+}
+
+func TestRoundRobin_Errors(t *testing.T) {
+	_, err := RoundRobin(1, 0)
+	assert.ErrorIs(t, err, ErrTooFewTeams)
+
+	_, err = RoundRobin(5, 3)
+	assert.ErrorIs(t, err, ErrUnevenGames)
+
+	_, err = RoundRobin(4, -1)
+	assert.Error(t, err)
+}
+
+// assertValidRound checks that no team plays twice in the round and the bye team doesn't play.
+func assertValidRound(t *testing.T, n int, r Round) {
+	t.Helper()
+	seen := make(map[int]bool)
+	for _, g := range r.Games {
+		for _, team := range g {
+			if team < 0 || team >= n {
+				t.Fatalf("team %d out of range", team)
+			}
+			if seen[team] {
+				t.Fatalf("team %d plays twice in a round", team)
+			}
+			seen[team] = true
+		}
+	}
+	if n%2 == 1 {
+		if r.Bye < 0 || seen[r.Bye] {
+			t.Fatalf("bad bye %d", r.Bye)
+		}
+	} else {
+		assert.Equal(t, r.Bye, -1)
+	}
+}
+
+func TestAssignTables(t *testing.T) {
+	tables := []Table{
+		{ID: "1", Cluster: "Upstairs"},
+		{ID: "2", Cluster: "Upstairs"},
+		{ID: "3"},
+		{ID: "4"},
+	}
+
+	ids, err := AssignTables([]string{"", "Upstairs", "", "Upstairs"}, tables)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3", "1", "4", "2"}, ids)
+
+	// Unpinned games spill over into clustered tables once the others are taken.
+	ids, err = AssignTables([]string{"", "", "Upstairs"}, tables)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3", "4", "1"}, ids)
+}
+
+func TestAssignTables_NotEnough(t *testing.T) {
+	tables := []Table{{ID: "1", Cluster: "Upstairs"}, {ID: "2"}}
+
+	_, err := AssignTables([]string{"Upstairs", "Upstairs"}, tables)
+	assert.ErrorIs(t, err, ErrNotEnoughTables)
+
+	_, err = AssignTables([]string{"", "", ""}, tables)
+	assert.ErrorIs(t, err, ErrNotEnoughTables)
+
+	_, err = AssignTables([]string{"Downstairs"}, tables)
+	assert.ErrorIs(t, err, ErrNotEnoughTables)
+}
+
+func TestCrossover(t *testing.T) {
+	rounds, err := Crossover(4, 2, CrossoverByStanding)
+	assert.NoError(t, err)
+	assert.Equal(t, []Round{{
+		Games: []Pairing{{0, 0}, {1, 1}, {2, 2}, {3, 3}},
+		Bye:   -1,
+	}, {
+		Games: []Pairing{{0, 1}, {1, 2}, {2, 3}, {3, 0}},
+		Bye:   -1,
+	}}, rounds)
+
+	rounds, err = Crossover(4, 1, CrossoverFolded)
+	assert.NoError(t, err)
+	assert.Equal(t, []Round{{
+		Games: []Pairing{{0, 3}, {1, 2}, {2, 1}, {3, 0}},
+		Bye:   -1,
+	}}, rounds)
+}
+
+func TestCrossover_NoRematchesUntilEveryoneMet(t *testing.T) {
+	for _, rule := range CrossoverRules {
+		t.Run(string(rule), func(t *testing.T) {
+			rounds, err := Crossover(5, 5, rule)
+			assert.NoError(t, err)
+
+			met := make(map[Pairing]int)
+			for _, r := range rounds {
+				opponents := make(map[int]bool)
+				for _, g := range r.Games {
+					met[g]++
+					if opponents[g[1]] {
+						t.Errorf("team %d of the second division plays twice in a round", g[1])
+					}
+					opponents[g[1]] = true
+				}
+			}
+			assert.MapLen(t, met, 25)
+		})
+	}
+}
+
+func TestCrossover_Invalid(t *testing.T) {
+	_, err := Crossover(0, 1, CrossoverByStanding)
+	assert.ErrorIs(t, err, ErrTooFewTeams)
+
+	_, err = Crossover(4, 0, CrossoverByStanding)
+	assert.Error(t, err)
+
+	_, err = Crossover(4, 1, "sideways")
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package schedule
+
+// maxRematchSearchSteps caps how many partial rounds the rematch-free search tries. Past it
+// there probably is no such round, so teams are paired with their neighbours instead.
+const maxRematchSearchSteps = 100_000
+
+// Swiss pairs the next round of a Swiss prelim for n teams, given in the order of the standings
+// (best first). played reports whether two teams have already played each other, and hadBye
+// whether a team has already sat out a round.
+//
+// With an odd number of teams, the lowest-ranked team that hasn't had a bye sits this round out (or
+// the lowest-ranked team, if they all have). The rest are paired from the top down, each with the
+// closest team in the standings that it hasn't played yet. If there's no way to pair everyone
+// without a rematch, or finding one takes too long, teams are paired with their neighbour in the
+// standings instead.
+func Swiss(n int, played func(a, b int) bool, hadBye func(i int) bool) (Round, error) {
+	if n < 2 {
+		return Round{}, ErrTooFewTeams
+	}
+
+	round := Round{Bye: -1}
+	if n%2 == 1 {
+		round.Bye = n - 1
+		for i := n - 1; i >= 0; i-- {
+			if !hadBye(i) {
+				round.Bye = i
+				break
+			}
+		}
+	}
+
+	var teams []int
+	for i := range n {
+		if i != round.Bye {
+			teams = append(teams, i)
+		}
+	}
+
+	steps := 0
+	games, ok := pairWithoutRematches(teams, played, &steps)
+	if !ok {
+		games = nil
+		for i := 0; i < len(teams); i += 2 {
+			games = append(games, Pairing{teams[i], teams[i+1]})
+		}
+	}
+	round.Games = games
+	return round, nil
+}
+
+// pairWithoutRematches pairs the first team with the highest-ranked team it hasn't played, then
+// does the same for the rest, backtracking when the teams left over can't all be paired. It gives
+// up once it has tried more than maxRematchSearchSteps pairings.
+func pairWithoutRematches(teams []int, played func(a, b int) bool, steps *int) ([]Pairing, bool) {
+	if len(teams) == 0 {
+		return nil, true
+	}
+
+	first := teams[0]
+	for i := 1; i < len(teams); i++ {
+		if played(first, teams[i]) {
+			continue
+		}
+
+		*steps++
+		if *steps > maxRematchSearchSteps {
+			return nil, false
+		}
+
+		rest := make([]int, 0, len(teams)-2)
+		rest = append(rest, teams[1:i]...)
+		rest = append(rest, teams[i+1:]...)
+
+		games, ok := pairWithoutRematches(rest, played, steps)
+		if ok {
+			return append([]Pairing{{first, teams[i]}}, games...), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+)
+
+func TestSwiss_FirstRound(t *testing.T) {
+	never := func(int, int) bool { return false }
+	noByes := func(int) bool { return false }
+
+	round, err := Swiss(4, never, noByes)
+	assert.NoError(t, err)
+	assert.Equal(t, Round{Games: []Pairing{{0, 1}, {2, 3}}, Bye: -1}, round)
+
+	// The lowest-ranked team sits out.
+	round, err = Swiss(5, never, noByes)
+	assert.NoError(t, err)
+	assert.Equal(t, Round{Games: []Pairing{{0, 1}, {2, 3}}, Bye: 4}, round)
+}
+
+func TestSwiss_AvoidsRematches(t *testing.T) {
+	played := pairSet(Pairing{0, 1}, Pairing{2, 3})
+
+	round, err := Swiss(4, played, func(int) bool { return false })
+	assert.NoError(t, err)
+	assert.Equal(t, Round{Games: []Pairing{{0, 2}, {1, 3}}, Bye: -1}, round)
+}
+
+func TestSwiss_Backtracks(t *testing.T) {
+	// 0 would take 2, but then 1 and 3 have already played; 0 has to take 3 instead.
+	played := pairSet(Pairing{0, 1}, Pairing{1, 3})
+
+	round, err := Swiss(4, played, func(int) bool { return false })
+	assert.NoError(t, err)
+	assert.Equal(t, Round{Games: []Pairing{{0, 3}, {1, 2}}, Bye: -1}, round)
+}
+
+func TestSwiss_RematchesWhenUnavoidable(t *testing.T) {
+	played := pairSet(Pairing{0, 1}, Pairing{0, 2}, Pairing{0, 3})
+
+	round, err := Swiss(4, played, func(int) bool { return false })
+	assert.NoError(t, err)
+	assert.Equal(t, Round{Games: []Pairing{{0, 1}, {2, 3}}, Bye: -1}, round)
+}
+
+func TestSwiss_GivesUpOnHopelessSearches(t *testing.T) {
+	// The last team has played everyone, so there's no round without a rematch, but there are
+	// far too many ways to pair the others to try them all before finding that out.
+	const n = 40
+	played := func(a, b int) bool { return a == n-1 || b == n-1 }
+
+	round, err := Swiss(n, played, func(int) bool { return false })
+	assert.NoError(t, err)
+	assert.SliceLen(t, round.Games, n/2)
+	for i, g := range round.Games {
+		assert.Equal(t, Pairing{2 * i, 2*i + 1}, g)
+	}
+}
+
+func TestSwiss_ByeGoesToSomeoneWithoutOne(t *testing.T) {
+	round, err := Swiss(5, pairSet(), func(i int) bool { return i >= 3 })
+	assert.NoError(t, err)
+	assert.Equal(t, 2, round.Bye)
+	assert.Equal(t, []Pairing{{0, 1}, {3, 4}}, round.Games)
+
+	// Once everybody has had one, the lowest-ranked team gets another.
+	round, err = Swiss(5, pairSet(), func(int) bool { return true })
+	assert.NoError(t, err)
+	assert.Equal(t, 4, round.Bye)
+}
+
+func TestSwiss_TooFewTeams(t *testing.T) {
+	_, err := Swiss(1, pairSet(), func(int) bool { return false })
+	assert.ErrorIs(t, err, ErrTooFewTeams)
+}
+
+func pairSet(ps ...Pairing) func(a, b int) bool {
+	set := make(map[Pairing]bool, len(ps))
+	for _, p := range ps {
+		set[p] = true
+		set[Pairing{p[1], p[0]}] = true
+	}
+	return func(a, b int) bool { return set[Pairing{a, b}] }
+}
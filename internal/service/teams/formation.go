@@ -0,0 +1,230 @@
+package teams
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+)
+
+// Proposal is a set of teams proposed for the free agents.
+type Proposal struct {
+	Teams [][2]players.Player
+	// Unpaired are free agents that couldn't be put on a team, like the odd one out when there's an
+	// odd number of them.
+	Unpaired []players.Player
+	// Notes explain partner requests that couldn't be honored and why anyone was left unpaired.
+	Notes []string
+}
+
+// maxConflictSearchSteps bounds the search for a pairing that avoids every conflict. It's only reached
+// when there are so many conflicts that there probably isn't one.
+const maxConflictSearchSteps = 100_000
+
+// ProposeTeams pairs up the free agents among ps (the players without a team).
+//
+// Players who asked to play with each other are put together first, unless they're listed as a
+// conflict. Everyone else is paired so that no two conflicting players share a team. When balance
+// is set, the strongest remaining player is paired with the weakest, the second strongest with the
+// second weakest, and so on, so teams' combined ratings are as even as possible; otherwise players
+// are paired at random.
+func ProposeTeams(
+	ps []players.Player,
+	requests map[string]string,
+	conflicts [][2]string,
+	balance bool,
+	rng *rand.Rand,
+) Proposal {
+	byID := make(map[string]players.Player, len(ps))
+	var free []players.Player
+	for _, p := range ps {
+		byID[p.ID] = p
+		if p.TeamID == "" {
+			free = append(free, p)
+		}
+	}
+	slices.SortFunc(free, func(a, b players.Player) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+
+	conflicting := make(map[[2]string]bool, len(conflicts))
+	for _, c := range conflicts {
+		conflicting[[2]string{c[0], c[1]}] = true
+		conflicting[[2]string{c[1], c[0]}] = true
+	}
+	canPair := func(a, b players.Player) bool {
+		return !conflicting[[2]string{a.ID, b.ID}]
+	}
+
+	var proposal Proposal
+	paired := make(map[string]bool, len(free))
+	for _, p := range free {
+		partnerID, ok := requests[p.ID]
+		if !ok || paired[p.ID] {
+			continue
+		}
+
+		partner, ok := byID[partnerID]
+		switch {
+		case !ok:
+			proposal.note("%s asked to play with a player who isn't registered.", p.Name())
+		case partner.TeamID != "":
+			proposal.note("%s asked to play with %s, who is already on a team.", p.Name(), partner.Name())
+		case requests[partnerID] == "":
+			proposal.note("%s asked to play with %s, who didn't ask for a partner.", p.Name(), partner.Name())
+		case requests[partnerID] != p.ID:
+			proposal.note(
+				"%s asked to play with %s, who asked to play with %s.",
+				p.Name(), partner.Name(), byID[requests[partnerID]].Name(),
+			)
+		case !canPair(p, partner):
+			if cmp.Or(cmp.Compare(p.Name(), partner.Name()), cmp.Compare(p.ID, partner.ID)) > 0 {
+				// Already noted when we came to their partner.
+				continue
+			}
+			proposal.note("%s and %s asked to play together, but they're listed as a conflict.", p.Name(), partner.Name())
+		default:
+			proposal.Teams = append(proposal.Teams, [2]players.Player{p, partner})
+			paired[p.ID] = true
+			paired[partner.ID] = true
+		}
+	}
+
+	var rest []players.Player
+	for _, p := range free {
+		if !paired[p.ID] {
+			rest = append(rest, p)
+		}
+	}
+	rng.Shuffle(len(rest), func(i, j int) {
+		rest[i], rest[j] = rest[j], rest[i]
+	})
+	if balance {
+		slices.SortStableFunc(rest, func(a, b players.Player) int {
+			return cmp.Compare(b.Rating, a.Rating)
+		})
+	}
+
+	if len(rest)%2 == 1 {
+		// Leave out the last player, or the middle one when balancing so everyone else still pairs
+		// strong with weak.
+		i := len(rest) - 1
+		if balance {
+			i = len(rest) / 2
+		}
+		proposal.Unpaired = append(proposal.Unpaired, rest[i])
+		proposal.note("%s is left without a partner; there's an odd number of free agents.", rest[i].Name())
+		rest = slices.Delete(rest, i, i+1)
+	}
+
+	steps := 0
+	teams, ok := pairUp(rest, balance, canPair, &steps)
+	if !ok {
+		// There's no way to avoid every conflict, so pair who we can and leave the rest.
+		teams = nil
+		for len(rest) > 0 {
+			first := rest[0]
+			i := slices.IndexFunc(rest[1:], func(p players.Player) bool { return canPair(first, p) })
+			if i < 0 {
+				proposal.Unpaired = append(proposal.Unpaired, first)
+				proposal.note("%s can't be paired with anyone left without a conflict.", first.Name())
+				rest = rest[1:]
+				continue
+			}
+			teams = append(teams, [2]players.Player{first, rest[i+1]})
+			rest = slices.Delete(rest, i+1, i+2)[1:]
+		}
+	}
+	proposal.Teams = append(proposal.Teams, teams...)
+
+	return proposal
+}
+
+func (p *Proposal) note(format string, args ...any) {
+	p.Notes = append(p.Notes, fmt.Sprintf(format, args...))
+}
+
+// pairUp pairs the first player with the first partner it can play with — the weakest when
+// balancing, otherwise the next in line — then does the same for the rest, backtracking when the
+// players left over can't all be paired.
+func pairUp(
+	ps []players.Player,
+	balance bool,
+	canPair func(a, b players.Player) bool,
+	steps *int,
+) ([][2]players.Player, bool) {
+	if len(ps) == 0 {
+		return nil, true
+	}
+
+	first := ps[0]
+	for j := 1; j < len(ps); j++ {
+		i := j
+		if balance {
+			i = len(ps) - j
+		}
+		if !canPair(first, ps[i]) {
+			continue
+		}
+
+		*steps++
+		if *steps > maxConflictSearchSteps {
+			return nil, false
+		}
+
+		rest := make([]players.Player, 0, len(ps)-2)
+		rest = append(rest, ps[1:i]...)
+		rest = append(rest, ps[i+1:]...)
+
+		teams, ok := pairUp(rest, balance, canPair, steps)
+		if ok {
+			return append([][2]players.Player{{first, ps[i]}}, teams...), true
+		}
+	}
+	return nil, false
+}
+
+// Propose proposes teams for the current event's free agents from their partner requests and
+// conflicts. Nothing is saved; pass the teams to CreateTeams to do that.
+func (s Service) Propose(ctx context.Context, balance bool) (Proposal, error) {
+	ps, err := s.playerRepo.GetAll(ctx)
+	if err != nil {
+		return Proposal{}, err
+	}
+
+	requests, err := s.playerRepo.GetPartnerRequests(ctx)
+	if err != nil {
+		return Proposal{}, err
+	}
+
+	conflicts, err := s.playerRepo.GetConflicts(ctx)
+	if err != nil {
+		return Proposal{}, err
+	}
+
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	return ProposeTeams(ps, requests, conflicts, balance, rng), nil
+}
+
+// CreateTeams creates a team for each pair of player IDs, all or nothing.
+func (s Service) CreateTeams(ctx context.Context, pairs [][2]string) error {
+	return s.txer.WithTx(ctx, func(ctx context.Context) error {
+		for _, pair := range pairs {
+			team, err := s.CreateTeam(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, playerID := range pair {
+				_, err := s.AssignPlayerToTeam(ctx, playerID, team.ID)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
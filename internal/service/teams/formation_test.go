@@ -0,0 +1,128 @@
+package teams
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+)
+
+// ratedPlayers makes free agents named and identified by their ratings.
+func ratedPlayers(ratings ...int) []players.Player {
+	ps := make([]players.Player, 0, len(ratings))
+	for _, r := range ratings {
+		ps = append(ps, players.Player{
+			ID:        fmt.Sprint(r),
+			FirstName: "P",
+			LastName:  fmt.Sprint(r),
+			Rating:    r,
+		})
+	}
+	return ps
+}
+
+func teamIDs(p Proposal) [][2]string {
+	res := make([][2]string, 0, len(p.Teams))
+	for _, t := range p.Teams {
+		res = append(res, [2]string{t[0].ID, t[1].ID})
+	}
+	return res
+}
+
+func newRand() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+func TestProposeTeams_Balances(t *testing.T) {
+	p := ProposeTeams(ratedPlayers(1, 2, 3, 4, 5, 6), nil, nil, true, newRand())
+	assert.Equal(t, [][2]string{{"6", "1"}, {"5", "2"}, {"4", "3"}}, teamIDs(p))
+	assert.SliceLen(t, p.Unpaired, 0)
+	assert.SliceLen(t, p.Notes, 0)
+}
+
+func TestProposeTeams_OddOneOut(t *testing.T) {
+	p := ProposeTeams(ratedPlayers(1, 2, 3, 4, 5), nil, nil, true, newRand())
+	assert.Equal(t, [][2]string{{"5", "1"}, {"4", "2"}}, teamIDs(p))
+	assert.SliceLen(t, p.Unpaired, 1)
+	assert.Equal(t, "3", p.Unpaired[0].ID)
+	assert.SliceLen(t, p.Notes, 1)
+}
+
+func TestProposeTeams_MutualRequests(t *testing.T) {
+	requests := map[string]string{"6": "5", "5": "6"}
+	p := ProposeTeams(ratedPlayers(1, 2, 3, 4, 5, 6), requests, nil, true, newRand())
+	assert.Equal(t, [][2]string{{"5", "6"}, {"4", "1"}, {"3", "2"}}, teamIDs(p))
+	assert.SliceLen(t, p.Notes, 0)
+}
+
+func TestProposeTeams_ExplainsUnhonoredRequests(t *testing.T) {
+	ps := ratedPlayers(1, 2, 3, 4)
+	ps = append(ps, players.Player{ID: "taken", FirstName: "T", LastName: "Taken", TeamID: "team"})
+	requests := map[string]string{
+		"1": "2",     // 2 didn't ask for anyone
+		"3": "4",     // 4 asked for someone else
+		"4": "1",     //
+		"2": "taken", // already on a team
+	}
+
+	p := ProposeTeams(ps, requests, nil, false, newRand())
+	assert.SliceLen(t, p.Teams, 2)
+	assert.Equal(t, []string{
+		"P 1 asked to play with P 2, who asked to play with T Taken.",
+		"P 2 asked to play with T Taken, who is already on a team.",
+		"P 3 asked to play with P 4, who asked to play with P 1.",
+		"P 4 asked to play with P 1, who asked to play with P 2.",
+	}, p.Notes)
+}
+
+func TestProposeTeams_AvoidsConflicts(t *testing.T) {
+	// Balancing would pair 4 with 1, but they conflict.
+	p := ProposeTeams(ratedPlayers(1, 2, 3, 4), nil, [][2]string{{"1", "4"}}, true, newRand())
+	assert.Equal(t, [][2]string{{"4", "2"}, {"3", "1"}}, teamIDs(p))
+
+	// A mutual request between conflicting players isn't honored.
+	requests := map[string]string{"1": "4", "4": "1"}
+	p = ProposeTeams(ratedPlayers(1, 2, 3, 4), requests, [][2]string{{"1", "4"}}, true, newRand())
+	assert.Equal(t, [][2]string{{"4", "2"}, {"3", "1"}}, teamIDs(p))
+	assert.Equal(t, []string{"P 1 and P 4 asked to play together, but they're listed as a conflict."}, p.Notes)
+}
+
+func TestProposeTeams_UnavoidableConflicts(t *testing.T) {
+	p := ProposeTeams(ratedPlayers(1, 2), nil, [][2]string{{"1", "2"}}, false, newRand())
+	assert.SliceLen(t, p.Teams, 0)
+	assert.SliceLen(t, p.Unpaired, 2)
+	assert.SliceLen(t, p.Notes, 2)
+}
+
+func TestCreateTeams(t *testing.T) {
+	svc, pr, tr := newService(t)
+	ctx := t.Context()
+
+	var ids []string
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		id, err := pr.Create(ctx, name, "Smith")
+		assert.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	assert.NoError(t, svc.CreateTeams(ctx, [][2]string{{ids[0], ids[1]}, {ids[2], ids[3]}}))
+
+	ts, err := tr.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, ts, 2)
+
+	free, err := pr.GetFreeAgents(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, free, 0)
+
+	// Nothing is created if any player is already on a team.
+	err = svc.CreateTeams(ctx, [][2]string{{ids[0], ids[1]}})
+	assert.ErrorIs(t, err, players.ErrPlayerAlreadyOnATeam)
+
+	ts, err = tr.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, ts, 2)
+}
@@ -18,9 +18,6 @@ func newService(t *testing.T) (Service, players.Repository, teams.Repository) {
 	pr := players.NewRepository(db)
 	tr := teams.NewRepository(db)
 
-	assert.NoError(t, pr.Init(t.Context()))
-	assert.NoError(t, tr.Init(t.Context()))
-
 	return New(txer, pr, tr), pr, tr
 }
 
@@ -0,0 +1,168 @@
+package tournament
+
+import (
+	"context"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+)
+
+// SeedDoubleElimination creates a double-elimination bracket of the given size from the prelim
+// standings. The winners side is seeded like Seed; the losers side starts out empty. The size
+// must be a power of two (at least four) because there are no byes in double elimination.
+func (s Service) SeedDoubleElimination(ctx context.Context, size int) error {
+	if !games.IsDoubleEliminationSize(size) {
+		return ErrInvalidDoubleEliminationSize
+	}
+
+	err := s.txer.WithTx(ctx, func(ctx context.Context) error {
+		standings, err := s.seedingStandings(ctx, size)
+		if err != nil {
+			return err
+		}
+
+		err = s.gameRepo.InitializeDoubleElimination(ctx, size)
+		if err != nil {
+			return err
+		}
+
+		order := seedOrder(size)
+		for i, seed := range order {
+			err := s.gameRepo.PutTeamIntoDoubleEliminationGame(
+				ctx, SideWinners, 0, i/2, i%2, standings[seed].TeamID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Reseeded: true})
+}
+
+func (b Bracket) doubleEliminationChampion() Team {
+	if len(b.Final) != 2 {
+		return Team{}
+	}
+	final, reset := b.Final[0].Games[0], b.Final[1].Games[0]
+	if reset.Decided() {
+		return reset.Winner
+	}
+	// The team from the winners side only needs to win the grand final once.
+	if final.Decided() && final.Winner == final.Teams[0] {
+		return final.Winner
+	}
+	return Team{}
+}
+
+// slot is a team's place in a double-elimination game.
+type slot struct {
+	side  Side
+	round int
+	idx   int
+	// pos is 0 for the first team and 1 for the second.
+	pos int
+}
+
+// move is a team going into a slot as the result of a game.
+type move struct {
+	to     slot
+	teamID string
+}
+
+// moves returns where the teams of g go when winnerID wins it.
+//
+// Winners move up their side. The winner of the winners side and the winner of the losers side
+// meet in the grand final. First-round losers pair up in the first losers round; losers of later
+// winners rounds drop into every other losers round to face a team that's already lost once,
+// in reverse order so that they don't meet the team that just beat them.
+func (b Bracket) moves(g Game, winnerID string) []move {
+	loserID := g.Teams[0].ID
+	if loserID == winnerID {
+		loserID = g.Teams[1].ID
+	}
+
+	switch g.Side {
+	case SideWinners:
+		var res []move
+		if g.Round+1 < len(b.Rounds) {
+			res = append(res, move{slot{SideWinners, g.Round + 1, g.Idx / 2, g.Idx % 2}, winnerID})
+		} else {
+			res = append(res, move{slot{SideFinal, 0, 0, 0}, winnerID})
+		}
+
+		if g.Round == 0 {
+			res = append(res, move{slot{SideLosers, 0, g.Idx / 2, g.Idx % 2}, loserID})
+		} else {
+			n := len(b.Rounds[g.Round].Games)
+			res = append(res, move{slot{SideLosers, 2*g.Round - 1, n - 1 - g.Idx, 1}, loserID})
+		}
+		return res
+
+	case SideLosers:
+		switch {
+		case g.Round+1 == len(b.Losers):
+			return []move{{slot{SideFinal, 0, 0, 1}, winnerID}}
+		case g.Round%2 == 0:
+			// The next round has the same number of games; the other slot is for a team
+			// dropping in from the winners side.
+			return []move{{slot{SideLosers, g.Round + 1, g.Idx, 0}, winnerID}}
+		default:
+			return []move{{slot{SideLosers, g.Round + 1, g.Idx / 2, g.Idx % 2}, winnerID}}
+		}
+
+	case SideFinal:
+		// If the team from the losers side wins the grand final, both teams have lost once and
+		// they play again.
+		if g.Round == 0 && winnerID == g.Teams[1].ID {
+			return []move{
+				{slot{SideFinal, 1, 0, 0}, g.Teams[0].ID},
+				{slot{SideFinal, 1, 0, 1}, g.Teams[1].ID},
+			}
+		}
+	}
+	return nil
+}
+
+func (s Service) advanceDoubleElimination(ctx context.Context, b Bracket, g Game, teamID string) error {
+	err := s.gameRepo.SetDoubleEliminationWinner(ctx, g.Side, g.Round, g.Idx, teamID)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range b.moves(g, teamID) {
+		err := s.gameRepo.PutTeamIntoDoubleEliminationGame(
+			ctx, m.to.side, m.to.round, m.to.idx, m.to.pos, m.teamID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Service) revertDoubleElimination(ctx context.Context, b Bracket, g Game) error {
+	ms := b.moves(g, g.Winner.ID)
+	for _, m := range ms {
+		next, ok := b.Game(m.to.side, m.to.round, m.to.idx)
+		if ok && next.started() {
+			return ErrNotFurthestGame
+		}
+	}
+
+	err := s.gameRepo.ClearDoubleEliminationWinner(ctx, g.Side, g.Round, g.Idx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range ms {
+		err := s.gameRepo.ClearTeamFromDoubleEliminationGame(ctx, m.to.side, m.to.round, m.to.idx, m.teamID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+)
+
+func TestSeedDoubleElimination(t *testing.T) {
+	svc, ts := newTournamentService(t, 8)
+	ctx := t.Context()
+
+	assert.ErrorIs(t, svc.SeedDoubleElimination(ctx, 6), ErrInvalidDoubleEliminationSize)
+	assert.ErrorIs(t, svc.SeedDoubleElimination(ctx, 16), ErrNotEnoughTeams)
+	assert.NoError(t, svc.SeedDoubleElimination(ctx, 8))
+	assert.ErrorIs(t, svc.Seed(ctx, 8, false), ErrAlreadySeeded)
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, FormatDoubleElimination, b.Format)
+	assert.SliceLen(t, b.Rounds, 3)
+	assert.SliceLen(t, b.Losers, 4)
+	assert.SliceLen(t, b.Final, 2)
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {ts[7].ID, "team7"}}, b.Rounds[0].Games[0].Teams)
+
+	// The first-round losers pair up; the second-round losers drop in from the other end.
+	for i := range 4 {
+		g := b.Rounds[0].Games[i]
+		assert.NoError(t, svc.Advance(ctx, SideWinners, 0, i, g.Teams[0].ID))
+	}
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	g := b.Rounds[1].Games[0]
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 1, 0, g.Teams[0].ID))
+
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	first := b.Rounds[0].Games
+	assert.Equal(t, [2]Team{first[0].Teams[1], first[1].Teams[1]}, b.Losers[0].Games[0].Teams)
+	assert.Equal(t, [2]Team{first[2].Teams[1], first[3].Teams[1]}, b.Losers[0].Games[1].Teams)
+	assert.Equal(t, [2]Team{{}, g.Teams[1]}, b.Losers[1].Games[1].Teams)
+}
+
+func TestDoubleElimination_GrandFinalReset(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.SeedDoubleElimination(ctx, 4))
+
+	// Seeds: 0 plays 3, 1 plays 2.
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 0, ts[3].ID))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[1].ID))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 1, 0, ts[1].ID))
+	assert.ErrorIs(t, svc.Advance(ctx, SideLosers, 1, 0, ts[3].ID), ErrGameNotReady)
+	assert.NoError(t, svc.Advance(ctx, SideLosers, 0, 0, ts[0].ID))
+	assert.NoError(t, svc.Advance(ctx, SideLosers, 1, 0, ts[0].ID))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {ts[3].ID, "team3"}}, b.Losers[1].Games[0].Teams)
+	assert.Equal(t, [2]Team{{ts[1].ID, "team1"}, {ts[0].ID, "team0"}}, b.Final[0].Games[0].Teams)
+
+	// The losers-side team wins the grand final, so there's a reset game.
+	assert.NoError(t, svc.Advance(ctx, SideFinal, 0, 0, ts[0].ID))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{}, b.Champion())
+	assert.Equal(t, [2]Team{{ts[1].ID, "team1"}, {ts[0].ID, "team0"}}, b.Final[1].Games[0].Teams)
+
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 1, 0, ts[1].ID), ErrNotFurthestGame)
+
+	assert.NoError(t, svc.Advance(ctx, SideFinal, 1, 0, ts[1].ID))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{ts[1].ID, "team1"}, b.Champion())
+	assert.Equal(t, Team{ts[0].ID, "team0"}, b.RunnerUp())
+
+	assert.ErrorIs(t, svc.Revert(ctx, SideFinal, 0, 0, ts[0].ID), ErrNotFurthestGame)
+	assert.NoError(t, svc.Revert(ctx, SideFinal, 1, 0, ts[1].ID))
+	assert.NoError(t, svc.Revert(ctx, SideFinal, 0, 0, ts[0].ID))
+
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]Team{}, b.Final[1].Games[0].Teams)
+
+	// Had the winners-side team won the grand final, they'd be champion without a reset.
+	assert.NoError(t, svc.Advance(ctx, SideFinal, 0, 0, ts[1].ID))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{ts[1].ID, "team1"}, b.Champion())
+	assert.Equal(t, Team{ts[0].ID, "team0"}, b.RunnerUp())
+	assert.Equal(t, [2]Team{}, b.Final[1].Games[0].Teams)
+}
@@ -0,0 +1,175 @@
+package tournament
+
+import (
+	"context"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+)
+
+// Report is a team's claim about the result of their bracket game.
+type Report struct {
+	Team Team
+	Won  bool
+	// LoserScore is the losing team's score; the winner has 121.
+	LoserScore int
+	// Reporter identifies who made the report; see ReportResult.
+	Reporter string
+}
+
+// Pending reports whether results have been reported for the game but it doesn't have a winner
+// yet, because the other team hasn't confirmed or the reports conflict.
+func (g Game) Pending() bool {
+	return !g.Decided() && len(g.Reports) > 0
+}
+
+// Conflicting reports whether both teams have reported a result but disagree about who won or the
+// score.
+func (g Game) Conflicting() bool {
+	return len(g.Reports) == 2 && !g.Reports[0].agrees(g.Reports[1])
+}
+
+// agrees reports whether r and the other team's report o describe the same result.
+func (r Report) agrees(o Report) bool {
+	return r.Won != o.Won && r.LoserScore == o.LoserScore
+}
+
+// ReportedWinner returns the team that the game's reports say won. It's false if nobody has
+// reported or the reports conflict.
+func (g Game) ReportedWinner() (Team, bool) {
+	if len(g.Reports) == 0 || g.Conflicting() {
+		return Team{}, false
+	}
+	r := g.Reports[0]
+	if r.Won {
+		return r.Team, true
+	}
+	return g.opponent(r.Team.ID), true
+}
+
+// games returns every game in the bracket: the winners side, then the losers side, the final,
+// and the third-place game.
+func (b Bracket) games() []Game {
+	var res []Game
+	for _, rounds := range [][]Round{b.Rounds, b.Losers, b.Final} {
+		for _, r := range rounds {
+			res = append(res, r.Games...)
+		}
+	}
+	if b.ThirdPlace != nil {
+		res = append(res, *b.ThirdPlace)
+	}
+	return res
+}
+
+// Pending returns the games that are waiting on a report to be confirmed, in bracket order.
+func (b Bracket) Pending() []Game {
+	var res []Game
+	for _, g := range b.games() {
+		if g.Pending() {
+			res = append(res, g)
+		}
+	}
+	return res
+}
+
+// CurrentGame returns the game that teamID is waiting to play or report, if they have one.
+func (b Bracket) CurrentGame(teamID string) (Game, bool) {
+	for _, g := range b.games() {
+		if g.Ready() && !g.Decided() && g.HasTeam(teamID) {
+			return g, true
+		}
+	}
+	return Game{}, false
+}
+
+// ReportResult records teamID's claim that they won or lost the given game, and the loser's score
+// out of 121, which must be between 1 and 120 as for prelim games. Once both teams agree on both,
+// the score is recorded and the winner advances as if by Advance. A report that the other team
+// hasn't confirmed, or two that conflict, wait for an admin to Advance the right team or record the
+// score.
+//
+// A team can change their report until the game is decided. Series are decided by their games'
+// scores, so they can't be reported this way.
+//
+// reporter identifies who is reporting, such as their browser. A report can't confirm one from the
+// same reporter, since then one person could decide a game alone; it returns ErrSameReporter.
+func (s Service) ReportResult(
+	ctx context.Context,
+	side Side,
+	round, idx int,
+	teamID, reporter string,
+	won bool,
+	loserScore int,
+) error {
+	if loserScore < 1 || loserScore > 120 {
+		return ErrInvalidScore
+	}
+
+	err := s.txer.WithTx(ctx, func(ctx context.Context) error {
+		b, err := s.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		g, ok := b.Game(side, round, idx)
+		if !ok {
+			return ErrGameNotFound
+		}
+		if !g.Ready() {
+			return ErrGameNotReady
+		}
+		if g.Decided() {
+			return ErrGameDecided
+		}
+		if !g.HasTeam(teamID) {
+			return ErrTeamNotInGame
+		}
+		if g.IsSeries() {
+			return ErrSeriesReport
+		}
+
+		other, ok := otherReport(g, teamID)
+		if ok && reporter != "" && other.Reporter == reporter {
+			return ErrSameReporter
+		}
+
+		report := games.BracketReport{Won: won, LoserScore: loserScore, Reporter: reporter}
+		err = s.gameRepo.PutBracketReport(ctx, side, round, idx, teamID, report)
+		if err != nil {
+			return err
+		}
+
+		if !ok || !other.agrees(Report{Won: won, LoserScore: loserScore}) {
+			return nil
+		}
+
+		winnerID := teamID
+		if !won {
+			winnerID = other.Team.ID
+		}
+		scores := [2]int{loserScore, 121}
+		if g.Teams[0].ID == winnerID {
+			scores = [2]int{121, loserScore}
+		}
+		err = s.gameRepo.AddSeriesGame(ctx, side, round, idx, scores[0], scores[1])
+		if err != nil {
+			return err
+		}
+		return s.advance(ctx, b, g, winnerID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Side: side, Round: round, Idx: idx})
+}
+
+// otherReport returns the report of the team playing against teamID in g, if they've made one.
+func otherReport(g Game, teamID string) (Report, bool) {
+	for _, r := range g.Reports {
+		if r.Team.ID != teamID {
+			return r, true
+		}
+	}
+	return Report{}, false
+}
@@ -0,0 +1,126 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+)
+
+// reporter returns the reporter ID of the nth team's device.
+func reporter(n int) string {
+	return fmt.Sprintf("reporter%d", n)
+}
+
+func TestReportResult(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.Seed(ctx, 4, false))
+
+	assert.ErrorIs(t, svc.ReportResult(ctx, SideWinners, 1, 0, ts[0].ID, reporter(0), true, 100), ErrGameNotReady)
+	assert.ErrorIs(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[1].ID, reporter(1), true, 100), ErrTeamNotInGame)
+
+	// One team's report waits for the other team.
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[3].ID, reporter(3), false, 100))
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	g := b.Rounds[0].Games[0]
+	assert.Equal(t, true, g.Pending())
+	assert.Equal(t, []Report{{Team: Team{ts[3].ID, "team3"}, Won: false, LoserScore: 100, Reporter: "reporter3"}}, g.Reports)
+	winner, ok := g.ReportedWinner()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, Team{ts[0].ID, "team0"}, winner)
+
+	cur, ok := b.CurrentGame(ts[3].ID)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 0, cur.Idx)
+	_, ok = b.CurrentGame(ts[0].ID + "x")
+	assert.Equal(t, false, ok)
+
+	// Once both teams agree, the score is recorded, the winner advances and the reports are cleared.
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[0].ID, reporter(0), true, 100))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{ts[0].ID, "team0"}, b.Rounds[0].Games[0].Winner)
+	assert.Equal(t, []SeriesGame{{Scores: [2]int{121, 100}}}, b.Rounds[0].Games[0].Series)
+	assert.SliceLen(t, b.Rounds[0].Games[0].Reports, 0)
+	assert.Equal(t, Team{ts[0].ID, "team0"}, b.Rounds[1].Games[0].Teams[0])
+	assert.ErrorIs(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[0].ID, reporter(0), true, 100), ErrGameDecided)
+
+	// Conflicting reports wait for an admin.
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 1, ts[1].ID, reporter(1), true, 100))
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 1, ts[2].ID, reporter(2), true, 100))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	g = b.Rounds[0].Games[1]
+	assert.Equal(t, true, g.Conflicting())
+	assert.Equal(t, false, g.Decided())
+	_, ok = g.ReportedWinner()
+	assert.Equal(t, false, ok)
+	assert.Equal(t, []Game{g}, b.Pending())
+
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[2].ID))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, b.Pending(), 0)
+	assert.SliceLen(t, b.Rounds[0].Games[1].Reports, 0)
+
+	// Scores must be possible.
+	assert.ErrorIs(t, svc.ReportResult(ctx, SideWinners, 1, 0, ts[0].ID, reporter(0), true, 0), ErrInvalidScore)
+	assert.ErrorIs(t, svc.ReportResult(ctx, SideWinners, 1, 0, ts[0].ID, reporter(0), true, 121), ErrInvalidScore)
+
+	// Series are decided by their games.
+	assert.NoError(t, svc.SetSeriesLength(ctx, SideWinners, 1, 0, 3))
+	assert.ErrorIs(t, svc.ReportResult(ctx, SideWinners, 1, 0, ts[0].ID, reporter(0), true, 100), ErrSeriesReport)
+}
+
+func TestReportResult_ScoresMustAgree(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.Seed(ctx, 4, false))
+
+	// The teams agree on the winner but not the score.
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[0].ID, reporter(0), true, 100))
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[3].ID, reporter(3), false, 101))
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	g := b.Rounds[0].Games[0]
+	assert.Equal(t, true, g.Conflicting())
+	assert.Equal(t, false, g.Decided())
+
+	// Fixing the score decides it.
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[3].ID, reporter(3), false, 100))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	g = b.Rounds[0].Games[0]
+	assert.Equal(t, Team{ts[0].ID, "team0"}, g.Winner)
+	assert.Equal(t, []SeriesGame{{Scores: [2]int{121, 100}}}, g.Series)
+}
+
+func TestReportResult_SameReporter(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.Seed(ctx, 4, false))
+
+	// One device can't report for both teams, whether or not the reports agree.
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[0].ID, "phone", true, 100))
+	assert.ErrorIs(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[3].ID, "phone", false, 100), ErrSameReporter)
+	assert.ErrorIs(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[3].ID, "phone", true, 100), ErrSameReporter)
+
+	// It can change its own report.
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[0].ID, "phone", false, 100))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	g := b.Rounds[0].Games[0]
+	assert.Equal(t, false, g.Decided())
+	assert.SliceLen(t, g.Reports, 1)
+
+	assert.NoError(t, svc.ReportResult(ctx, SideWinners, 0, 0, ts[3].ID, "tablet", true, 100))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{ts[3].ID, "team3"}, b.Rounds[0].Games[0].Winner)
+}
@@ -0,0 +1,145 @@
+package tournament
+
+import (
+	"context"
+)
+
+// SeriesGame is one game of a bracket game's series.
+type SeriesGame struct {
+	// Scores are in the order of the bracket game's Teams.
+	Scores [2]int
+}
+
+// winner returns the position (0 or 1) of the team that won the game.
+func (g SeriesGame) winner() int {
+	if g.Scores[1] > g.Scores[0] {
+		return 1
+	}
+	return 0
+}
+
+// IsSeries reports whether the game is a series of more than one game.
+func (g Game) IsSeries() bool {
+	return g.BestOf > 1
+}
+
+// Wins returns the number of series games each team has won, in the order of Teams.
+func (g Game) Wins() [2]int {
+	var wins [2]int
+	for _, sg := range g.Series {
+		wins[sg.winner()]++
+	}
+	return wins
+}
+
+// SetSeriesLength makes the given game a best-of-bestOf series; 1 makes it a single game again.
+// The length can't be changed once a game of the series has been recorded.
+func (s Service) SetSeriesLength(ctx context.Context, side Side, round, idx, bestOf int) error {
+	if bestOf < 1 || bestOf%2 == 0 {
+		return ErrInvalidSeriesLength
+	}
+
+	err := s.txer.WithTx(ctx, func(ctx context.Context) error {
+		b, err := s.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		g, ok := b.Game(side, round, idx)
+		if !ok {
+			return ErrGameNotFound
+		}
+		if g.Decided() {
+			return ErrGameDecided
+		}
+		if len(g.Series) > 0 {
+			return ErrSeriesStarted
+		}
+
+		return s.gameRepo.SetSeriesLength(ctx, side, round, idx, bestOf)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Side: side, Round: round, Idx: idx})
+}
+
+// RecordSeriesGame records the next game of the given game's series, won by winnerID with 121
+// points to loserScore. Once a team has won a majority of the series' games they advance as if by
+// Advance. A single game's score can be recorded the same way, which decides it straight away.
+func (s Service) RecordSeriesGame(ctx context.Context, side Side, round, idx int, winnerID string, loserScore int) error {
+	if loserScore < 1 || loserScore > 120 {
+		return ErrInvalidScore
+	}
+
+	err := s.txer.WithTx(ctx, func(ctx context.Context) error {
+		b, err := s.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		g, ok := b.Game(side, round, idx)
+		if !ok {
+			return ErrGameNotFound
+		}
+		if !g.Ready() {
+			return ErrGameNotReady
+		}
+		if g.Decided() {
+			return ErrGameDecided
+		}
+		if !g.HasTeam(winnerID) {
+			return ErrTeamNotInGame
+		}
+
+		game := SeriesGame{Scores: [2]int{loserScore, 121}}
+		if g.Teams[0].ID == winnerID {
+			game.Scores = [2]int{121, loserScore}
+		}
+		err = s.gameRepo.AddSeriesGame(ctx, side, round, idx, game.Scores[0], game.Scores[1])
+		if err != nil {
+			return err
+		}
+
+		g.Series = append(g.Series, game)
+		if g.Wins()[game.winner()] <= g.BestOf/2 {
+			return nil
+		}
+		return s.advance(ctx, b, g, winnerID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Side: side, Round: round, Idx: idx})
+}
+
+// UndoSeriesGame removes the last recorded game of the given game's series. If that game decided
+// the series, the result is reverted as if by Revert.
+func (s Service) UndoSeriesGame(ctx context.Context, side Side, round, idx int) error {
+	err := s.txer.WithTx(ctx, func(ctx context.Context) error {
+		b, err := s.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		g, ok := b.Game(side, round, idx)
+		if !ok {
+			return ErrGameNotFound
+		}
+		if len(g.Series) == 0 {
+			return ErrNoSeriesGames
+		}
+
+		if g.Decided() {
+			return s.revert(ctx, b, g)
+		}
+		return s.gameRepo.DeleteLastSeriesGame(ctx, side, round, idx)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Side: side, Round: round, Idx: idx})
+}
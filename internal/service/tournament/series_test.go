@@ -0,0 +1,127 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+)
+
+func TestSeries(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.Seed(ctx, 4, false))
+
+	assert.ErrorIs(t, svc.SetSeriesLength(ctx, SideWinners, 1, 0, 2), ErrInvalidSeriesLength)
+	assert.ErrorIs(t, svc.SetSeriesLength(ctx, SideWinners, 2, 0, 3), ErrGameNotFound)
+	assert.NoError(t, svc.SetSeriesLength(ctx, SideWinners, 1, 0, 3))
+
+	// The semifinals are single games, so recording a score decides them.
+	assert.ErrorIs(t, svc.RecordSeriesGame(ctx, SideWinners, 0, 0, ts[0].ID, 121), ErrInvalidScore)
+	assert.NoError(t, svc.RecordSeriesGame(ctx, SideWinners, 0, 0, ts[0].ID, 110))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[2].ID))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{ts[0].ID, "team0"}, b.Rounds[0].Games[0].Winner)
+	assert.Equal(t, []SeriesGame{{Scores: [2]int{121, 110}}}, b.Rounds[0].Games[0].Series)
+
+	final := b.Rounds[1].Games[0]
+	assert.Equal(t, 3, final.BestOf)
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {ts[2].ID, "team2"}}, final.Teams)
+
+	// The final is best of three, so it can only be won through its games.
+	assert.ErrorIs(t, svc.Advance(ctx, SideWinners, 1, 0, ts[0].ID), ErrSeriesGame)
+	assert.NoError(t, svc.RecordSeriesGame(ctx, SideWinners, 1, 0, ts[2].ID, 90))
+	assert.ErrorIs(t, svc.SetSeriesLength(ctx, SideWinners, 1, 0, 5), ErrSeriesStarted)
+	assert.NoError(t, svc.RecordSeriesGame(ctx, SideWinners, 1, 0, ts[0].ID, 100))
+
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]int{1, 1}, b.Rounds[1].Games[0].Wins())
+	assert.Equal(t, Team{}, b.Champion())
+
+	assert.NoError(t, svc.RecordSeriesGame(ctx, SideWinners, 1, 0, ts[2].ID, 75))
+	assert.ErrorIs(t, svc.RecordSeriesGame(ctx, SideWinners, 1, 0, ts[2].ID, 75), ErrGameDecided)
+
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{ts[2].ID, "team2"}, b.Champion())
+	assert.Equal(t, []SeriesGame{
+		{Scores: [2]int{90, 121}},
+		{Scores: [2]int{121, 100}},
+		{Scores: [2]int{75, 121}},
+	}, b.Rounds[1].Games[0].Series)
+
+	// A semifinal can't be undone while its winner is still playing on.
+	assert.ErrorIs(t, svc.UndoSeriesGame(ctx, SideWinners, 0, 0), ErrNotFurthestGame)
+
+	// Undoing the deciding game takes the title away again.
+	assert.NoError(t, svc.UndoSeriesGame(ctx, SideWinners, 1, 0))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{}, b.Champion())
+	assert.Equal(t, [2]int{1, 1}, b.Rounds[1].Games[0].Wins())
+
+	assert.NoError(t, svc.UndoSeriesGame(ctx, SideWinners, 1, 0))
+	assert.NoError(t, svc.UndoSeriesGame(ctx, SideWinners, 1, 0))
+	assert.ErrorIs(t, svc.UndoSeriesGame(ctx, SideWinners, 1, 0), ErrNoSeriesGames)
+
+	// Reverting a game whose score was recorded removes the score too.
+	assert.NoError(t, svc.Revert(ctx, SideWinners, 0, 0, ts[0].ID))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, b.Rounds[0].Games[0].Series, 0)
+}
+
+func TestRevert_NextSeriesStarted(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.Seed(ctx, 4, true))
+	assert.NoError(t, svc.SetSeriesLength(ctx, SideWinners, 1, 0, 3))
+	assert.NoError(t, svc.SetSeriesLength(ctx, SideThirdPlace, 0, 0, 3))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 0, ts[0].ID))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[2].ID))
+
+	// The final's series games belong to its slot, so neither semifinal can be reverted once it
+	// has started: the team that took the slot over would inherit the wins.
+	assert.NoError(t, svc.RecordSeriesGame(ctx, SideWinners, 1, 0, ts[0].ID, 100))
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 0, 0, ts[0].ID), ErrNotFurthestGame)
+	assert.NoError(t, svc.UndoSeriesGame(ctx, SideWinners, 1, 0))
+
+	// The same goes for the losers in the third-place game.
+	assert.NoError(t, svc.RecordSeriesGame(ctx, SideThirdPlace, 0, 0, ts[3].ID, 100))
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 0, 0, ts[0].ID), ErrNotFurthestGame)
+	assert.NoError(t, svc.UndoSeriesGame(ctx, SideThirdPlace, 0, 0))
+
+	assert.NoError(t, svc.Revert(ctx, SideWinners, 0, 0, ts[0].ID))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 0, ts[3].ID))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]Team{{ts[3].ID, "team3"}, {ts[2].ID, "team2"}}, b.Rounds[1].Games[0].Teams)
+	assert.SliceLen(t, b.Rounds[1].Games[0].Series, 0)
+	assert.SliceLen(t, b.ThirdPlace.Series, 0)
+}
+
+func TestRevertDoubleElimination_NextSeriesStarted(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.SeedDoubleElimination(ctx, 4))
+	assert.NoError(t, svc.SetSeriesLength(ctx, SideLosers, 0, 0, 3))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 0, ts[0].ID))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[1].ID))
+
+	// The losers of the first round have started their series.
+	assert.NoError(t, svc.RecordSeriesGame(ctx, SideLosers, 0, 0, ts[3].ID, 100))
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 0, 0, ts[0].ID), ErrNotFurthestGame)
+
+	assert.NoError(t, svc.UndoSeriesGame(ctx, SideLosers, 0, 0))
+	assert.NoError(t, svc.Revert(ctx, SideWinners, 0, 0, ts[0].ID))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, b.Losers[0].Games[0].Series, 0)
+}
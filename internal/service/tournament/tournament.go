@@ -0,0 +1,762 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cszczepaniak/cribbly/internal/notifier"
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+var (
+	ErrInvalidSize     = errors.New("a bracket needs at least two teams")
+	ErrNotEnoughTeams  = errors.New("not enough teams to seed the tournament")
+	ErrAlreadySeeded   = errors.New("the bracket has already been seeded")
+	ErrGameNotFound    = errors.New("bracket game not found")
+	ErrGameNotReady    = errors.New("both teams must be in the game")
+	ErrGameDecided     = errors.New("game already has a winner")
+	ErrTeamNotInGame   = errors.New("team is not in this game")
+	ErrNotFurthestGame = errors.New("can only revert a team from their furthest game")
+	ErrTeamIsNotWinner = errors.New("team did not win this game")
+	ErrByeGame         = errors.New("byes can't be reverted")
+
+	ErrThirdPlaceTooSmall = errors.New("a third-place game needs at least four teams")
+
+	ErrSeriesGame          = errors.New("the winner of a series is decided by its games")
+	ErrInvalidSeriesLength = errors.New("a series must be an odd number of games")
+	ErrSeriesStarted       = errors.New("can't change the length of a series once it has started")
+	ErrInvalidScore        = errors.New("losing score must be between 1 and 120")
+	ErrNoSeriesGames       = errors.New("no games have been recorded")
+
+	ErrSeriesReport = errors.New("a series can't be reported, only its games' scores")
+	ErrSameReporter = errors.New("the other team must confirm the result themselves")
+
+	ErrMainBracketNotSeeded = errors.New("the main bracket must be seeded before the consolation bracket")
+
+	ErrInvalidDoubleEliminationSize = errors.New("double elimination needs a power of two of at least four teams")
+)
+
+// Format is how teams are eliminated from a bracket.
+type Format int
+
+const (
+	// FormatSingleElimination knocks a team out after one loss.
+	FormatSingleElimination Format = iota
+	// FormatDoubleElimination drops a team into the losers side after their first loss and knocks
+	// them out after their second.
+	FormatDoubleElimination
+)
+
+// Side is the part of a bracket a game is in. Single-elimination brackets only have the winners
+// side.
+type Side = games.BracketSide
+
+const (
+	SideWinners    = games.SideWinners
+	SideLosers     = games.SideLosers
+	SideFinal      = games.SideFinal
+	SideThirdPlace = games.SideThirdPlace
+)
+
+type Team struct {
+	ID   string
+	Name string
+}
+
+type Game struct {
+	Side  Side
+	Round int
+	Idx   int
+	// Teams holds the two teams in the game. Either may be empty if the team that will play in that
+	// slot hasn't been decided yet.
+	Teams  [2]Team
+	Winner Team
+	// Bye is true for a first-round game with only one team, who advanced without playing.
+	Bye bool
+	// BestOf is the number of games in the game's series, or 1 if it's a single game.
+	BestOf int
+	// Series holds the scores of the games played so far, if they've been recorded.
+	Series []SeriesGame
+	// Reports holds the results the game's teams have reported, in the order of Teams. They're
+	// cleared once the game has a winner.
+	Reports []Report
+}
+
+func (g Game) Ready() bool {
+	return g.Teams[0].ID != "" && g.Teams[1].ID != ""
+}
+
+func (g Game) Decided() bool {
+	return g.Winner.ID != ""
+}
+
+func (g Game) HasTeam(id string) bool {
+	return id != "" && (g.Teams[0].ID == id || g.Teams[1].ID == id)
+}
+
+// started reports whether the game has a winner or any games of its series recorded. The series
+// games belong to the slot rather than to its teams, so a team can't be taken out of a started game.
+func (g Game) started() bool {
+	return g.Decided() || len(g.Series) > 0
+}
+
+// opponent returns the team playing against teamID.
+func (g Game) opponent(teamID string) Team {
+	if g.Teams[0].ID == teamID {
+		return g.Teams[1]
+	}
+	return g.Teams[0]
+}
+
+type Round struct {
+	Games []Game
+}
+
+type Bracket struct {
+	Format Format
+	// Rounds is empty if the bracket hasn't been seeded. For single elimination, the last round is
+	// the final; for double elimination these are the rounds of the winners side.
+	Rounds []Round
+	// Losers holds the rounds of the losers side of a double-elimination bracket.
+	Losers []Round
+	// Final holds the grand final of a double-elimination bracket (round 0) and the reset game
+	// (round 1), which is only played if the team from the losers side wins the grand final.
+	Final []Round
+	// ThirdPlace is the game between the semifinal losers of a single-elimination bracket, or nil if
+	// the bracket doesn't have one.
+	ThirdPlace *Game
+	// TeamCount is the most teams that can be seeded: every team in the event for the main bracket,
+	// or the teams that missed the main bracket for the consolation bracket (none until the main
+	// bracket is seeded).
+	TeamCount int
+}
+
+func (b Bracket) Seeded() bool {
+	return len(b.Rounds) > 0
+}
+
+// Champion returns the winner of the final, or an empty Team if it hasn't been played.
+func (b Bracket) Champion() Team {
+	if b.Format == FormatDoubleElimination {
+		return b.doubleEliminationChampion()
+	}
+	if len(b.Rounds) == 0 {
+		return Team{}
+	}
+	last := b.Rounds[len(b.Rounds)-1]
+	if len(last.Games) != 1 {
+		return Team{}
+	}
+	return last.Games[0].Winner
+}
+
+// RunnerUp returns the team that lost the final, or an empty Team if it hasn't been played.
+func (b Bracket) RunnerUp() Team {
+	champion := b.Champion()
+	if champion.ID == "" {
+		return Team{}
+	}
+	if b.Format == FormatDoubleElimination {
+		// Both games of the grand final are between the same two teams.
+		return b.Final[0].Games[0].opponent(champion.ID)
+	}
+	return b.Rounds[len(b.Rounds)-1].Games[0].opponent(champion.ID)
+}
+
+// decidesChampion reports whether g is a game whose result can decide the bracket's champion.
+func (b Bracket) decidesChampion(g Game) bool {
+	if b.Format == FormatDoubleElimination {
+		return g.Side == SideFinal
+	}
+	return g.Side == SideWinners && g.Round == len(b.Rounds)-1
+}
+
+// teamsSeeded returns the number of teams that were seeded into the bracket.
+func (b Bracket) teamsSeeded() int {
+	if len(b.Rounds) == 0 {
+		return 0
+	}
+	n := 0
+	for _, g := range b.Rounds[0].Games {
+		for _, t := range g.Teams {
+			if t.ID != "" {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// semifinal reports whether round is the semifinals of a bracket with a third-place game, so
+// that its losers go on to play for third.
+func (b Bracket) semifinal(round int) bool {
+	return b.ThirdPlace != nil && round == len(b.Rounds)-2
+}
+
+// Game returns the game at the given place in the bracket, if there is one.
+func (b Bracket) Game(side Side, round, idx int) (Game, bool) {
+	var rounds []Round
+	switch side {
+	case SideThirdPlace:
+		if b.ThirdPlace == nil || round != 0 || idx != 0 {
+			return Game{}, false
+		}
+		return *b.ThirdPlace, true
+	case SideWinners:
+		rounds = b.Rounds
+	case SideLosers:
+		rounds = b.Losers
+	case SideFinal:
+		rounds = b.Final
+	}
+	if round < 0 || round >= len(rounds) {
+		return Game{}, false
+	}
+	gs := rounds[round].Games
+	if idx < 0 || idx >= len(gs) {
+		return Game{}, false
+	}
+	return gs[idx], true
+}
+
+// Change is published on the tournament notifier whenever a bracket changes. It goes to the
+// bracket's Topic.
+type Change struct {
+	EventID string
+	// Reseeded is true when the whole bracket was created or deleted. Otherwise the game at (Side,
+	// Round, Idx) changed: either a result was reported, or its winner was set or cleared, which also
+	// changes the games that its teams move on to.
+	Reseeded bool
+	Side     Side
+	Round    int
+	Idx      int
+}
+
+type Notifier = notifier.Notifier[Change]
+
+// BracketTopic is the tournament notifier topic for the given event's bracket.
+func BracketTopic(eventID string) string {
+	return "bracket:" + eventID
+}
+
+// ConsolationTopic is the tournament notifier topic for the given event's consolation bracket.
+func ConsolationTopic(eventID string) string {
+	return "consolation:" + eventID
+}
+
+// Service owns the rules for running the playoff bracket. Every change is announced on the
+// tournament notifier so that live views refresh.
+//
+// A Service manages the main bracket; Consolation returns one that manages the consolation bracket
+// instead.
+type Service struct {
+	txer     database.Transactor
+	gameRepo games.Repository
+	teamRepo teams.Repository
+	notifier *Notifier
+	bracket  games.BracketName
+}
+
+func New(
+	txer database.Transactor,
+	gameRepo games.Repository,
+	teamRepo teams.Repository,
+	notifier *Notifier,
+) Service {
+	return Service{
+		txer:     txer,
+		gameRepo: gameRepo,
+		teamRepo: teamRepo,
+		notifier: notifier,
+		bracket:  games.MainBracket,
+	}
+}
+
+// Consolation returns a Service for the consolation bracket, which is seeded from the teams in the
+// standings that missed the main bracket and is run independently of it.
+func (s Service) Consolation() Service {
+	return s.forBracket(games.ConsolationBracket)
+}
+
+func (s Service) forBracket(name games.BracketName) Service {
+	s.bracket = name
+	s.gameRepo = s.gameRepo.ForBracket(name)
+	return s
+}
+
+// Topic returns the tournament notifier topic for the bracket that ctx is scoped to.
+func (s Service) Topic(ctx context.Context) (string, error) {
+	eventID, err := s.gameRepo.CurrentEventID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return s.topic(eventID), nil
+}
+
+func (s Service) topic(eventID string) string {
+	if s.bracket == games.ConsolationBracket {
+		return ConsolationTopic(eventID)
+	}
+	return BracketTopic(eventID)
+}
+
+func (s Service) notify(ctx context.Context, change Change) error {
+	eventID, err := s.gameRepo.CurrentEventID(ctx)
+	if err != nil {
+		return err
+	}
+	change.EventID = eventID
+	s.notifier.Notify(change, s.topic(eventID))
+	return nil
+}
+
+func (s Service) Get(ctx context.Context) (Bracket, error) {
+	tourney, err := s.gameRepo.LoadTournament(ctx)
+	if err != nil {
+		return Bracket{}, err
+	}
+
+	de, err := s.gameRepo.LoadDoubleElimination(ctx)
+	if err != nil {
+		return Bracket{}, err
+	}
+
+	ts, err := s.teamRepo.GetAll(ctx)
+	if err != nil {
+		return Bracket{}, err
+	}
+	firstSeed, err := s.firstSeed(ctx)
+	if err != nil {
+		return Bracket{}, err
+	}
+	teamCount := max(len(ts)-firstSeed, 0)
+	if s.bracket == games.ConsolationBracket && firstSeed == 0 {
+		// Nobody can be seeded until we know who missed the main bracket.
+		teamCount = 0
+	}
+
+	teamsByID := make(map[string]Team, len(ts))
+	for _, t := range ts {
+		teamsByID[t.ID] = Team{ID: t.ID, Name: t.Name}
+	}
+
+	team := func(id string) Team {
+		if id == "" {
+			return Team{}
+		}
+		if t, ok := teamsByID[id]; ok {
+			return t
+		}
+		return Team{ID: id}
+	}
+
+	series, err := s.gameRepo.LoadSeries(ctx)
+	if err != nil {
+		return Bracket{}, err
+	}
+	withSeries := func(g Game) Game {
+		g.BestOf = 1
+		sr, ok := series[games.BracketSlot{Side: g.Side, Round: g.Round, Idx: g.Idx}]
+		if !ok {
+			return g
+		}
+		g.BestOf = sr.BestOf
+		for _, scores := range sr.Games {
+			g.Series = append(g.Series, SeriesGame{Scores: scores})
+		}
+		return g
+	}
+
+	reports, err := s.gameRepo.LoadBracketReports(ctx)
+	if err != nil {
+		return Bracket{}, err
+	}
+	withReports := func(g Game) Game {
+		byTeam := reports[games.BracketSlot{Side: g.Side, Round: g.Round, Idx: g.Idx}]
+		for _, t := range g.Teams {
+			if r, ok := byTeam[t.ID]; ok && t.ID != "" {
+				g.Reports = append(g.Reports, Report{Team: t, Won: r.Won, LoserScore: r.LoserScore, Reporter: r.Reporter})
+			}
+		}
+		return g
+	}
+
+	rounds := func(side Side, rs []games.Round) []Round {
+		res := make([]Round, 0, len(rs))
+		for i, rnd := range rs {
+			gs := make([]Game, 0, len(rnd.Games))
+			for j, g := range rnd.Games {
+				gs = append(gs, withReports(withSeries(Game{
+					Side:   side,
+					Round:  i,
+					Idx:    j,
+					Teams:  [2]Team{team(g.TeamIDs[0]), team(g.TeamIDs[1])},
+					Winner: team(g.Winner),
+					Bye:    g.Bye,
+				})))
+			}
+			res = append(res, Round{Games: gs})
+		}
+		return res
+	}
+
+	if len(de.Winners) > 0 {
+		return Bracket{
+			Format:    FormatDoubleElimination,
+			Rounds:    rounds(SideWinners, de.Winners),
+			Losers:    rounds(SideLosers, de.Losers),
+			Final:     rounds(SideFinal, de.Final),
+			TeamCount: teamCount,
+		}, nil
+	}
+
+	var thirdPlace *Game
+	if g := tourney.ThirdPlace; g != nil {
+		tp := withReports(withSeries(Game{
+			Side:   SideThirdPlace,
+			Teams:  [2]Team{team(g.TeamIDs[0]), team(g.TeamIDs[1])},
+			Winner: team(g.Winner),
+		}))
+		thirdPlace = &tp
+	}
+
+	return Bracket{
+		Rounds:     rounds(SideWinners, tourney.Rounds),
+		ThirdPlace: thirdPlace,
+		TeamCount:  teamCount,
+	}, nil
+}
+
+// Seed creates a bracket of the given size from the prelim standings. Seeds are placed the usual
+// way, so the top seed plays the bottom seed and the top two seeds can only meet in the final. If
+// size isn't a power of two, the top seeds get byes into the second round.
+//
+// If thirdPlace is true, the bracket gets a third-place game, which the semifinal losers are put
+// into as the semifinals are decided.
+func (s Service) Seed(ctx context.Context, size int, thirdPlace bool) error {
+	if size < 2 {
+		return ErrInvalidSize
+	}
+	if thirdPlace && size < 4 {
+		return ErrThirdPlaceTooSmall
+	}
+
+	err := s.txer.WithTx(ctx, func(ctx context.Context) error {
+		standings, err := s.seedingStandings(ctx, size)
+		if err != nil {
+			return err
+		}
+
+		err = s.gameRepo.InitializeTournament(ctx, size)
+		if err != nil {
+			return err
+		}
+
+		if thirdPlace {
+			err = s.gameRepo.InitializeThirdPlaceGame(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		order := seedOrder(games.BracketSlots(size))
+		for i := range len(order) / 2 {
+			seed1, seed2 := order[2*i], order[2*i+1]
+			if seed2 >= size {
+				// There's no team with this seed, so seed1 gets a bye.
+				err := s.gameRepo.PutByeIntoTournamentGame(ctx, i, standings[seed1].TeamID)
+				if err != nil {
+					return err
+				}
+				err = s.putIntoNextRound(ctx, 0, i, standings[seed1].TeamID)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := s.gameRepo.PutTeam1IntoTournamentGame(ctx, 0, i, standings[seed1].TeamID)
+			if err != nil {
+				return err
+			}
+			err = s.gameRepo.PutTeam2IntoTournamentGame(ctx, 0, i, standings[seed2].TeamID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Reseeded: true})
+}
+
+// seedingStandings checks that a bracket of the given size can be seeded and returns the prelim
+// standings to seed it from.
+func (s Service) seedingStandings(ctx context.Context, size int) ([]games.Standing, error) {
+	existing, err := s.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Seeded() {
+		return nil, ErrAlreadySeeded
+	}
+
+	firstSeed, err := s.firstSeed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.bracket == games.ConsolationBracket && firstSeed == 0 {
+		return nil, ErrMainBracketNotSeeded
+	}
+
+	// Seeding is what the standings decide, so any remaining ties are settled by coin flips now.
+	standings, err := s.gameRepo.FinalizeStandings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	standings = standings[min(firstSeed, len(standings)):]
+
+	if len(standings) < size {
+		return nil, ErrNotEnoughTeams
+	}
+	return standings, nil
+}
+
+// firstSeed returns the place in the standings that the bracket's top seed comes from. That's the
+// top of the standings for the main bracket, and the first team that missed the main bracket for
+// the consolation bracket.
+func (s Service) firstSeed(ctx context.Context) (int, error) {
+	if s.bracket != games.ConsolationBracket {
+		return 0, nil
+	}
+	main, err := s.forBracket(games.MainBracket).Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return main.teamsSeeded(), nil
+}
+
+// Advance records teamID as the winner of the given game and moves them into their slot in the
+// next round (unless the game is the final). In a double-elimination bracket the loser moves too,
+// from the winners side into the losers side, and the loser of a semifinal moves into the
+// third-place game if there is one.
+//
+// The winner of a series can't be set directly; see RecordSeriesGame.
+func (s Service) Advance(ctx context.Context, side Side, round, idx int, teamID string) error {
+	err := s.txer.WithTx(ctx, func(ctx context.Context) error {
+		b, err := s.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		g, ok := b.Game(side, round, idx)
+		if !ok {
+			return ErrGameNotFound
+		}
+		if g.IsSeries() {
+			return ErrSeriesGame
+		}
+
+		return s.advance(ctx, b, g, teamID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Side: side, Round: round, Idx: idx})
+}
+
+func (s Service) advance(ctx context.Context, b Bracket, g Game, teamID string) error {
+	if !g.Ready() {
+		return ErrGameNotReady
+	}
+	if g.Decided() {
+		return ErrGameDecided
+	}
+	if !g.HasTeam(teamID) {
+		return ErrTeamNotInGame
+	}
+
+	// Once there's a winner, there's nothing left to confirm.
+	err := s.gameRepo.DeleteBracketReports(ctx, g.Side, g.Round, g.Idx)
+	if err != nil {
+		return err
+	}
+
+	if b.Format == FormatDoubleElimination {
+		err = s.advanceDoubleElimination(ctx, b, g, teamID)
+		if err != nil {
+			return err
+		}
+		return s.recordFinalists(ctx, b, g)
+	}
+	if g.Side == SideThirdPlace {
+		return s.gameRepo.SetThirdPlaceWinner(ctx, teamID)
+	}
+
+	err = s.gameRepo.SetTournamentGameWinner(ctx, g.Round, g.Idx, teamID)
+	if err != nil {
+		return err
+	}
+
+	if b.semifinal(g.Round) {
+		err = s.gameRepo.PutTeamIntoThirdPlaceGame(ctx, g.Idx, g.opponent(teamID).ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Only advance team to next round if there is one (skip for final/champion game)
+	if g.Round+1 < len(b.Rounds) {
+		return s.putIntoNextRound(ctx, g.Round, g.Idx, teamID)
+	}
+
+	return s.recordFinalists(ctx, b, g)
+}
+
+// recordFinalists keeps the finalists of the main bracket up to date after the result of g changes,
+// so that they're remembered once the bracket is deleted.
+func (s Service) recordFinalists(ctx context.Context, b Bracket, g Game) error {
+	if s.bracket != games.MainBracket || !b.decidesChampion(g) {
+		return nil
+	}
+
+	b, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	champion := b.Champion()
+	if champion.ID == "" {
+		return s.gameRepo.ClearFinalists(ctx)
+	}
+	return s.gameRepo.SetFinalists(ctx, champion.ID, b.RunnerUp().ID)
+}
+
+// Revert undoes Advance: it clears the winner of the given game and removes teamID from the next
+// round (and, in a double-elimination bracket, the loser from the losers side, or a semifinal loser
+// from the third-place game). Only a team's furthest result can be reverted.
+//
+// If the game's scores were recorded, the last game's scores are removed too, since they decided
+// the winner.
+func (s Service) Revert(ctx context.Context, side Side, round, idx int, teamID string) error {
+	err := s.txer.WithTx(ctx, func(ctx context.Context) error {
+		b, err := s.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		g, ok := b.Game(side, round, idx)
+		if !ok {
+			return ErrGameNotFound
+		}
+		if g.Winner.ID != teamID {
+			return ErrTeamIsNotWinner
+		}
+
+		return s.revert(ctx, b, g)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Side: side, Round: round, Idx: idx})
+}
+
+func (s Service) revert(ctx context.Context, b Bracket, g Game) error {
+	if g.Bye {
+		return ErrByeGame
+	}
+
+	err := s.clearResult(ctx, b, g)
+	if err != nil {
+		return err
+	}
+
+	if len(g.Series) > 0 {
+		err = s.gameRepo.DeleteLastSeriesGame(ctx, g.Side, g.Round, g.Idx)
+		if err != nil {
+			return err
+		}
+	}
+	return s.recordFinalists(ctx, b, g)
+}
+
+// clearResult clears the winner of g and takes its teams out of the games they moved on to.
+func (s Service) clearResult(ctx context.Context, b Bracket, g Game) error {
+	if b.Format == FormatDoubleElimination {
+		return s.revertDoubleElimination(ctx, b, g)
+	}
+	if g.Side == SideThirdPlace {
+		return s.gameRepo.ClearThirdPlaceWinner(ctx)
+	}
+
+	round, idx, teamID := g.Round, g.Idx, g.Winner.ID
+
+	next, hasNext := b.Game(SideWinners, round+1, idx/2)
+	if hasNext && next.started() {
+		return ErrNotFurthestGame
+	}
+	// The loser has played on too.
+	if b.semifinal(round) && b.ThirdPlace.started() {
+		return ErrNotFurthestGame
+	}
+
+	err := s.gameRepo.ClearTournamentGameWinner(ctx, round, idx)
+	if err != nil {
+		return err
+	}
+
+	if hasNext {
+		err = s.gameRepo.ClearTeamFromTournamentGame(ctx, round+1, idx/2, teamID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.semifinal(round) {
+		return s.gameRepo.ClearTeamFromThirdPlaceGame(ctx, g.opponent(teamID).ID)
+	}
+
+	return nil
+}
+
+// putIntoNextRound puts the winner of the given game into their slot in the next round.
+func (s Service) putIntoNextRound(ctx context.Context, round, idx int, teamID string) error {
+	if idx%2 == 0 {
+		return s.gameRepo.PutTeam1IntoTournamentGame(ctx, round+1, idx/2, teamID)
+	}
+	return s.gameRepo.PutTeam2IntoTournamentGame(ctx, round+1, idx/2, teamID)
+}
+
+// seedOrder returns the (zero-based) seeds in the order they fill the first-round slots of a
+// bracket with the given number of slots, a power of two. Consecutive pairs play each other: for
+// 8 slots it's 0,7, 3,4, 1,6, 2,5. Each seed's opponent is the lowest seed left in its part of the
+// bracket, so byes (seeds past the number of teams) go to the top seeds.
+func seedOrder(slots int) []int {
+	order := []int{0}
+	for len(order) < slots {
+		n := len(order) * 2
+		next := make([]int, 0, n)
+		for _, seed := range order {
+			next = append(next, seed, n-1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// Delete deletes the bracket. The finalists of a finished main bracket are kept.
+func (s Service) Delete(ctx context.Context) error {
+	err := s.gameRepo.DeleteTournament(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, Change{Reseeded: true})
+}
@@ -0,0 +1,332 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+// newTournamentService returns a service whose event has n teams that finished the prelims ranked
+// in creation order.
+func newTournamentService(t *testing.T, n int) (Service, []teams.Team) {
+	t.Helper()
+
+	db := database.NewInMemory(t)
+	tr := teams.NewRepository(db)
+	gr := games.NewRepository(db, &games.ScoreNotifier{})
+
+	ts := make([]teams.Team, 0, n)
+	for i := range n {
+		team, err := tr.Create(t.Context(), fmt.Sprintf("team%d", i))
+		assert.NoError(t, err)
+		ts = append(ts, team)
+	}
+
+	// Every team wins one game and loses one; earlier teams score more in their loss.
+	for i, team := range ts {
+		next := ts[(i+1)%n]
+		gameID, err := gr.Create(t.Context(), team.ID, next.ID)
+		assert.NoError(t, err)
+		assert.NoError(t, gr.UpdateScores(t.Context(), gameID, team.ID, 121, next.ID, 100-(i+1)%n))
+	}
+
+	svc := New(database.NewTransactor(db), gr, tr, &Notifier{})
+	return svc, ts
+}
+
+func TestSeed(t *testing.T) {
+	svc, ts := newTournamentService(t, 5)
+
+	assert.ErrorIs(t, svc.Seed(t.Context(), 1, false), ErrInvalidSize)
+	assert.ErrorIs(t, svc.Seed(t.Context(), 8, false), ErrNotEnoughTeams)
+
+	assert.NoError(t, svc.Seed(t.Context(), 4, false))
+	assert.ErrorIs(t, svc.Seed(t.Context(), 4, false), ErrAlreadySeeded)
+
+	b, err := svc.Get(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, true, b.Seeded())
+	assert.Equal(t, 5, b.TeamCount)
+	assert.SliceLen(t, b.Rounds, 2)
+
+	// 1 plays 4, 2 plays 3.
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {ts[3].ID, "team3"}}, b.Rounds[0].Games[0].Teams)
+	assert.Equal(t, [2]Team{{ts[1].ID, "team1"}, {ts[2].ID, "team2"}}, b.Rounds[0].Games[1].Teams)
+	assert.Equal(t, [2]Team{}, b.Rounds[1].Games[0].Teams)
+}
+
+func TestSeed_Byes(t *testing.T) {
+	svc, ts := newTournamentService(t, 6)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.Seed(ctx, 6, false))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, b.Rounds, 3)
+	assert.SliceLen(t, b.Rounds[0].Games, 4)
+
+	// Seeds 1 and 2 get byes and are already in the second round; 4 plays 5 and 3 plays 6.
+	r0 := b.Rounds[0].Games
+	assert.Equal(t, true, r0[0].Bye)
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {}}, r0[0].Teams)
+	assert.Equal(t, Team{ts[0].ID, "team0"}, r0[0].Winner)
+	assert.Equal(t, false, r0[1].Bye)
+	assert.Equal(t, [2]Team{{ts[3].ID, "team3"}, {ts[4].ID, "team4"}}, r0[1].Teams)
+	assert.Equal(t, true, r0[2].Bye)
+	assert.Equal(t, [2]Team{{ts[1].ID, "team1"}, {}}, r0[2].Teams)
+	assert.Equal(t, [2]Team{{ts[2].ID, "team2"}, {ts[5].ID, "team5"}}, r0[3].Teams)
+
+	r1 := b.Rounds[1].Games
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {}}, r1[0].Teams)
+	assert.Equal(t, [2]Team{{ts[1].ID, "team1"}, {}}, r1[1].Teams)
+
+	assert.ErrorIs(t, svc.Advance(ctx, SideWinners, 0, 0, ts[0].ID), ErrGameNotReady)
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 0, 0, ts[0].ID), ErrByeGame)
+
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[4].ID))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {ts[4].ID, "team4"}}, b.Rounds[1].Games[0].Teams)
+}
+
+func TestAdvanceAndRevert(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.Seed(ctx, 4, false))
+
+	assert.ErrorIs(t, svc.Advance(ctx, SideWinners, 0, 0, ts[1].ID), ErrTeamNotInGame)
+	assert.ErrorIs(t, svc.Advance(ctx, SideWinners, 0, 2, ts[0].ID), ErrGameNotFound)
+	assert.ErrorIs(t, svc.Advance(ctx, SideWinners, 1, 0, ts[0].ID), ErrGameNotReady)
+
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 0, ts[3].ID))
+	assert.ErrorIs(t, svc.Advance(ctx, SideWinners, 0, 0, ts[0].ID), ErrGameDecided)
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[1].ID))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, ts[3].ID, b.Rounds[0].Games[0].Winner.ID)
+	assert.Equal(t, [2]Team{{ts[3].ID, "team3"}, {ts[1].ID, "team1"}}, b.Rounds[1].Games[0].Teams)
+
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 1, 0, ts[1].ID))
+
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{ts[1].ID, "team1"}, b.Champion())
+
+	// Team 1's semifinal can't be reverted while they're the champion.
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 0, 1, ts[1].ID), ErrNotFurthestGame)
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 1, 0, ts[3].ID), ErrTeamIsNotWinner)
+
+	assert.NoError(t, svc.Revert(ctx, SideWinners, 1, 0, ts[1].ID))
+	assert.NoError(t, svc.Revert(ctx, SideWinners, 0, 1, ts[1].ID))
+
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{}, b.Champion())
+	assert.Equal(t, Team{}, b.Rounds[0].Games[1].Winner)
+	assert.Equal(t, [2]Team{{ts[3].ID, "team3"}, {}}, b.Rounds[1].Games[0].Teams)
+}
+
+func TestFinalists(t *testing.T) {
+	svc, ts := newTournamentService(t, 4)
+	ctx := t.Context()
+
+	assert.NoError(t, svc.Seed(ctx, 4, false))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 0, ts[0].ID))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[2].ID))
+
+	fs, err := svc.gameRepo.GetAllFinalists(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, fs, 0)
+
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 1, 0, ts[2].ID))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{ts[0].ID, "team0"}, b.RunnerUp())
+
+	fs, err = svc.gameRepo.GetAllFinalists(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, fs, 2)
+	assert.Equal(t, games.PlaceChampion, fs[0].Place)
+	assert.Equal(t, ts[2].ID, fs[0].TeamID)
+	assert.Equal(t, "team2", fs[0].TeamName)
+	assert.Equal(t, games.PlaceRunnerUp, fs[1].Place)
+	assert.Equal(t, ts[0].ID, fs[1].TeamID)
+
+	// Reverting the final forgets them.
+	assert.NoError(t, svc.Revert(ctx, SideWinners, 1, 0, ts[2].ID))
+	fs, err = svc.gameRepo.GetAllFinalists(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, fs, 0)
+
+	// Deleting the bracket doesn't.
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 1, 0, ts[0].ID))
+	assert.NoError(t, svc.Delete(ctx))
+	fs, err = svc.gameRepo.GetAllFinalists(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, fs, 2)
+	assert.Equal(t, ts[0].ID, fs[0].TeamID)
+	assert.Equal(t, ts[2].ID, fs[1].TeamID)
+}
+
+func TestThirdPlaceGame(t *testing.T) {
+	svc, ts := newTournamentService(t, 5)
+	ctx := t.Context()
+
+	assert.ErrorIs(t, svc.Seed(ctx, 3, true), ErrThirdPlaceTooSmall)
+	assert.NoError(t, svc.Seed(ctx, 5, true))
+
+	b, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &Game{Side: SideThirdPlace, BestOf: 1}, b.ThirdPlace)
+	assert.ErrorIs(t, svc.Advance(ctx, SideThirdPlace, 0, 0, ts[0].ID), ErrGameNotReady)
+
+	// 4 plays 5 for a place in the semifinals; everyone else has a bye.
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 0, 1, ts[3].ID))
+
+	// The semifinal losers go into the third-place game in the order of their semifinals.
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 1, 1, ts[1].ID))
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 1, 0, ts[3].ID))
+
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {ts[2].ID, "team2"}}, b.ThirdPlace.Teams)
+
+	assert.NoError(t, svc.Advance(ctx, SideThirdPlace, 0, 0, ts[2].ID))
+
+	// Neither semifinal can be reverted once the third-place game has been played.
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 1, 0, ts[3].ID), ErrNotFurthestGame)
+	assert.ErrorIs(t, svc.Revert(ctx, SideWinners, 1, 1, ts[1].ID), ErrNotFurthestGame)
+
+	assert.NoError(t, svc.Revert(ctx, SideThirdPlace, 0, 0, ts[2].ID))
+	assert.NoError(t, svc.Revert(ctx, SideWinners, 1, 1, ts[1].ID))
+
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{}, b.ThirdPlace.Winner)
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {}}, b.ThirdPlace.Teams)
+
+	// The other semifinal can go the other way.
+	assert.NoError(t, svc.Advance(ctx, SideWinners, 1, 1, ts[2].ID))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]Team{{ts[0].ID, "team0"}, {ts[1].ID, "team1"}}, b.ThirdPlace.Teams)
+
+	assert.NoError(t, svc.Delete(ctx))
+	assert.NoError(t, svc.Seed(ctx, 4, false))
+	b, err = svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, (*Game)(nil), b.ThirdPlace)
+}
+
+func TestChangesAreNotified(t *testing.T) {
+	svc, ts := newTournamentService(t, 2)
+
+	topic, err := svc.Topic(t.Context())
+	assert.NoError(t, err)
+	sub, done := svc.notifier.Subscribe(topic)
+	defer done()
+
+	assert.NoError(t, svc.Seed(t.Context(), 2, false))
+	ev := <-sub
+	assert.Equal(t, true, ev.Change.Reseeded)
+	assert.Equal(t, BracketTopic(ev.Change.EventID), topic)
+
+	assert.NoError(t, svc.Advance(t.Context(), SideWinners, 0, 0, ts[0].ID))
+	ev = <-sub
+	assert.Equal(t, Change{EventID: ev.Change.EventID, Side: SideWinners, Round: 0, Idx: 0}, ev.Change)
+
+	assert.NoError(t, svc.Delete(t.Context()))
+	ev = <-sub
+	assert.Equal(t, true, ev.Change.Reseeded)
+
+	b, err := svc.Get(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, false, b.Seeded())
+}
+
+func TestConsolation(t *testing.T) {
+	svc, ts := newTournamentService(t, 7)
+	ctx := t.Context()
+	cons := svc.Consolation()
+
+	b, err := cons.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, b.TeamCount)
+	assert.ErrorIs(t, cons.Seed(ctx, 2, false), ErrMainBracketNotSeeded)
+
+	assert.NoError(t, svc.Seed(ctx, 4, false))
+
+	b, err = cons.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, false, b.Seeded())
+	assert.Equal(t, 3, b.TeamCount)
+
+	assert.ErrorIs(t, cons.Seed(ctx, 4, false), ErrNotEnoughTeams)
+	assert.NoError(t, cons.Seed(ctx, 3, false))
+
+	// The consolation bracket is seeded from the teams after the four in the main bracket.
+	b, err = cons.Get(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, b.Rounds, 2)
+	assert.Equal(t, [2]Team{{ts[4].ID, "team4"}, {}}, b.Rounds[0].Games[0].Teams)
+	assert.Equal(t, [2]Team{{ts[5].ID, "team5"}, {ts[6].ID, "team6"}}, b.Rounds[0].Games[1].Teams)
+
+	// Each bracket is run on its own.
+	assert.NoError(t, cons.Advance(ctx, SideWinners, 0, 1, ts[6].ID))
+	assert.ErrorIs(t, svc.Advance(ctx, SideWinners, 0, 1, ts[6].ID), ErrTeamNotInGame)
+
+	main, err := svc.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Team{}, main.Rounds[0].Games[1].Winner)
+
+	// Only the main bracket has finalists.
+	assert.NoError(t, cons.Advance(ctx, SideWinners, 1, 0, ts[6].ID))
+	fs, err := svc.gameRepo.GetAllFinalists(ctx)
+	assert.NoError(t, err)
+	assert.SliceLen(t, fs, 0)
+
+	assert.NoError(t, svc.Delete(ctx))
+	b, err = cons.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, true, b.Seeded())
+	assert.Equal(t, Team{ts[6].ID, "team6"}, b.Rounds[0].Games[1].Winner)
+	assert.Equal(t, Team{ts[6].ID, "team6"}, b.Champion())
+}
+
+func TestConsolation_Topic(t *testing.T) {
+	svc, _ := newTournamentService(t, 4)
+	ctx := t.Context()
+	cons := svc.Consolation()
+
+	mainTopic, err := svc.Topic(ctx)
+	assert.NoError(t, err)
+	consTopic, err := cons.Topic(ctx)
+	assert.NoError(t, err)
+
+	mainSub, mainDone := svc.notifier.Subscribe(mainTopic)
+	defer mainDone()
+	consSub, consDone := svc.notifier.Subscribe(consTopic)
+	defer consDone()
+
+	assert.NoError(t, svc.Seed(ctx, 2, false))
+	<-mainSub
+	assert.NoError(t, cons.Seed(ctx, 2, false))
+	ev := <-consSub
+	assert.Equal(t, true, ev.Change.Reseeded)
+	assert.Equal(t, ConsolationTopic(ev.Change.EventID), consTopic)
+
+	select {
+	case ev := <-mainSub:
+		t.Fatalf("main bracket subscriber got a consolation change: %+v", ev)
+	default:
+	}
+}
@@ -0,0 +1,107 @@
+// Package sse serves server-streaming RPCs as text/event-stream responses. It is the runtime for
+// the handlers generated by protoc-gen-cribbly-sse; see proto/cribbly/v1/sse.proto.
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrorEvent is the event type sent if the stream fails after it has started. Its data is a short,
+// human-readable message. It is deliberately not "error", which EventSource uses for connection
+// errors.
+const ErrorEvent = "stream-error"
+
+// Stream sends messages to the client. Each message is one event whose data is the message encoded
+// as proto JSON.
+type Stream[T proto.Message] struct {
+	w           http.ResponseWriter
+	rc          *http.ResponseController
+	lastEventID string
+}
+
+func (s *Stream[T]) Send(msg T) error {
+	return s.SendWithID("", msg)
+}
+
+// SendWithID sends msg as an event with the given ID, which the client sends back as Last-Event-ID
+// if it has to reconnect.
+func (s *Stream[T]) SendWithID(id string, msg T) error {
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.write(id, "", b)
+}
+
+// LastEventID returns the ID of the last event the client received before reconnecting, or "" on a
+// first connection.
+func (s *Stream[T]) LastEventID() string {
+	return s.lastEventID
+}
+
+func (s *Stream[T]) write(id, event string, data []byte) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(s.w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	if event != "" {
+		if _, err := fmt.Fprintf(s.w, "event: %s\n", event); err != nil {
+			return err
+		}
+	}
+	// protojson never produces newlines in its compact form, so the data always fits on one line.
+	if _, err := fmt.Fprintf(s.w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// NewHandler returns a handler that decodes the request message from the "message" query
+// parameter (proto JSON; the zero message if absent) and then streams whatever fn sends until fn
+// returns or the client disconnects.
+func NewHandler[Req any, Res proto.Message, PReq interface {
+	*Req
+	proto.Message
+}](fn func(context.Context, PReq, *Stream[Res]) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := PReq(new(Req))
+		if msg := r.URL.Query().Get("message"); msg != "" {
+			err := protojson.Unmarshal([]byte(msg), req)
+			if err != nil {
+				http.Error(w, "invalid message: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+
+		stream := &Stream[Res]{
+			w:           w,
+			rc:          http.NewResponseController(w),
+			lastEventID: r.Header.Get("Last-Event-ID"),
+		}
+		// Flush the headers so that the client sees the stream open before the first message.
+		if err := stream.rc.Flush(); err != nil {
+			return
+		}
+
+		err := fn(r.Context(), req, stream)
+		if err == nil || errors.Is(err, context.Canceled) {
+			return
+		}
+
+		slog.Error("sse.error", "error", err, "url", r.URL)
+		_ = stream.write("", ErrorEvent, []byte("the stream failed"))
+	})
+}
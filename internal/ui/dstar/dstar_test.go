@@ -1,6 +1,12 @@
 package dstar
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cszczepaniak/cribbly/internal/notifier"
+)
 
 func TestSendFormatsRequests(t *testing.T) {
 	tests := []struct {
@@ -33,3 +39,29 @@ func TestSendFormatsRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestLastEventID(t *testing.T) {
+	tests := []struct {
+		header string
+		id     notifier.ID
+		ok     bool
+	}{
+		{header: "", ok: false},
+		{header: "1760000000-42", id: notifier.ID{Epoch: 1760000000, Seq: 42}, ok: true},
+		{header: "1760000000-0", id: notifier.ID{Epoch: 1760000000}, ok: true},
+		{header: "42", ok: false},
+		{header: "abc", ok: false},
+	}
+
+	for _, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/stream", nil)
+		if tc.header != "" {
+			r.Header.Set("Last-Event-ID", tc.header)
+		}
+
+		id, ok := LastEventID(r)
+		if id != tc.id || ok != tc.ok {
+			t.Fatalf("header %q: expected (%v, %t), got (%v, %t)", tc.header, tc.id, tc.ok, id, ok)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package dstar
+
+import (
+	"net/http"
+
+	"github.com/starfederation/datastar-go/datastar"
+
+	"github.com/cszczepaniak/cribbly/internal/notifier"
+)
+
+// LastEventID returns the ID of the last event a reconnecting client received, which it sends in
+// the Last-Event-ID header. It returns false on a first connection or if the header isn't an ID we
+// sent.
+func LastEventID(r *http.Request) (notifier.ID, bool) {
+	id, err := notifier.ParseID(r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		return notifier.ID{}, false
+	}
+	return id, true
+}
+
+// WithEventID stamps a patch with a notifier event ID so that the client sends it back in
+// Last-Event-ID if it has to reconnect.
+func WithEventID(id notifier.ID) datastar.PatchElementOption {
+	return datastar.WithPatchElementsEventID(id.String())
+}
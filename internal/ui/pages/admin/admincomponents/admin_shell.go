@@ -10,10 +10,13 @@ type Route string
 
 const (
 	Home      Route = ""
+	Events    Route = "Events"
 	Players   Route = "Players"
 	Teams     Route = "Teams"
 	Divisions Route = "Divisions"
 	Games     Route = "Games"
+	Tables    Route = "Tables"
+	Disputes  Route = "Disputes"
 	RoomCodes Route = "Room Codes"
 	Users     Route = "Users"
 	Profile   Route = "My Profile"
@@ -25,12 +28,18 @@ func (r Route) ToSafeURL() templ.SafeURL {
 		return "/admin/divisions"
 	case Home:
 		return "/admin"
+	case Events:
+		return "/admin/events"
 	case Players:
 		return "/admin/players"
 	case Teams:
 		return "/admin/teams"
 	case Games:
 		return "/admin/games"
+	case Tables:
+		return "/admin/tables"
+	case Disputes:
+		return "/admin/disputes"
 	case RoomCodes:
 		return "/admin/room-codes"
 	case Users:
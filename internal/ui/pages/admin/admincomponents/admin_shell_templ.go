@@ -95,10 +95,13 @@ func adminNavs(selected Route) templ.Component {
 		}
 		ctx = templ.ClearChildren(ctx)
 		for _, targ := range []Route{
+			Events,
 			Players,
 			Teams,
 			Divisions,
 			Games,
+			Tables,
+			Disputes,
 			RoomCodes,
 			Users,
 			Profile,
@@ -134,7 +137,7 @@ func adminNavs(selected Route) templ.Component {
 			var templ_7745c5c3_Var5 string
 			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(string(targ))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/ui/pages/admin/admincomponents/admin_shell.templ`, Line: 41, Col: 17}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/ui/pages/admin/admincomponents/admin_shell.templ`, Line: 44, Col: 17}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 			if templ_7745c5c3_Err != nil {
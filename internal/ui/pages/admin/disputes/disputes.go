@@ -0,0 +1,125 @@
+package disputes
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+type Handler struct {
+	GameRepo games.Repository
+	TeamRepo teams.Repository
+}
+
+// result is one team's version of a disputed game.
+type result struct {
+	teamName   string
+	winnerID   string
+	winnerName string
+	loserScore int
+}
+
+type dispute struct {
+	gameID    string
+	team1Name string
+	team2Name string
+	// entered is the score that was entered first, and counter the other team's version of it.
+	entered result
+	counter result
+}
+
+// Index lists the disputed prelim games. They don't count in the standings until an admin picks
+// one of the teams' scores or edits the game on the games page.
+func (h Handler) Index(w http.ResponseWriter, r *http.Request) error {
+	pending, err := h.GameRepo.GetPendingScores(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var ds []dispute
+	for _, p := range pending {
+		if !p.Disputed() {
+			continue
+		}
+
+		scores, err := h.GameRepo.Get(r.Context(), p.GameID)
+		if err != nil {
+			return err
+		}
+
+		names := make(map[string]string, 2)
+		for _, s := range scores {
+			t, err := h.TeamRepo.Get(r.Context(), s.TeamID)
+			if err != nil {
+				return err
+			}
+			names[t.ID] = t.Name
+		}
+
+		winner, loser := scores[0], scores[1]
+		if loser.Score > winner.Score {
+			winner, loser = loser, winner
+		}
+		counterTeam := scores[0].TeamID
+		if counterTeam == p.ReportedBy {
+			counterTeam = scores[1].TeamID
+		}
+
+		ds = append(ds, dispute{
+			gameID:    p.GameID,
+			team1Name: names[scores[0].TeamID],
+			team2Name: names[scores[1].TeamID],
+			entered: result{
+				teamName:   names[p.ReportedBy],
+				winnerID:   winner.TeamID,
+				winnerName: names[winner.TeamID],
+				loserScore: loser.Score,
+			},
+			counter: result{
+				teamName:   names[counterTeam],
+				winnerID:   p.Dispute.WinnerID,
+				winnerName: names[p.Dispute.WinnerID],
+				loserScore: p.Dispute.LoserScore,
+			},
+		})
+	}
+
+	slices.SortFunc(ds, func(a, b dispute) int {
+		return cmp.Or(cmp.Compare(a.team1Name, b.team1Name), cmp.Compare(a.team2Name, b.team2Name))
+	})
+
+	return index(ds).Render(r.Context(), w)
+}
+
+// Resolve settles a disputed game with the result in the winner and loserScore form values.
+func (h Handler) Resolve(w http.ResponseWriter, r *http.Request) error {
+	gameID := r.PathValue("id")
+	winnerID := r.FormValue("winner")
+
+	loserScore, err := strconv.Atoi(r.FormValue("loserScore"))
+	if err != nil {
+		return err
+	}
+
+	scores, err := h.GameRepo.Get(r.Context(), gameID)
+	if err != nil {
+		return err
+	}
+
+	loserID := scores[0].TeamID
+	if loserID == winnerID {
+		loserID = scores[1].TeamID
+	}
+
+	err = h.GameRepo.UpdateScores(r.Context(), gameID, winnerID, games.WinningScore, loserID, loserScore)
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/disputes", http.StatusFound)
+	return nil
+}
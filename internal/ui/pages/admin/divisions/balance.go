@@ -0,0 +1,138 @@
+package divisions
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	divisionservice "github.com/cszczepaniak/cribbly/internal/service/divisions"
+)
+
+// Balance renders the division generator: team ratings, teams to keep apart, and a preview of the
+// divisions it would create.
+func (h DivisionsHandler) Balance(w http.ResponseWriter, r *http.Request) error {
+	return h.renderBalance(w, r, "")
+}
+
+func (h DivisionsHandler) renderBalance(w http.ResponseWriter, r *http.Request, errMsg string) error {
+	ts, err := h.TeamRepo.GetWithoutDivision(r.Context())
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(ts, func(a, b teams.Team) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	allTeams, err := h.TeamRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+	teamNamesByID := make(map[string]string, len(allTeams))
+	for _, t := range allTeams {
+		teamNamesByID[t.ID] = t.Name
+	}
+
+	apart, err := h.TeamRepo.GetKeepApart(r.Context())
+	if err != nil {
+		return err
+	}
+
+	planned, err := h.DivisionService.Plan(r.Context())
+	if err != nil {
+		if !errors.Is(err, divisionservice.ErrCantKeepApart) {
+			return err
+		}
+		errMsg = cmp.Or(errMsg, err.Error())
+	}
+
+	return balancePage(ts, apart, teamNamesByID, planned, errMsg).Render(r.Context(), w)
+}
+
+// SetRating sets a team's rating from the rating form value.
+func (h DivisionsHandler) SetRating(w http.ResponseWriter, r *http.Request) error {
+	rating, err := strconv.Atoi(r.FormValue("rating"))
+	if err != nil {
+		return h.renderBalance(w, r, "Ratings must be whole numbers.")
+	}
+
+	err = h.TeamRepo.SetRating(r.Context(), r.PathValue("id"), rating)
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/divisions/balance", http.StatusFound)
+	return nil
+}
+
+// KeepApart keeps the teams in the team1 and team2 form values out of the same division.
+func (h DivisionsHandler) KeepApart(w http.ResponseWriter, r *http.Request) error {
+	team1, team2 := r.FormValue("team1"), r.FormValue("team2")
+	if team1 == "" || team2 == "" {
+		return h.renderBalance(w, r, "Choose two teams to keep apart.")
+	}
+
+	err := h.TeamRepo.KeepApart(r.Context(), team1, team2)
+	if err != nil {
+		if errors.Is(err, teams.ErrKeepApartSameTeam) {
+			return h.renderBalance(w, r, "Choose two different teams to keep apart.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/divisions/balance", http.StatusFound)
+	return nil
+}
+
+// AllowTogether removes the constraint keeping the teams in the team1 and team2 form values apart.
+func (h DivisionsHandler) AllowTogether(w http.ResponseWriter, r *http.Request) error {
+	err := h.TeamRepo.AllowTogether(r.Context(), r.FormValue("team1"), r.FormValue("team2"))
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/divisions/balance", http.StatusFound)
+	return nil
+}
+
+// CreateBalanced creates the divisions exactly as they were previewed. The form has a "division"
+// value per division holding its name, and a "team" value per team of the form "<index>:<team ID>",
+// where the index is the team's division.
+func (h DivisionsHandler) CreateBalanced(w http.ResponseWriter, r *http.Request) error {
+	err := r.ParseForm()
+	if err != nil {
+		return err
+	}
+
+	names := r.PostForm["division"]
+	planned := make([]divisionservice.PlannedDivision, len(names))
+	for i, name := range names {
+		planned[i].Name = name
+	}
+
+	for _, v := range r.PostForm["team"] {
+		idx, teamID, ok := strings.Cut(v, ":")
+		if !ok {
+			return errors.New("malformed team value")
+		}
+		i, err := strconv.Atoi(idx)
+		if err != nil || i < 0 || i >= len(planned) {
+			return errors.New("team is in a division that doesn't exist")
+		}
+		planned[i].Teams = append(planned[i].Teams, teams.Team{ID: teamID})
+	}
+
+	err = h.DivisionService.Create(r.Context(), planned)
+	if err != nil {
+		if errors.Is(err, teams.ErrTeamAlreadyInDivision) {
+			return h.renderBalance(w, r, "Some of these teams were put in a division since this preview was made. Check the new preview and try again.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/divisions", http.StatusFound)
+	return nil
+}
@@ -14,7 +14,6 @@ import (
 	qrcode "github.com/skip2/go-qrcode"
 	"github.com/starfederation/datastar-go/datastar"
 
-	"github.com/cszczepaniak/cribbly/internal/fake"
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
 	"github.com/cszczepaniak/cribbly/internal/persistence/divisions"
 	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
@@ -153,49 +152,14 @@ func (h DivisionsHandler) Create(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (h DivisionsHandler) Generate(w http.ResponseWriter, r *http.Request) error {
-	allTeams, err := h.TeamRepo.GetAll(r.Context())
+	planned, err := h.DivisionService.Plan(r.Context())
 	if err != nil {
 		return err
 	}
 
-	for len(allTeams) > 0 {
-		division, err := h.DivisionRepo.Create(r.Context())
-		if err != nil {
-			return err
-		}
-
-		err = h.DivisionRepo.Rename(r.Context(), division.ID, fake.USState())
-		if err != nil {
-			return err
-		}
-
-		// Greedily take 4; use 3, 5, or 6 for the last division to avoid leaving 1 or 2 teams
-		nForThisDivision := 4
-		switch len(allTeams) {
-		case 6:
-			nForThisDivision = 6
-		case 5:
-			nForThisDivision = 5
-		case 3:
-			nForThisDivision = 3
-		}
-
-		forDivision := allTeams[:nForThisDivision]
-		allTeams = allTeams[nForThisDivision:]
-
-		for _, team := range forDivision {
-			err := h.TeamRepo.AssignToDivision(r.Context(), team.ID, division.ID)
-			if err != nil {
-				return err
-			}
-		}
-
-		if nForThisDivision != 4 {
-			err = h.DivisionRepo.UpdateSize(r.Context(), division.ID, nForThisDivision)
-			if err != nil {
-				return err
-			}
-		}
+	err = h.DivisionService.Create(r.Context(), planned)
+	if err != nil {
+		return err
 	}
 
 	sse := datastar.NewSSE(w, r)
@@ -0,0 +1,195 @@
+package events
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	ratingservice "github.com/cszczepaniak/cribbly/internal/service/ratings"
+)
+
+const (
+	dateFormat  = "2006-01-02"
+	archivedMsg = "Archived events' settings can't be changed."
+)
+
+type Handler struct {
+	EventRepo     events.Repository
+	RatingService ratingservice.Service
+}
+
+func (h Handler) Index(w http.ResponseWriter, r *http.Request) error {
+	return h.render(w, r, "")
+}
+
+func (h Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	date, err := time.Parse(dateFormat, r.FormValue("date"))
+	if err != nil {
+		return h.render(w, r, "Event must have a valid date.")
+	}
+
+	_, err = h.EventRepo.Create(r.Context(), r.FormValue("name"), date)
+	if err != nil {
+		if errors.Is(err, events.ErrEventNameMissing) {
+			return h.render(w, r, "Event must have a name.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/events", http.StatusFound)
+	return nil
+}
+
+func (h Handler) Activate(w http.ResponseWriter, r *http.Request) error {
+	err := h.EventRepo.Activate(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, events.ErrEventArchived) {
+			return h.render(w, r, "Archived events can't be made active.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/events", http.StatusFound)
+	return nil
+}
+
+func (h Handler) Archive(w http.ResponseWriter, r *http.Request) error {
+	err := h.EventRepo.Archive(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, events.ErrCannotArchive) {
+			return h.render(w, r, "The active event can't be archived. Switch to another event first.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/events", http.StatusFound)
+	return nil
+}
+
+// RecomputeRatings replays every event's games to bring player ratings up to date.
+func (h Handler) RecomputeRatings(w http.ResponseWriter, r *http.Request) error {
+	err := h.RatingService.Recompute(r.Context())
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/events", http.StatusFound)
+	return nil
+}
+
+func (h Handler) SetPoints(w http.ResponseWriter, r *http.Request) error {
+	var p events.PointScheme
+	for name, dst := range map[string]*int{
+		"win":            &p.Win,
+		"skunkWin":       &p.SkunkWin,
+		"doubleSkunkWin": &p.DoubleSkunkWin,
+		"loss":           &p.Loss,
+	} {
+		n, err := strconv.Atoi(r.FormValue(name))
+		if err != nil {
+			return h.render(w, r, "Game points must be whole numbers.")
+		}
+		*dst = n
+	}
+
+	err := h.EventRepo.SetPoints(r.Context(), r.PathValue("id"), p)
+	if err != nil {
+		if errors.Is(err, events.ErrEventArchived) {
+			return h.render(w, r, archivedMsg)
+		}
+		if errors.Is(err, events.ErrInvalidPoints) {
+			return h.render(w, r, "Game points can't be negative, and every win must be worth more than a loss.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/events", http.StatusFound)
+	return nil
+}
+
+func (h Handler) SetSwissRounds(w http.ResponseWriter, r *http.Request) error {
+	rounds, err := strconv.Atoi(r.FormValue("rounds"))
+	if err != nil {
+		return h.render(w, r, "The number of Swiss rounds must be a whole number.")
+	}
+
+	err = h.EventRepo.SetSwissRounds(r.Context(), r.PathValue("id"), rounds)
+	if err != nil {
+		if errors.Is(err, events.ErrEventArchived) {
+			return h.render(w, r, archivedMsg)
+		}
+		if errors.Is(err, events.ErrInvalidSwissRounds) {
+			return h.render(w, r, "The number of Swiss rounds can't be negative.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/events", http.StatusFound)
+	return nil
+}
+
+func (h Handler) SetTiebreakers(w http.ResponseWriter, r *http.Request) error {
+	err := r.ParseForm()
+	if err != nil {
+		return err
+	}
+
+	// Each position in the form is a select; positions left on "None" are skipped.
+	var ts []events.Tiebreaker
+	for _, v := range r.PostForm["tiebreaker"] {
+		if v != "" {
+			ts = append(ts, events.Tiebreaker(v))
+		}
+	}
+
+	err = h.EventRepo.SetTiebreakers(r.Context(), r.PathValue("id"), ts)
+	if err != nil {
+		if errors.Is(err, events.ErrEventArchived) {
+			return h.render(w, r, archivedMsg)
+		}
+		if errors.Is(err, events.ErrInvalidTiebreaks) {
+			return h.render(w, r, "Each tiebreaker can only be used once.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/events", http.StatusFound)
+	return nil
+}
+
+// SetConfirmScores turns score confirmation on or off, as given by the confirm form value.
+func (h Handler) SetConfirmScores(w http.ResponseWriter, r *http.Request) error {
+	confirm, err := strconv.ParseBool(r.FormValue("confirm"))
+	if err != nil {
+		return err
+	}
+
+	err = h.EventRepo.SetConfirmScores(r.Context(), r.PathValue("id"), confirm)
+	if err != nil {
+		if errors.Is(err, events.ErrEventArchived) {
+			return h.render(w, r, archivedMsg)
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/events", http.StatusFound)
+	return nil
+}
+
+func (h Handler) render(w http.ResponseWriter, r *http.Request, errMsg string) error {
+	es, err := h.EventRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return index(es, errMsg).Render(r.Context(), w)
+}
+
+func onOff(on bool) string {
+	if on {
+		return "On"
+	}
+	return "Off"
+}
@@ -0,0 +1,245 @@
+package events
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+func newHandler(t *testing.T) (Handler, events.Repository) {
+	t.Helper()
+
+	db := database.NewInMemory(t)
+	repo := events.NewRepository(db)
+
+	return Handler{EventRepo: repo}, repo
+}
+
+func newServer(t *testing.T, h Handler) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	for route, fn := range map[string]func(http.ResponseWriter, *http.Request) error{
+		"GET /admin/events":                      h.Index,
+		"POST /admin/events":                     h.Create,
+		"POST /admin/events/{id}/activate":       h.Activate,
+		"POST /admin/events/{id}/archive":        h.Archive,
+		"POST /admin/events/{id}/points":         h.SetPoints,
+		"POST /admin/events/{id}/tiebreakers":    h.SetTiebreakers,
+		"POST /admin/events/{id}/confirm-scores": h.SetConfirmScores,
+	} {
+		mux.HandleFunc(route, func(w http.ResponseWriter, r *http.Request) {
+			if err := fn(w, r); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		})
+	}
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func noRedirectClient() *http.Client {
+	return &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// Return the first response without following redirects.
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func TestCreateActivateAndArchive(t *testing.T) {
+	h, repo := newHandler(t)
+	srv := newServer(t, h)
+	client := noRedirectClient()
+
+	resp, err := client.PostForm(srv.URL+"/admin/events", url.Values{
+		"name": {"2026"},
+		"date": {"2026-07-04"},
+	})
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+	es, err := repo.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, es, 2)
+
+	var created events.Event
+	for _, e := range es {
+		if e.Name == "2026" {
+			created = e
+		}
+	}
+	assert.Equal(t, "2026-07-04", created.Date.Format(dateFormat))
+
+	resp, err = client.Post(srv.URL+"/admin/events/"+created.ID+"/activate", "", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+	resp, err = client.Post(srv.URL+"/admin/events/"+events.LegacyID+"/archive", "", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+	active, err := repo.GetActive(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, active.ID)
+
+	legacy, err := repo.Get(t.Context(), events.LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, true, legacy.Archived())
+
+	resp, err = client.PostForm(srv.URL+"/admin/events/"+events.LegacyID+"/points", url.Values{
+		"win":            {"1"},
+		"skunkWin":       {"2"},
+		"doubleSkunkWin": {"3"},
+		"loss":           {"0"},
+	})
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, strings.Contains(string(body), "settings can&#39;t be changed"))
+}
+
+func TestCreateRequiresNameAndDate(t *testing.T) {
+	h, repo := newHandler(t)
+	srv := newServer(t, h)
+
+	for _, form := range []url.Values{
+		{"name": {""}, "date": {"2026-07-04"}},
+		{"name": {"2026"}, "date": {"not a date"}},
+	} {
+		resp, err := noRedirectClient().PostForm(srv.URL+"/admin/events", form)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		// The page is re-rendered with an error instead of redirecting.
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	es, err := repo.GetAll(t.Context())
+	assert.NoError(t, err)
+	assert.SliceLen(t, es, 1)
+}
+
+func TestArchiveActiveEventShowsError(t *testing.T) {
+	h, _ := newHandler(t)
+	srv := newServer(t, h)
+
+	resp, err := noRedirectClient().Post(srv.URL+"/admin/events/"+events.LegacyID+"/archive", "", nil)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	if !strings.Contains(string(body), "can&#39;t be archived") {
+		t.Fatalf("expected an error message, got:\n%s", body)
+	}
+}
+
+func TestSetPoints(t *testing.T) {
+	h, repo := newHandler(t)
+	srv := newServer(t, h)
+	client := noRedirectClient()
+
+	resp, err := client.PostForm(srv.URL+"/admin/events/legacy/points", url.Values{
+		"win":            {"1"},
+		"skunkWin":       {"2"},
+		"doubleSkunkWin": {"3"},
+		"loss":           {"0"},
+	})
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+	e, err := repo.Get(t.Context(), events.LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, events.PointScheme{Win: 1, SkunkWin: 2, DoubleSkunkWin: 3, Loss: 0}, e.Points)
+
+	for _, form := range []url.Values{
+		{"win": {"x"}, "skunkWin": {"2"}, "doubleSkunkWin": {"3"}, "loss": {"0"}},
+		{"win": {"1"}, "skunkWin": {"2"}, "doubleSkunkWin": {"3"}, "loss": {"1"}},
+	} {
+		resp, err := client.PostForm(srv.URL+"/admin/events/legacy/points", form)
+		assert.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, true, strings.Contains(string(body), "Game points"))
+	}
+
+	e, err = repo.Get(t.Context(), events.LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, e.Points.Win)
+}
+
+func TestSetTiebreakers(t *testing.T) {
+	h, repo := newHandler(t)
+	srv := newServer(t, h)
+	client := noRedirectClient()
+
+	resp, err := client.PostForm(srv.URL+"/admin/events/legacy/tiebreakers", url.Values{
+		"tiebreaker": {"skunk-differential", "", "head-to-head", ""},
+	})
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+	e, err := repo.Get(t.Context(), events.LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, []events.Tiebreaker{events.TiebreakSkunkDifferential, events.TiebreakHeadToHead}, e.Tiebreakers)
+
+	resp, err = client.PostForm(srv.URL+"/admin/events/legacy/tiebreakers", url.Values{
+		"tiebreaker": {"point-spread", "point-spread"},
+	})
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, strings.Contains(string(body), "only be used once"))
+
+	e, err = repo.Get(t.Context(), events.LegacyID)
+	assert.NoError(t, err)
+	assert.SliceLen(t, e.Tiebreakers, 2)
+}
+
+func TestSetConfirmScores(t *testing.T) {
+	h, repo := newHandler(t)
+	srv := newServer(t, h)
+	client := noRedirectClient()
+
+	resp, err := client.PostForm(srv.URL+"/admin/events/legacy/confirm-scores", url.Values{
+		"confirm": {"true"},
+	})
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+	e, err := repo.Get(t.Context(), events.LegacyID)
+	assert.NoError(t, err)
+	assert.Equal(t, true, e.ConfirmScores)
+
+	resp, err = client.Get(srv.URL + "/admin/events")
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, true, strings.Contains(string(body), "Turn Off"))
+}
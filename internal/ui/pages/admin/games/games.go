@@ -4,6 +4,8 @@ import (
 	"cmp"
 	"context"
 	"errors"
+	"fmt"
+	"maps"
 	"net/http"
 	"slices"
 	"strconv"
@@ -12,8 +14,11 @@ import (
 
 	"github.com/cszczepaniak/cribbly/internal/persistence/database"
 	"github.com/cszczepaniak/cribbly/internal/persistence/divisions"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/tables"
 	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	"github.com/cszczepaniak/cribbly/internal/service/schedule"
 	"github.com/cszczepaniak/cribbly/internal/ui/components"
 )
 
@@ -22,6 +27,8 @@ type Handler struct {
 	DivisionRepo divisions.Repository
 	TeamRepo     teams.Repository
 	GameRepo     games.Repository
+	TableRepo    tables.Repository
+	EventRepo    events.Repository
 }
 
 func (h Handler) Index(w http.ResponseWriter, r *http.Request) error {
@@ -30,7 +37,109 @@ func (h Handler) Index(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	return index(gs).Render(r.Context(), w)
+	points, err := h.GameRepo.PointScheme(r.Context())
+	if err != nil {
+		return err
+	}
+
+	divs, err := h.DivisionRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(divs, func(a, b divisions.Division) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	e, err := h.currentEvent(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return index(gs, points, divs, e).Render(r.Context(), w)
+}
+
+func (h Handler) currentEvent(ctx context.Context) (events.Event, error) {
+	eventID, err := h.GameRepo.CurrentEventID(ctx)
+	if err != nil {
+		return events.Event{}, err
+	}
+	return h.EventRepo.Get(ctx, eventID)
+}
+
+// GenerateSwissRound pairs the next round of a Swiss prelim from the current standings.
+func (h Handler) GenerateSwissRound(w http.ResponseWriter, r *http.Request) error {
+	err := h.generateSwissRound(r.Context())
+	if errors.Is(err, errRoundInProgress) ||
+		errors.Is(err, errSwissFinished) ||
+		errors.Is(err, schedule.ErrTooFewTeams) ||
+		errors.Is(err, schedule.ErrNotEnoughTables) {
+		return components.ShowErrorToast(w, r, fmt.Sprintf("Couldn't generate the next round: %v.", err))
+	}
+	if err != nil {
+		return err
+	}
+
+	return datastar.NewSSE(w, r).Redirect("/admin/games")
+}
+
+// PairDivisions pairs a division with the one in the crossoverWith form value for crossover games,
+// or unpairs it if that's empty.
+func (h Handler) PairDivisions(w http.ResponseWriter, r *http.Request) error {
+	err := h.DivisionRepo.PairForCrossover(r.Context(), r.PathValue("id"), r.FormValue("crossoverWith"))
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/games", http.StatusFound)
+	return nil
+}
+
+func (h Handler) GenerateCrossover(w http.ResponseWriter, r *http.Request) error {
+	var signals struct {
+		GamesPerTeam string `json:"crossoverGamesPerTeam"`
+		Rule         string `json:"crossoverRule"`
+	}
+	err := datastar.ReadSignals(r, &signals)
+	if err != nil {
+		return err
+	}
+
+	gamesPerTeam := 1
+	if signals.GamesPerTeam != "" {
+		gamesPerTeam, err = strconv.Atoi(signals.GamesPerTeam)
+		if err != nil || gamesPerTeam < 1 {
+			return components.ShowErrorToast(w, r, "Games per team must be a positive number.")
+		}
+	}
+
+	rule := schedule.CrossoverRule(signals.Rule)
+	if !slices.Contains(schedule.CrossoverRules, rule) {
+		rule = schedule.CrossoverByStanding
+	}
+
+	err = h.generateCrossoverGames(r.Context(), gamesPerTeam, rule)
+	if errors.Is(err, errNoCrossoverPairs) ||
+		errors.Is(err, schedule.ErrUnevenGames) ||
+		errors.Is(err, schedule.ErrTooFewTeams) ||
+		errors.Is(err, schedule.ErrNotEnoughTables) {
+		return components.ShowErrorToast(w, r, fmt.Sprintf("Couldn't generate crossover games: %v.", err))
+	}
+	if err != nil {
+		return err
+	}
+
+	gs, err := h.getAllGames(r.Context())
+	if err != nil {
+		return err
+	}
+
+	points, err := h.GameRepo.PointScheme(r.Context())
+	if err != nil {
+		return err
+	}
+
+	sse := datastar.NewSSE(w, r)
+	return sse.PatchElementTempl(gameList(gs, points))
 }
 
 func (h Handler) DeleteAll(w http.ResponseWriter, r *http.Request) error {
@@ -43,7 +152,28 @@ func (h Handler) DeleteAll(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (h Handler) Generate(w http.ResponseWriter, r *http.Request) error {
-	err := h.generatePrelimGames(r.Context())
+	var signals struct {
+		GamesPerTeam string `json:"gamesPerTeam"`
+	}
+	err := datastar.ReadSignals(r, &signals)
+	if err != nil {
+		return err
+	}
+
+	var gamesPerTeam int
+	if signals.GamesPerTeam != "" {
+		gamesPerTeam, err = strconv.Atoi(signals.GamesPerTeam)
+		if err != nil || gamesPerTeam < 1 {
+			return components.ShowErrorToast(w, r, "Games per team must be a positive number.")
+		}
+	}
+
+	err = h.generatePrelimGames(r.Context(), gamesPerTeam)
+	if errors.Is(err, schedule.ErrUnevenGames) ||
+		errors.Is(err, schedule.ErrTooFewTeams) ||
+		errors.Is(err, schedule.ErrNotEnoughTables) {
+		return components.ShowErrorToast(w, r, fmt.Sprintf("Couldn't generate games: %v.", err))
+	}
 	if err != nil {
 		return err
 	}
@@ -53,8 +183,13 @@ func (h Handler) Generate(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	points, err := h.GameRepo.PointScheme(r.Context())
+	if err != nil {
+		return err
+	}
+
 	sse := datastar.NewSSE(w, r)
-	return sse.PatchElementTempl(gameList(gs))
+	return sse.PatchElementTempl(gameList(gs, points))
 }
 
 type gamesSignal struct {
@@ -129,8 +264,13 @@ func (h Handler) Save(w http.ResponseWriter, r *http.Request) error {
 	game.Team1Score = team1Score
 	game.Team2Score = team2Score
 
+	points, err := h.GameRepo.PointScheme(r.Context())
+	if err != nil {
+		return err
+	}
+
 	sse := datastar.NewSSE(w, r)
-	return sse.PatchElementTempl(gameRow(game))
+	return sse.PatchElementTempl(gameRow(game, points))
 }
 
 func (h Handler) ResetScores(w http.ResponseWriter, r *http.Request) error {
@@ -152,12 +292,20 @@ func (h Handler) ResetScores(w http.ResponseWriter, r *http.Request) error {
 	game.Team1Score = 0
 	game.Team2Score = 0
 
-	return datastar.NewSSE(w, r).PatchElementTempl(gameRow(game))
+	points, err := h.GameRepo.PointScheme(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return datastar.NewSSE(w, r).PatchElementTempl(gameRow(game, points))
 }
 
 type game struct {
 	GameID       string
 	DivisionName string
+	Round        int
+	TableName    string
+	Crossover    bool
 	Team1ID      string
 	Team1Name    string
 	Team1Score   int
@@ -166,7 +314,11 @@ type game struct {
 	Team2Score   int
 }
 
-func (h Handler) generatePrelimGames(ctx context.Context) error {
+// generatePrelimGames schedules every division's games. Rounds line up across divisions, so round 1
+// of every division can be played at the same time. If gamesPerTeam is 0, each division plays a
+// default number of games for its size. If tables have been set up, every game of a round is given
+// its own table.
+func (h Handler) generatePrelimGames(ctx context.Context, gamesPerTeam int) error {
 	return h.Transactor.WithTx(ctx, func(ctx context.Context) error {
 		allTeams, err := h.TeamRepo.GetAll(ctx)
 		if err != nil {
@@ -181,69 +333,339 @@ func (h Handler) generatePrelimGames(ctx context.Context) error {
 			teamsByDivision[team.DivisionID] = append(teamsByDivision[team.DivisionID], team)
 		}
 
-		// TODO: don't insert one-by-one (but at least we're in a transaction now!)
-		for _, teams := range teamsByDivision {
-			pairs, err := generateMatchups(teams)
+		divs, err := h.DivisionRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		clusterByDivision := make(map[string]string, len(divs))
+		for _, d := range divs {
+			clusterByDivision[d.ID] = d.TableCluster
+		}
+
+		// Gather every division's games by round so each round's tables are handed out together.
+		var rounds [][]scheduledGame
+		for _, divisionID := range slices.Sorted(maps.Keys(teamsByDivision)) {
+			divRounds, err := generateMatchups(teamsByDivision[divisionID], gamesPerTeam)
 			if err != nil {
 				return err
 			}
 
-			for _, pair := range pairs {
-				_, err := h.GameRepo.Create(ctx, pair[0].ID, pair[1].ID)
+			for i, pairs := range divRounds {
+				if i == len(rounds) {
+					rounds = append(rounds, nil)
+				}
+				for _, pair := range pairs {
+					rounds[i] = append(rounds[i], scheduledGame{
+						cluster: clusterByDivision[divisionID],
+						teams:   pair,
+					})
+				}
+			}
+		}
+
+		return h.createRounds(ctx, 1, rounds)
+	})
+}
+
+type scheduledGame struct {
+	cluster   string
+	teams     [2]teams.Team
+	crossover bool
+}
+
+// createRounds creates the games of each round, numbering the rounds from firstRound. If tables have
+// been set up, every game of a round is given its own table.
+func (h Handler) createRounds(ctx context.Context, firstRound int, rounds [][]scheduledGame) error {
+	allTables, err := h.TableRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	scheduleTables := make([]schedule.Table, 0, len(allTables))
+	for _, t := range allTables {
+		scheduleTables = append(scheduleTables, schedule.Table{ID: t.ID, Cluster: t.Cluster})
+	}
+
+	// TODO: don't insert one-by-one (but at least we're in a transaction now!)
+	for i, games := range rounds {
+		round := firstRound + i
+
+		var tableIDs []string
+		if len(scheduleTables) > 0 {
+			clusters := make([]string, 0, len(games))
+			for _, g := range games {
+				clusters = append(clusters, g.cluster)
+			}
+			tableIDs, err = schedule.AssignTables(clusters, scheduleTables)
+			if err != nil {
+				return fmt.Errorf("round %d: %w", round, err)
+			}
+		}
+
+		for j, g := range games {
+			create := h.GameRepo.CreateInRound
+			if g.crossover {
+				create = h.GameRepo.CreateCrossover
+			}
+			id, err := create(ctx, round, g.teams[0].ID, g.teams[1].ID)
+			if err != nil {
+				return err
+			}
+
+			if tableIDs != nil {
+				err := h.GameRepo.SetTable(ctx, id, tableIDs[j])
 				if err != nil {
 					return err
 				}
 			}
 		}
+	}
+
+	return nil
+}
+
+var errNoCrossoverPairs = errors.New("no divisions are paired for crossover games")
 
-		return nil
+// generateCrossoverGames schedules gamesPerTeam crossover games for every pair of divisions, after
+// the rounds that have already been scheduled. Each division's teams are ranked by the current
+// standings and matched up with the paired division's according to rule.
+func (h Handler) generateCrossoverGames(ctx context.Context, gamesPerTeam int, rule schedule.CrossoverRule) error {
+	return h.Transactor.WithTx(ctx, func(ctx context.Context) error {
+		divs, err := h.DivisionRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		divisionsByID := make(map[string]divisions.Division, len(divs))
+		for _, d := range divs {
+			divisionsByID[d.ID] = d
+		}
+
+		allTeams, err := h.TeamRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		teamsByDivision := make(map[string][]teams.Team)
+		for _, team := range allTeams {
+			teamsByDivision[team.DivisionID] = append(teamsByDivision[team.DivisionID], team)
+		}
+
+		standings, err := h.GameRepo.GetStandings(ctx)
+		if err != nil {
+			return err
+		}
+		rankByTeam := make(map[string]int, len(standings))
+		for i, st := range standings {
+			rankByTeam[st.TeamID] = i
+		}
+
+		scores, err := h.GameRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		lastRound := 0
+		for _, s := range scores {
+			lastRound = max(lastRound, s.Round)
+		}
+
+		slices.SortFunc(divs, func(a, b divisions.Division) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+
+		var rounds [][]scheduledGame
+		paired := make(map[string]bool)
+		for _, d := range divs {
+			other, ok := divisionsByID[d.CrossoverDivisionID]
+			if !ok || paired[d.ID] {
+				continue
+			}
+			paired[d.ID], paired[other.ID] = true, true
+
+			teams1 := rankTeams(teamsByDivision[d.ID], rankByTeam)
+			teams2 := rankTeams(teamsByDivision[other.ID], rankByTeam)
+			if len(teams1) != len(teams2) {
+				return fmt.Errorf(
+					"%w: %s has %d teams but %s has %d",
+					schedule.ErrUnevenGames, d.Name, len(teams1), other.Name, len(teams2),
+				)
+			}
+
+			pairRounds, err := schedule.Crossover(len(teams1), gamesPerTeam, rule)
+			if err != nil {
+				return err
+			}
+
+			// Only pin the games to a cluster if both divisions play there.
+			var cluster string
+			if d.TableCluster == other.TableCluster {
+				cluster = d.TableCluster
+			}
+
+			for i, r := range pairRounds {
+				if i == len(rounds) {
+					rounds = append(rounds, nil)
+				}
+				for _, g := range r.Games {
+					rounds[i] = append(rounds[i], scheduledGame{
+						cluster:   cluster,
+						teams:     [2]teams.Team{teams1[g[0]], teams2[g[1]]},
+						crossover: true,
+					})
+				}
+			}
+		}
+		if len(rounds) == 0 {
+			return errNoCrossoverPairs
+		}
+
+		return h.createRounds(ctx, lastRound+1, rounds)
 	})
 }
 
-// generateMatchups returns pairings so every team plays the same number of games (fair for
-// standings). 3 teams → 2 games each; 4 → 3 each; 5 → 4 each; 6 → 3 each. Only 3–6 teams allowed.
-func generateMatchups(allTeams []teams.Team) ([][2]teams.Team, error) {
-	switch n := len(allTeams); n {
-	case 3, 4, 5:
-		// Full round robin: all pairs once. 2, 3, or 4 games per team.
-		var pairs [][2]teams.Team
-		for i := range n {
-			for j := i + 1; j < n; j++ {
-				pairs = append(pairs, [2]teams.Team{allTeams[i], allTeams[j]})
+var (
+	errRoundInProgress = errors.New("every game of the current round must be finished first")
+	errSwissFinished   = errors.New("every Swiss round has been played")
+)
+
+// generateSwissRound pairs the next round of the event's Swiss prelim. Teams are ranked by the
+// current standings, so every game of the round before has to be decided first.
+func (h Handler) generateSwissRound(ctx context.Context) error {
+	return h.Transactor.WithTx(ctx, func(ctx context.Context) error {
+		e, err := h.currentEvent(ctx)
+		if err != nil {
+			return err
+		}
+
+		scores, err := h.GameRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		scoresByGame := make(map[string][]games.Score)
+		lastRound := 0
+		for _, s := range scores {
+			scoresByGame[s.GameID] = append(scoresByGame[s.GameID], s)
+			lastRound = max(lastRound, s.Round)
+		}
+
+		byes, err := h.GameRepo.GetByes(ctx)
+		if err != nil {
+			return err
+		}
+		hadBye := make(map[string]bool, len(byes))
+		for _, b := range byes {
+			hadBye[b.TeamID] = true
+			lastRound = max(lastRound, b.Round)
+		}
+
+		if lastRound >= e.SwissRounds {
+			return errSwissFinished
+		}
+
+		played := make(map[[2]string]bool)
+		for _, ss := range scoresByGame {
+			if len(ss) != 2 {
+				return errors.New("dev error: each game should have two scores")
+			}
+			if ss[0].Round == lastRound && ss[0].Score == 0 && ss[1].Score == 0 {
+				return fmt.Errorf("%w (round %d)", errRoundInProgress, lastRound)
 			}
+			played[[2]string{ss[0].TeamID, ss[1].TeamID}] = true
+			played[[2]string{ss[1].TeamID, ss[0].TeamID}] = true
+		}
+
+		standings, err := h.GameRepo.GetStandings(ctx)
+		if err != nil {
+			return err
 		}
-		if n == 3 {
-			// For 3-team divisions, double the games so everyone plays 4.
-			pairs = slices.Concat(pairs, pairs)
+		rankByTeam := make(map[string]int, len(standings))
+		for i, st := range standings {
+			rankByTeam[st.TeamID] = i
 		}
-		return pairs, nil
-	case 6:
-		// Circle method: 3 games per team = 9 games, no duplicate pairings.
-		//
-		// Imagine 6 teams sitting in a circle. Team 0 stays fixed; teams 1–5 sit in order around
-		// the rest. Each round we pair "across": (0,5), (1,4), (2,3). Then we rotate teams 1–5
-		// one position (e.g. 1->2->3->4->5->1) and repeat. After 3 rounds every team has played 3 games
-		// and no pair has met twice.
-		others := []int{1, 2, 3, 4, 5} // indices of the rotating teams (0 is fixed)
-		var pairs [][2]teams.Team
-		for r := range 3 {
-			// Build this round's seating order: 0 fixed, then others rotated by r positions.
-			// Round 0: [0,1,2,3,4,5]. Round 1: [0,5,1,2,3,4]. Round 2: [0,4,5,1,2,3].
-			order := make([]int, 6)
-			order[0] = 0
-			for i := range 5 {
-				order[i+1] = others[(i-r+5)%5]
+
+		allTeams, err := h.TeamRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		ranked := rankTeams(allTeams, rankByTeam)
+
+		round, err := schedule.Swiss(
+			len(ranked),
+			func(a, b int) bool { return played[[2]string{ranked[a].ID, ranked[b].ID}] },
+			func(i int) bool { return hadBye[ranked[i].ID] },
+		)
+		if err != nil {
+			return err
+		}
+
+		next := lastRound + 1
+		if round.Bye >= 0 {
+			err := h.GameRepo.GiveBye(ctx, next, ranked[round.Bye].ID)
+			if err != nil {
+				return err
 			}
-			// Pair across the circle: first with last, second with second-last, third with third-last.
-			pairs = append(pairs,
-				[2]teams.Team{allTeams[order[0]], allTeams[order[5]]},
-				[2]teams.Team{allTeams[order[1]], allTeams[order[4]]},
-				[2]teams.Team{allTeams[order[2]], allTeams[order[3]]},
-			)
-		}
-		return pairs, nil
+		}
+
+		gs := make([]scheduledGame, 0, len(round.Games))
+		for _, g := range round.Games {
+			gs = append(gs, scheduledGame{teams: [2]teams.Team{ranked[g[0]], ranked[g[1]]}})
+		}
+		return h.createRounds(ctx, next, [][]scheduledGame{gs})
+	})
+}
+
+// rankTeams orders a division's teams by their place in the standings. Teams that haven't played
+// yet go last, by name.
+func rankTeams(ts []teams.Team, rankByTeam map[string]int) []teams.Team {
+	ts = slices.Clone(ts)
+	slices.SortFunc(ts, func(a, b teams.Team) int {
+		rankA, okA := rankByTeam[a.ID]
+		rankB, okB := rankByTeam[b.ID]
+		switch {
+		case okA && okB:
+			return cmp.Compare(rankA, rankB)
+		case okA:
+			return -1
+		case okB:
+			return 1
+		default:
+			return cmp.Compare(a.Name, b.Name)
+		}
+	})
+	return ts
+}
+
+// generateMatchups returns a division's games grouped into rounds, in the order they should be
+// played. Every team plays the same number of games (fair for standings): gamesPerTeam if it's set,
+// otherwise a default for the division's size.
+func generateMatchups(allTeams []teams.Team, gamesPerTeam int) ([][][2]teams.Team, error) {
+	if gamesPerTeam == 0 {
+		gamesPerTeam = defaultGamesPerTeam(len(allTeams))
+	}
+
+	rounds, err := schedule.RoundRobin(len(allTeams), gamesPerTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][][2]teams.Team, 0, len(rounds))
+	for _, r := range rounds {
+		pairs := make([][2]teams.Team, 0, len(r.Games))
+		for _, g := range r.Games {
+			pairs = append(pairs, [2]teams.Team{allTeams[g[0]], allTeams[g[1]]})
+		}
+		res = append(res, pairs)
+	}
+	return res, nil
+}
+
+// defaultGamesPerTeam is a full round robin, except that 3-team divisions play it twice so everyone
+// gets 4 games, and 6-team divisions play 3 games each instead of 5.
+func defaultGamesPerTeam(n int) int {
+	switch n {
+	case 3:
+		return 4
+	case 6:
+		return 3
 	default:
-		return nil, errors.New("can only generate matchups for 3, 4, 5, or 6 teams")
+		return n - 1
 	}
 }
 
@@ -279,6 +701,15 @@ func (h Handler) getAllGames(ctx context.Context) ([]game, error) {
 		divisionsByID[d.ID] = d
 	}
 
+	daTables, err := h.TableRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tableNamesByID := make(map[string]string, len(daTables))
+	for _, t := range daTables {
+		tableNamesByID[t.ID] = t.Name
+	}
+
 	var daGames []game
 	for gameID, scores := range scoresByGame {
 		if len(scores) != 2 {
@@ -293,18 +724,32 @@ func (h Handler) getAllGames(ctx context.Context) ([]game, error) {
 			return nil, errors.New("team not found")
 		}
 
-		if team1.DivisionID != team2.DivisionID {
-			return nil, errors.New("should not have inter-division games")
+		// Teams of a Swiss prelim aren't in divisions.
+		division1, ok := divisionsByID[team1.DivisionID]
+		if !ok && team1.DivisionID != "" {
+			return nil, errors.New("division not found")
 		}
+		divisionName := division1.Name
 
-		division, ok := divisionsByID[team1.DivisionID]
-		if !ok {
-			return nil, errors.New("division not found")
+		if team1.DivisionID != team2.DivisionID {
+			if !scores[0].Crossover {
+				return nil, errors.New("only crossover games should be between divisions")
+			}
+			division2, ok := divisionsByID[team2.DivisionID]
+			if !ok {
+				return nil, errors.New("division not found")
+			}
+			names := []string{division1.Name, division2.Name}
+			slices.Sort(names)
+			divisionName = fmt.Sprintf("%s vs. %s", names[0], names[1])
 		}
 
 		g := game{
 			GameID:       gameID,
-			DivisionName: division.Name,
+			DivisionName: divisionName,
+			Crossover:    scores[0].Crossover,
+			Round:        scores[0].Round,
+			TableName:    tableNamesByID[scores[0].TableID],
 			Team1ID:      team1.ID,
 			Team1Name:    team1.Name,
 			Team1Score:   scores[0].Score,
@@ -317,7 +762,10 @@ func (h Handler) getAllGames(ctx context.Context) ([]game, error) {
 
 	slices.SortFunc(daGames, func(a, b game) int {
 		return cmp.Or(
+			// Crossover games come after every division's own games.
+			compareBool(a.Crossover, b.Crossover),
 			cmp.Compare(a.DivisionName, b.DivisionName),
+			cmp.Compare(a.Round, b.Round),
 			cmp.Compare(a.Team1Name, b.Team1Name),
 			cmp.Compare(a.Team2Name, b.Team2Name),
 		)
@@ -325,3 +773,14 @@ func (h Handler) getAllGames(ctx context.Context) ([]game, error) {
 
 	return daGames, nil
 }
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cszczepaniak/gotest/assert"
 
 	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	"github.com/cszczepaniak/cribbly/internal/service/schedule"
 )
 
 func TestGeneratePairs_4(t *testing.T) {
@@ -21,8 +22,9 @@ func TestGeneratePairs_4(t *testing.T) {
 	}, {
 		ID: "4",
 	}}
-	pairs, err := generateMatchups(allTeams)
+	rounds, err := generateMatchups(allTeams, 0)
 	assert.NoError(t, err)
+	pairs := slices.Concat(rounds...)
 	assertEachTeamPlaysNGames(t, allTeams, pairs, 3)
 
 	assert.Equal(t, []string{
@@ -37,8 +39,9 @@ func TestGeneratePairs_4(t *testing.T) {
 
 func TestGeneratePairs_3(t *testing.T) {
 	allTeams := []teams.Team{{ID: "1"}, {ID: "2"}, {ID: "3"}}
-	pairs, err := generateMatchups(allTeams)
+	rounds, err := generateMatchups(allTeams, 0)
 	assert.NoError(t, err)
+	pairs := slices.Concat(rounds...)
 	assertEachTeamPlaysNGames(t, allTeams, pairs, 4)
 	// Full round robin: 3 games total, 2 per team.
 	assert.Equal(t, []string{"1,2", "1,2", "1,3", "1,3", "2,3", "2,3"}, pairsToStrings(pairs))
@@ -46,8 +49,9 @@ func TestGeneratePairs_3(t *testing.T) {
 
 func TestGeneratePairs_5(t *testing.T) {
 	allTeams := []teams.Team{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}, {ID: "5"}}
-	pairs, err := generateMatchups(allTeams)
+	rounds, err := generateMatchups(allTeams, 0)
 	assert.NoError(t, err)
+	pairs := slices.Concat(rounds...)
 	assertEachTeamPlaysNGames(t, allTeams, pairs, 4)
 	// Full round robin: 10 games, 4 per team.
 	assert.Equal(t, []string{
@@ -72,8 +76,9 @@ func TestGeneratePairs_6(t *testing.T) {
 	}, {
 		ID: "6",
 	}}
-	pairs, err := generateMatchups(allTeams)
+	rounds, err := generateMatchups(allTeams, 0)
 	assert.NoError(t, err)
+	pairs := slices.Concat(rounds...)
 	assertEachTeamPlaysNGames(t, allTeams, pairs, 3)
 
 	// 3 games per team via 3 rounds of circle method (no duplicate pairings).
@@ -85,6 +90,30 @@ func TestGeneratePairs_6(t *testing.T) {
 	}, pairsToStrings(pairs))
 }
 
+func TestGeneratePairs_Rounds(t *testing.T) {
+	allTeams := []teams.Team{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}, {ID: "5"}, {ID: "6"}, {ID: "7"}}
+	rounds, err := generateMatchups(allTeams, 0)
+	assert.NoError(t, err)
+	assertEachTeamPlaysNGames(t, allTeams, slices.Concat(rounds...), 6)
+
+	// One team sits out each round.
+	assert.SliceLen(t, rounds, 7)
+	for _, r := range rounds {
+		assert.SliceLen(t, r, 3)
+	}
+}
+
+func TestGeneratePairs_GamesPerTeam(t *testing.T) {
+	allTeams := []teams.Team{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}, {ID: "5"}, {ID: "6"}, {ID: "7"}, {ID: "8"}}
+	rounds, err := generateMatchups(allTeams, 4)
+	assert.NoError(t, err)
+	assert.SliceLen(t, rounds, 4)
+	assertEachTeamPlaysNGames(t, allTeams, slices.Concat(rounds...), 4)
+
+	_, err = generateMatchups(allTeams[:5], 3)
+	assert.ErrorIs(t, err, schedule.ErrUnevenGames)
+}
+
 // assertEachTeamPlaysNGames checks that every team in allTeams appears in exactly n games (pairs).
 func assertEachTeamPlaysNGames(t *testing.T, allTeams []teams.Team, pairs [][2]teams.Team, n int) {
 	t.Helper()
@@ -115,3 +144,15 @@ func pairsToStrings(pairs [][2]teams.Team) []string {
 	slices.Sort(s)
 	return s
 }
+
+func TestRankTeams(t *testing.T) {
+	ts := []teams.Team{{ID: "1", Name: "d"}, {ID: "2", Name: "c"}, {ID: "3", Name: "b"}, {ID: "4", Name: "a"}}
+	ranked := rankTeams(ts, map[string]int{"1": 5, "3": 2})
+
+	var ids []string
+	for _, t := range ranked {
+		ids = append(ids, t.ID)
+	}
+	// Ranked teams first, then the ones without a standing by name.
+	assert.Equal(t, []string{"3", "1", "4", "2"}, ids)
+}
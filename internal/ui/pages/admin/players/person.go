@@ -0,0 +1,129 @@
+package players
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+)
+
+// personOption is a person a player could be, with the names of the events they've played in.
+type personOption struct {
+	Person players.Person
+	Events []string
+}
+
+type personPageData struct {
+	Player  players.Player
+	Current personOption
+	// Suggestions are the other people with the player's name.
+	Suggestions []personOption
+	// People are everyone the player could be linked to.
+	People []players.Person
+}
+
+// PersonPage shows who the player is from one event to the next, and lets an admin link them to
+// someone else when matching by name picked the wrong person.
+func (h PlayersHandler) PersonPage(w http.ResponseWriter, r *http.Request) error {
+	return h.renderPerson(w, r, "")
+}
+
+func (h PlayersHandler) renderPerson(w http.ResponseWriter, r *http.Request, errMsg string) error {
+	p, err := h.PlayerRepo.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	es, err := h.EventRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+	eventNames := make(map[string]string, len(es))
+	for _, e := range es {
+		eventNames[e.ID] = e.Name
+	}
+
+	option := func(person players.Person) (personOption, error) {
+		ps, err := h.PlayerRepo.GetForPerson(r.Context(), person.ID)
+		if err != nil {
+			return personOption{}, err
+		}
+		o := personOption{Person: person}
+		for _, pl := range ps {
+			o.Events = append(o.Events, eventNames[pl.EventID])
+		}
+		slices.Sort(o.Events)
+		return o, nil
+	}
+
+	data := personPageData{Player: p}
+
+	current, err := h.PlayerRepo.GetPerson(r.Context(), p.PersonID)
+	if err != nil {
+		return err
+	}
+	data.Current, err = option(current)
+	if err != nil {
+		return err
+	}
+
+	named, err := h.PlayerRepo.GetPeopleNamed(r.Context(), p.FirstName, p.LastName)
+	if err != nil {
+		return err
+	}
+	for _, person := range named {
+		if person.ID == p.PersonID {
+			continue
+		}
+		o, err := option(person)
+		if err != nil {
+			return err
+		}
+		data.Suggestions = append(data.Suggestions, o)
+	}
+
+	people, err := h.PlayerRepo.GetPeople(r.Context())
+	if err != nil {
+		return err
+	}
+	data.People = slices.DeleteFunc(people, func(person players.Person) bool {
+		return person.ID == p.PersonID
+	})
+
+	return personPage(data, errMsg).Render(r.Context(), w)
+}
+
+// LinkPerson makes the player the person in the person form value.
+func (h PlayersHandler) LinkPerson(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+	personID := r.FormValue("person")
+	if personID == "" {
+		return h.renderPerson(w, r, "Choose who this player is.")
+	}
+
+	err := h.PlayerRepo.LinkToPerson(r.Context(), id, personID)
+	if err != nil {
+		if errors.Is(err, players.ErrPersonInEvent) {
+			return h.renderPerson(w, r, "That person already has a player in this event.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/players/%s/person", id), http.StatusFound)
+	return nil
+}
+
+// UnlinkPerson makes the player a new person of their own.
+func (h PlayersHandler) UnlinkPerson(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	err := h.PlayerRepo.UnlinkFromPerson(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/players/%s/person", id), http.StatusFound)
+	return nil
+}
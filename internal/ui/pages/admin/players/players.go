@@ -12,11 +12,13 @@ import (
 
 	"github.com/cszczepaniak/cribbly/internal/fake"
 	"github.com/cszczepaniak/cribbly/internal/moreiter"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 	"github.com/cszczepaniak/cribbly/internal/persistence/players"
 )
 
 type PlayersHandler struct {
 	PlayerRepo players.Repository
+	EventRepo  events.Repository
 }
 
 type excelSheetData struct {
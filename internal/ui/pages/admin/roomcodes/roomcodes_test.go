@@ -17,7 +17,6 @@ func newHandler(t *testing.T) (Handler, roomcodes.Repository) {
 
 	db := database.NewInMemory(t)
 	repo := roomcodes.NewRepository(db)
-	assert.NoError(t, repo.Init(t.Context()))
 
 	return Handler{RoomCodeRepo: repo}, repo
 }
@@ -0,0 +1,162 @@
+package tables
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/divisions"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/tables"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+type Handler struct {
+	TableRepo    tables.Repository
+	DivisionRepo divisions.Repository
+	GameRepo     games.Repository
+	TeamRepo     teams.Repository
+}
+
+func (h Handler) Index(w http.ResponseWriter, r *http.Request) error {
+	return h.render(w, r, "")
+}
+
+func (h Handler) render(w http.ResponseWriter, r *http.Request, errMsg string) error {
+	ts, err := h.TableRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+
+	divs, err := h.DivisionRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(divs, func(a, b divisions.Division) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return index(ts, divs, errMsg).Render(r.Context(), w)
+}
+
+// SetCount sets how many tables the event has from the count form value.
+func (h Handler) SetCount(w http.ResponseWriter, r *http.Request) error {
+	n, err := strconv.Atoi(r.FormValue("count"))
+	if err != nil || n < 0 {
+		return h.render(w, r, "The number of tables must be a whole number.")
+	}
+
+	err = h.TableRepo.SetCount(r.Context(), n)
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/tables", http.StatusFound)
+	return nil
+}
+
+// Update renames a table and moves it into the cluster in the cluster form value.
+func (h Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	err := h.TableRepo.Update(r.Context(), r.PathValue("id"), r.FormValue("name"), r.FormValue("cluster"))
+	if err != nil {
+		if errors.Is(err, tables.ErrTableNameMissing) {
+			return h.render(w, r, "Tables must have a name.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/tables", http.StatusFound)
+	return nil
+}
+
+// PinDivision pins a division's prelim games to the cluster in the cluster form value, or unpins
+// them if it's empty. It takes effect the next time games are generated.
+func (h Handler) PinDivision(w http.ResponseWriter, r *http.Request) error {
+	err := h.DivisionRepo.SetTableCluster(r.Context(), r.PathValue("id"), r.FormValue("cluster"))
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/tables", http.StatusFound)
+	return nil
+}
+
+// clusters returns the distinct clusters the tables are in, in the order they first appear.
+func clusters(ts []tables.Table) []string {
+	var res []string
+	for _, t := range ts {
+		if t.Cluster != "" && !slices.Contains(res, t.Cluster) {
+			res = append(res, t.Cluster)
+		}
+	}
+	return res
+}
+
+// sheetGame is one game on a table's sheet.
+type sheetGame struct {
+	round     int
+	team1Name string
+	team2Name string
+}
+
+type sheet struct {
+	tableName string
+	games     []sheetGame
+}
+
+// Sheets renders a printable page per table listing the games played at it, round by round.
+func (h Handler) Sheets(w http.ResponseWriter, r *http.Request) error {
+	ts, err := h.TableRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+
+	allTeams, err := h.TeamRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+	teamNamesByID := make(map[string]string, len(allTeams))
+	for _, t := range allTeams {
+		teamNamesByID[t.ID] = t.Name
+	}
+
+	scores, err := h.GameRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+	scoresByGame := make(map[string][]games.Score)
+	for _, s := range scores {
+		scoresByGame[s.GameID] = append(scoresByGame[s.GameID], s)
+	}
+
+	gamesByTable := make(map[string][]sheetGame)
+	for _, ss := range scoresByGame {
+		if len(ss) != 2 || ss[0].TableID == "" {
+			continue
+		}
+		slices.SortFunc(ss, func(a, b games.Score) int {
+			return cmp.Compare(teamNamesByID[a.TeamID], teamNamesByID[b.TeamID])
+		})
+		gamesByTable[ss[0].TableID] = append(gamesByTable[ss[0].TableID], sheetGame{
+			round:     ss[0].Round,
+			team1Name: teamNamesByID[ss[0].TeamID],
+			team2Name: teamNamesByID[ss[1].TeamID],
+		})
+	}
+
+	sheets := make([]sheet, 0, len(ts))
+	for _, t := range ts {
+		gs := gamesByTable[t.ID]
+		if len(gs) == 0 {
+			continue
+		}
+		slices.SortFunc(gs, func(a, b sheetGame) int {
+			return cmp.Or(cmp.Compare(a.round, b.round), cmp.Compare(a.team1Name, b.team1Name))
+		})
+		sheets = append(sheets, sheet{tableName: t.Name, games: gs})
+	}
+
+	return sheetsPage(sheets).Render(r.Context(), w)
+}
@@ -0,0 +1,165 @@
+package teams
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+)
+
+// Formation renders the team formation page: free agents' ratings, partner requests and conflicts,
+// and proposed teams for them. The balance query parameter balances teams by rating.
+func (h TeamsHandler) Formation(w http.ResponseWriter, r *http.Request) error {
+	return h.renderFormation(w, r, "")
+}
+
+func (h TeamsHandler) renderFormation(w http.ResponseWriter, r *http.Request, errMsg string) error {
+	balance := r.URL.Query().Get("balance") != ""
+
+	free, err := h.PlayerRepo.GetFreeAgents(r.Context())
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(free, func(a, b players.Player) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+
+	all, err := h.PlayerRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+	namesByID := make(map[string]string, len(all))
+	for _, p := range all {
+		namesByID[p.ID] = p.Name()
+	}
+
+	requests, err := h.PlayerRepo.GetPartnerRequests(r.Context())
+	if err != nil {
+		return err
+	}
+
+	conflicts, err := h.PlayerRepo.GetConflicts(r.Context())
+	if err != nil {
+		return err
+	}
+
+	proposal, err := h.TeamService.Propose(r.Context(), balance)
+	if err != nil {
+		return err
+	}
+
+	return formationPage(formationData{
+		free:      free,
+		namesByID: namesByID,
+		requests:  requests,
+		conflicts: conflicts,
+		proposal:  proposal,
+		balance:   balance,
+		errMsg:    errMsg,
+	}).Render(r.Context(), w)
+}
+
+// SetRating sets a player's rating from the rating form value.
+func (h TeamsHandler) SetRating(w http.ResponseWriter, r *http.Request) error {
+	rating, err := strconv.Atoi(r.FormValue("rating"))
+	if err != nil {
+		return h.renderFormation(w, r, "Ratings must be whole numbers.")
+	}
+
+	err = h.PlayerRepo.SetRating(r.Context(), r.PathValue("id"), rating)
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/teams/formation", http.StatusFound)
+	return nil
+}
+
+// UseComputedRatings sets every player's rating to the one computed from past results.
+func (h TeamsHandler) UseComputedRatings(w http.ResponseWriter, r *http.Request) error {
+	err := h.RatingService.ApplyToPlayers(r.Context())
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/teams/formation", http.StatusFound)
+	return nil
+}
+
+// RequestPartner records the partner in the partner form value as the player's request, or
+// withdraws their request if it's empty.
+func (h TeamsHandler) RequestPartner(w http.ResponseWriter, r *http.Request) error {
+	err := h.PlayerRepo.RequestPartner(r.Context(), r.PathValue("id"), r.FormValue("partner"))
+	if err != nil {
+		if errors.Is(err, players.ErrSamePlayer) {
+			return h.renderFormation(w, r, "Players can't ask to play with themselves.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/teams/formation", http.StatusFound)
+	return nil
+}
+
+// AddConflict keeps the players in the player1 and player2 form values off the same team.
+func (h TeamsHandler) AddConflict(w http.ResponseWriter, r *http.Request) error {
+	player1, player2 := r.FormValue("player1"), r.FormValue("player2")
+	if player1 == "" || player2 == "" {
+		return h.renderFormation(w, r, "Choose two players who shouldn't play together.")
+	}
+
+	err := h.PlayerRepo.AddConflict(r.Context(), player1, player2)
+	if err != nil {
+		if errors.Is(err, players.ErrSamePlayer) {
+			return h.renderFormation(w, r, "Choose two different players.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/teams/formation", http.StatusFound)
+	return nil
+}
+
+// RemoveConflict removes the conflict between the players in the player1 and player2 form values.
+func (h TeamsHandler) RemoveConflict(w http.ResponseWriter, r *http.Request) error {
+	err := h.PlayerRepo.RemoveConflict(r.Context(), r.FormValue("player1"), r.FormValue("player2"))
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/teams/formation", http.StatusFound)
+	return nil
+}
+
+// AcceptFormation creates the proposed teams exactly as they were shown. The form has a "team"
+// value per team of the form "<player ID>:<player ID>".
+func (h TeamsHandler) AcceptFormation(w http.ResponseWriter, r *http.Request) error {
+	err := r.ParseForm()
+	if err != nil {
+		return err
+	}
+
+	var pairs [][2]string
+	for _, v := range r.PostForm["team"] {
+		p1, p2, ok := strings.Cut(v, ":")
+		if !ok {
+			return errors.New("malformed team value")
+		}
+		pairs = append(pairs, [2]string{p1, p2})
+	}
+
+	err = h.TeamService.CreateTeams(r.Context(), pairs)
+	if err != nil {
+		if errors.Is(err, players.ErrPlayerAlreadyOnATeam) {
+			return h.renderFormation(w, r, "Some of these players were put on a team since these teams were proposed. Check the new proposal and try again.")
+		}
+		return err
+	}
+
+	http.Redirect(w, r, "/admin/teams", http.StatusFound)
+	return nil
+}
@@ -8,13 +8,15 @@ import (
 	"github.com/cszczepaniak/cribbly/internal/moreiter"
 	"github.com/cszczepaniak/cribbly/internal/persistence/players"
 	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	ratingservice "github.com/cszczepaniak/cribbly/internal/service/ratings"
 	teamservice "github.com/cszczepaniak/cribbly/internal/service/teams"
 )
 
 type TeamsHandler struct {
-	PlayerRepo  players.Repository
-	TeamRepo    teams.Repository
-	TeamService teamservice.Service
+	PlayerRepo    players.Repository
+	TeamRepo      teams.Repository
+	TeamService   teamservice.Service
+	RatingService ratingservice.Service
 }
 
 func (h TeamsHandler) Index(w http.ResponseWriter, r *http.Request) error {
@@ -72,23 +74,8 @@ func (h TeamsHandler) Create(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	teams, err := h.TeamRepo.GetAll(r.Context())
-	if err != nil {
-		return err
-	}
-
-	playersByTeam := make(map[string][]players.Player, len(teams))
-	for _, team := range teams {
-		players, err := h.PlayerRepo.GetForTeam(r.Context(), team.ID)
-		if err != nil {
-			return err
-		}
-		playersByTeam[team.ID] = players
-	}
-
 	sse := datastar.NewSSE(w, r)
 	return sse.Redirectf("/admin/teams/%s", team.ID)
-	return sse.PatchElementTempl(teamGrid(teams, playersByTeam))
 }
 
 func (h TeamsHandler) Delete(w http.ResponseWriter, r *http.Request) error {
@@ -125,26 +112,26 @@ func (h TeamsHandler) DeleteAll(w http.ResponseWriter, r *http.Request) error {
 	return datastar.NewSSE(w, r).Redirect("/admin/teams")
 }
 
+// Generate pairs every free agent into teams at random. If some partner request couldn't be
+// honored or someone would be left without a team, no teams are made and the admin is sent to the
+// formation page instead, which explains why and lets them review the teams before accepting them.
 func (h TeamsHandler) Generate(w http.ResponseWriter, r *http.Request) error {
-	players, err := h.PlayerRepo.GetFreeAgents(r.Context())
+	proposal, err := h.TeamService.Propose(r.Context(), false)
 	if err != nil {
 		return err
 	}
+	if len(proposal.Notes) > 0 || len(proposal.Unpaired) > 0 {
+		return datastar.NewSSE(w, r).Redirect("/admin/teams/formation")
+	}
 
-	for len(players) >= 2 {
-		team, err := h.TeamService.CreateTeam(r.Context())
-		if err != nil {
-			return err
-		}
-
-		for i := range 2 {
-			_, err = h.TeamService.AssignPlayerToTeam(r.Context(), players[i].ID, team.ID)
-			if err != nil {
-				return err
-			}
-		}
+	pairs := make([][2]string, 0, len(proposal.Teams))
+	for _, t := range proposal.Teams {
+		pairs = append(pairs, [2]string{t[0].ID, t[1].ID})
+	}
 
-		players = players[2:]
+	err = h.TeamService.CreateTeams(r.Context(), pairs)
+	if err != nil {
+		return err
 	}
 
 	return datastar.NewSSE(w, r).Redirect("/admin/teams")
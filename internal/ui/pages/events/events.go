@@ -0,0 +1,20 @@
+package events
+
+import (
+	"net/http"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+)
+
+type Handler struct {
+	EventRepo events.Repository
+}
+
+func (h Handler) Index(w http.ResponseWriter, r *http.Request) error {
+	es, err := h.EventRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return index(es).Render(r.Context(), w)
+}
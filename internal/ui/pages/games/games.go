@@ -1,21 +1,28 @@
 package games
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/starfederation/datastar-go/datastar"
 
+	"github.com/cszczepaniak/cribbly/internal/api/standingsconnect"
+	cribblyv1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
 	"github.com/cszczepaniak/cribbly/internal/notifier"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
 	"github.com/cszczepaniak/cribbly/internal/persistence/games"
 	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
 	"github.com/cszczepaniak/cribbly/internal/server/middleware"
+	"github.com/cszczepaniak/cribbly/internal/ui/dstar"
 )
 
 type Handler struct {
 	GameRepo            games.Repository
 	TeamRepo            teams.Repository
-	ScoreUpdateNotifier *notifier.Notifier
+	ScoreUpdateNotifier *games.ScoreNotifier
 }
 
 type team struct {
@@ -30,6 +37,19 @@ type getGameProps struct {
 
 	// if we came from a team's page, used to redirect back to it
 	fromID string
+
+	// pending is set if the game's score is waiting for confirmation or disputed. confirming is true
+	// if the team we came from is the one that should confirm it.
+	pending    *games.PendingScore
+	confirming bool
+}
+
+// reportedBy returns the name of the team that entered the pending score.
+func (p getGameProps) reportedBy() string {
+	if p.pending.ReportedBy == p.team1.ID {
+		return p.team1.Name
+	}
+	return p.team2.Name
 }
 
 func (p getGameProps) getWinningTeam() string {
@@ -78,9 +98,22 @@ func (h Handler) GetGame(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	pending, err := h.GameRepo.GetPendingScores(r.Context())
+	if err != nil {
+		return err
+	}
+
+	fromID := r.URL.Query().Get("fromID")
+	var pendingScore *games.PendingScore
+	if p, ok := pending[id]; ok {
+		pendingScore = &p
+	}
+
 	return Game(getGameProps{
-		gameID:   id,
-		complete: g[0].Score != 0 && g[1].Score != 0,
+		gameID:     id,
+		pending:    pendingScore,
+		confirming: canConfirm(pending[id], fromID, g),
+		complete:   g[0].Score != 0 && g[1].Score != 0,
 		team1: team{
 			Team:  team1,
 			score: g[0].Score,
@@ -89,10 +122,19 @@ func (h Handler) GetGame(w http.ResponseWriter, r *http.Request) error {
 			Team:  team2,
 			score: g[1].Score,
 		},
-		fromID: r.URL.Query().Get("fromID"),
+		fromID: fromID,
 	}).Render(r.Context(), w)
 }
 
+// canConfirm reports whether teamID is the one that should confirm p, the pending score of a game
+// with the given scores: they're in the game, didn't report it, and haven't already disputed it.
+func canConfirm(p games.PendingScore, teamID string, scores [2]games.Score) bool {
+	return p.GameID != "" &&
+		!p.Disputed() &&
+		teamID != p.ReportedBy &&
+		(teamID == scores[0].TeamID || teamID == scores[1].TeamID)
+}
+
 func (h Handler) UpdateGame(w http.ResponseWriter, r *http.Request) error {
 	gameID := r.PathValue("id")
 	var signals struct {
@@ -126,10 +168,6 @@ func (h Handler) UpdateGame(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	if (scores[0].Score != 0 || scores[1].Score != 0) && !middleware.IsAdmin(r.Context()) {
-		return errors.New("completed games can only be edited by an admin")
-	}
-
 	var losingID string
 	if signals.WinningTeamID == scores[0].TeamID {
 		losingID = scores[1].TeamID
@@ -137,12 +175,31 @@ func (h Handler) UpdateGame(w http.ResponseWriter, r *http.Request) error {
 		losingID = scores[0].TeamID
 	}
 
-	err = h.GameRepo.UpdateScores(r.Context(), gameID, signals.WinningTeamID, 121, losingID, signals.LoserScore)
+	fromID := r.URL.Query().Get("fromID")
+	confirm, err := h.GameRepo.ConfirmScores(r.Context())
+	if err != nil {
+		return err
+	}
+
+	complete := scores[0].Score != 0 || scores[1].Score != 0
+	switch {
+	case middleware.IsAdmin(r.Context()), !confirm && !complete:
+		err = h.GameRepo.UpdateScores(r.Context(), gameID, signals.WinningTeamID, 121, losingID, signals.LoserScore)
+	case confirm && !complete:
+		if fromID != scores[0].TeamID && fromID != scores[1].TeamID {
+			return errors.New("scores must be entered from one of the teams' pages")
+		}
+		err = h.GameRepo.ReportScore(r.Context(), gameID, fromID, signals.WinningTeamID, 121, losingID, signals.LoserScore)
+	case confirm:
+		// The other team entering the score confirms it if they agree and disputes it if they don't.
+		err = h.confirmOrDispute(r.Context(), gameID, fromID, scores, signals.WinningTeamID, signals.LoserScore)
+	default:
+		return errors.New("completed games can only be edited by an admin")
+	}
 	if err != nil {
 		return err
 	}
 
-	fromID := r.URL.Query().Get("fromID")
 	if fromID != "" {
 		// Show the team's games again.
 		return datastar.NewSSE(w, r).Redirectf("/teams/%s/games", fromID)
@@ -152,33 +209,141 @@ func (h Handler) UpdateGame(w http.ResponseWriter, r *http.Request) error {
 	return datastar.NewSSE(w, r).Redirectf("/games/%s", gameID)
 }
 
+func (h Handler) confirmOrDispute(
+	ctx context.Context,
+	gameID string,
+	teamID string,
+	scores [2]games.Score,
+	winnerID string,
+	loserScore int,
+) error {
+	pending, err := h.GameRepo.GetPendingScores(ctx)
+	if err != nil {
+		return err
+	}
+
+	p := pending[gameID]
+	if !canConfirm(p, teamID, scores) {
+		return errors.New("completed games can only be edited by an admin")
+	}
+
+	for i, sc := range scores {
+		other := scores[1-i]
+		if sc.TeamID == winnerID && sc.Score == games.WinningScore && other.Score == loserScore {
+			return h.GameRepo.ConfirmScore(ctx, gameID)
+		}
+	}
+	return h.GameRepo.DisputeScore(ctx, gameID, winnerID, loserScore)
+}
+
+// ConfirmGame confirms a pending score as is, on behalf of the team in the fromID query parameter.
+func (h Handler) ConfirmGame(w http.ResponseWriter, r *http.Request) error {
+	gameID := r.PathValue("id")
+	fromID := r.URL.Query().Get("fromID")
+
+	scores, err := h.GameRepo.Get(r.Context(), gameID)
+	if err != nil {
+		return err
+	}
+
+	pending, err := h.GameRepo.GetPendingScores(r.Context())
+	if err != nil {
+		return err
+	}
+
+	if !canConfirm(pending[gameID], fromID, scores) {
+		return errors.New("this score can't be confirmed by this team")
+	}
+
+	err = h.GameRepo.ConfirmScore(r.Context(), gameID)
+	if err != nil {
+		return err
+	}
+
+	return datastar.NewSSE(w, r).Redirectf("/teams/%s/games", fromID)
+}
+
+// standing is a row of the standings table. The page and its stream both build it from the
+// standings API's messages, so that there is one source of truth for what a team's row says.
+type standing struct {
+	teamID            string
+	teamName          string
+	wins              int
+	losses            int
+	gamePoints        int
+	winRate           float64
+	pointsPerGame     float64
+	skunksGiven       int
+	skunksTaken       int
+	doubleSkunksGiven int
+	doubleSkunksTaken int
+	tiebreak          events.Tiebreaker
+	coinFlippedAt     time.Time
+	coinFlipPending   bool
+}
+
+func (s standing) gamesPlayed() int {
+	return s.wins + s.losses
+}
+
+func toStandings(ss []*cribblyv1.Standing) []standing {
+	res := make([]standing, 0, len(ss))
+	for _, st := range ss {
+		s := standing{
+			teamID:            st.GetTeamId(),
+			teamName:          st.GetTeamName(),
+			wins:              int(st.GetWins()),
+			losses:            int(st.GetLosses()),
+			gamePoints:        int(st.GetGamePoints()),
+			winRate:           st.GetWinRate(),
+			pointsPerGame:     st.GetPointsPerGame(),
+			skunksGiven:       int(st.GetSkunksGiven()),
+			skunksTaken:       int(st.GetSkunksTaken()),
+			doubleSkunksGiven: int(st.GetDoubleSkunksGiven()),
+			doubleSkunksTaken: int(st.GetDoubleSkunksTaken()),
+			tiebreak:          events.Tiebreaker(st.GetTiebreak()),
+			coinFlipPending:   st.GetCoinFlipPending(),
+		}
+		if t, err := time.Parse(time.RFC3339, st.GetCoinFlippedAt()); err == nil {
+			s.coinFlippedAt = t
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+func (h Handler) standingsServer() *standingsconnect.Server {
+	return &standingsconnect.Server{
+		GameRepo:            h.GameRepo,
+		ScoreUpdateNotifier: h.ScoreUpdateNotifier,
+	}
+}
+
 func (h Handler) StandingsPage(w http.ResponseWriter, r *http.Request) error {
-	s, err := h.GameRepo.GetStandings(r.Context())
+	res, err := h.standingsServer().GetStandings(r.Context(), connect.NewRequest(&cribblyv1.GetStandingsRequest{}))
 	if err != nil {
 		return err
 	}
-	return standings(s).Render(r.Context(), w)
+	return standings(toStandings(res.Msg.GetStandings())).Render(r.Context(), w)
 }
 
+// StreamStandings patches the standings table with every message of the WatchStandings stream.
+// Each patch carries the ID of the change it reflects, so a client that reconnects (sending
+// Last-Event-ID) only gets the standings again if it missed anything in between.
 func (h Handler) StreamStandings(w http.ResponseWriter, r *http.Request) error {
+	// On a first connection this is the zero ID, which gets the standings straight away.
+	lastID, _ := dstar.LastEventID(r)
+
 	sse := datastar.NewSSE(w, r)
-	notify, cancel := h.ScoreUpdateNotifier.Subscribe()
-	defer cancel()
-
-	for {
-		select {
-		case <-r.Context().Done():
-			return nil
-		case <-notify:
-			s, err := h.GameRepo.GetStandings(r.Context())
-			if err != nil {
-				return err
-			}
-
-			err = sse.PatchElementTempl(standingsTable(s), datastar.WithViewTransitions())
-			if err != nil {
-				return err
-			}
-		}
-	}
+	return h.standingsServer().Watch(
+		r.Context(),
+		lastID,
+		func(res *cribblyv1.WatchStandingsResponse, id notifier.ID) error {
+			return sse.PatchElementTempl(
+				standingsTable(toStandings(res.GetStandings())),
+				datastar.WithViewTransitions(),
+				dstar.WithEventID(id),
+			)
+		},
+	)
 }
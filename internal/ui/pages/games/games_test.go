@@ -0,0 +1,168 @@
+package games
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cszczepaniak/gotest/assert"
+
+	"github.com/cszczepaniak/cribbly/internal/notifier"
+	"github.com/cszczepaniak/cribbly/internal/persistence/database"
+	"github.com/cszczepaniak/cribbly/internal/persistence/events"
+	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+)
+
+type streamTest struct {
+	h      Handler
+	events events.Repository
+	gameID string
+	a, b   string
+	url    string
+}
+
+func newStreamTest(t *testing.T, historySize int) streamTest {
+	t.Helper()
+
+	db := database.NewInMemory(t)
+	tr := teams.NewRepository(db)
+	n := &games.ScoreNotifier{HistorySize: historySize}
+	gr := games.NewRepository(db, n)
+
+	a, err := tr.Create(t.Context(), "A")
+	assert.NoError(t, err)
+	b, err := tr.Create(t.Context(), "B")
+	assert.NoError(t, err)
+	gameID, err := gr.Create(t.Context(), a.ID, b.ID)
+	assert.NoError(t, err)
+
+	h := Handler{GameRepo: gr, TeamRepo: tr, ScoreUpdateNotifier: n}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = h.StreamStandings(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	return streamTest{h: h, events: events.NewRepository(db), gameID: gameID, a: a.ID, b: b.ID, url: ts.URL}
+}
+
+func (st streamTest) setScore(t *testing.T, a, b int) {
+	t.Helper()
+	assert.NoError(t, st.h.GameRepo.UpdateScores(t.Context(), st.gameID, st.a, a, st.b, b))
+}
+
+// id formats the ID of the seq'th change, as the stream sends it.
+func (st streamTest) id(seq uint64) string {
+	return notifier.ID{Epoch: st.h.ScoreUpdateNotifier.LastID().Epoch, Seq: seq}.String()
+}
+
+// connect opens the stream, sending lastEventID if it isn't empty, and returns a function that
+// reads the ID of the next event.
+func (st streamTest) connect(t *testing.T, lastEventID string) func() string {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, st.url, nil)
+	assert.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	lines := bufio.NewScanner(resp.Body)
+	return func() string {
+		t.Helper()
+		for lines.Scan() {
+			if id, ok := strings.CutPrefix(lines.Text(), "id: "); ok {
+				return id
+			}
+		}
+		t.Fatal("stream ended")
+		return ""
+	}
+}
+
+func TestStreamStandings_ReplaysMissedChanges(t *testing.T) {
+	st := newStreamTest(t, 0)
+	st.setScore(t, 50, 40)
+	st.setScore(t, 121, 90)
+
+	// The client saw change 1 and missed change 2.
+	next := st.connect(t, st.id(1))
+	assert.Equal(t, st.id(2), next())
+
+	st.setScore(t, 121, 100)
+	assert.Equal(t, st.id(3), next())
+}
+
+func TestStreamStandings_UpToDate(t *testing.T) {
+	st := newStreamTest(t, 0)
+	st.setScore(t, 121, 90)
+
+	// Nothing was missed, so the next event is the next change.
+	next := st.connect(t, st.id(1))
+	st.setScore(t, 121, 100)
+	assert.Equal(t, st.id(2), next())
+}
+
+func TestStreamStandings_SnapshotWhenGapIsTooOld(t *testing.T) {
+	st := newStreamTest(t, 1)
+	st.setScore(t, 10, 0)
+	st.setScore(t, 20, 0)
+	st.setScore(t, 30, 0)
+
+	// Changes 2 and 3 can't both be replayed, so the client gets the current standings.
+	next := st.connect(t, st.id(1))
+	assert.Equal(t, st.id(3), next())
+
+	// Likewise for an ID from before a restart, even one the new counter has passed.
+	before := notifier.ID{Epoch: st.h.ScoreUpdateNotifier.LastID().Epoch - 1, Seq: 2}
+	next = st.connect(t, before.String())
+	assert.Equal(t, st.id(3), next())
+}
+
+func TestUpdateGame_ConfirmScores(t *testing.T) {
+	st := newStreamTest(t, 0)
+
+	report := func(t *testing.T, fromID, winnerID string, loserScore int) error {
+		t.Helper()
+		body := fmt.Sprintf(`{"winner":%q,"loserScore":%d}`, winnerID, loserScore)
+		r := httptest.NewRequest(http.MethodPut, "/games/"+st.gameID+"?fromID="+fromID, strings.NewReader(body))
+		r.SetPathValue("id", st.gameID)
+		return st.h.UpdateGame(httptest.NewRecorder(), r)
+	}
+	pending := func(t *testing.T) map[string]games.PendingScore {
+		t.Helper()
+		p, err := st.h.GameRepo.GetPendingScores(t.Context())
+		assert.NoError(t, err)
+		return p
+	}
+
+	assert.NoError(t, st.events.SetConfirmScores(t.Context(), events.LegacyID, true))
+
+	// The score is entered by a, so only b can confirm it.
+	assert.NoError(t, report(t, st.a, st.a, 90))
+	assert.Equal(t, games.PendingScore{GameID: st.gameID, ReportedBy: st.a}, pending(t)[st.gameID])
+	assert.Error(t, report(t, st.a, st.a, 95))
+
+	// b enters a different score, so the game is disputed.
+	assert.NoError(t, report(t, st.b, st.b, 100))
+	assert.Equal(t, true, pending(t)[st.gameID].Disputed())
+	assert.Error(t, report(t, st.b, st.a, 90))
+
+	// An admin settles it; then the teams enter it again and agree.
+	st.setScore(t, 0, 0)
+	assert.MapLen(t, pending(t), 0)
+	assert.NoError(t, report(t, st.b, st.a, 90))
+	assert.NoError(t, report(t, st.a, st.a, 90))
+	assert.MapLen(t, pending(t), 0)
+
+	scores, err := st.h.GameRepo.Get(t.Context(), st.gameID)
+	assert.NoError(t, err)
+	assert.Equal(t, 121+90, scores[0].Score+scores[1].Score)
+}
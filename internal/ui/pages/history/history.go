@@ -0,0 +1,56 @@
+package history
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cszczepaniak/cribbly/internal/service/history"
+)
+
+type Handler struct {
+	HistoryService history.Service
+}
+
+// Index shows the champions of every event by year, and the records set across all of them.
+func (h Handler) Index(w http.ResponseWriter, r *http.Request) error {
+	records, err := h.HistoryService.GetRecords(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return index(records).Render(r.Context(), w)
+}
+
+// year is the finals of the events held in one year.
+type year struct {
+	Year   int
+	Finals []history.Final
+}
+
+// byYear groups finals, most recent first, by the year of their event.
+func byYear(fs []history.Final) []year {
+	var ys []year
+	for _, f := range fs {
+		y := f.Champion.EventDate.Year()
+		if len(ys) == 0 || ys[len(ys)-1].Year != y {
+			ys = append(ys, year{Year: y})
+		}
+		ys[len(ys)-1].Finals = append(ys[len(ys)-1].Finals, f)
+	}
+	return ys
+}
+
+// eventSpan names the events a streak ran across, like "Spring Open" or "Spring Open – Fall Open".
+func eventSpan(s history.Streak) string {
+	if s.From.ID == s.To.ID {
+		return s.To.Name
+	}
+	return fmt.Sprintf("%s – %s", s.From.Name, s.To.Name)
+}
+
+func titlesLabel(n int) string {
+	if n == 1 {
+		return "1 title"
+	}
+	return fmt.Sprintf("%d titles", n)
+}
@@ -0,0 +1,73 @@
+package players
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/service/history"
+)
+
+type Handler struct {
+	PlayerRepo     players.Repository
+	HistoryService history.Service
+}
+
+// Profile shows a person's teams, finishes and records in every event they've played in, and how
+// they've done against the people they've played most. Links to one event's player (which is how
+// players were linked before they had a person) redirect to that player's person.
+func (h Handler) Profile(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	hist, err := h.HistoryService.GetPlayerHistory(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		p, err := h.PlayerRepo.Get(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				http.NotFound(w, r)
+				return nil
+			}
+			return err
+		}
+		http.Redirect(w, r, fmt.Sprintf("/players/%s", p.PersonID), http.StatusMovedPermanently)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return profile(hist).Render(r.Context(), w)
+}
+
+// ordinal formats n as 1st, 2nd, 3rd and so on.
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// finish describes where a team finished in the prelims, like "2nd of 4 in Texas".
+func finish(e history.EventHistory) string {
+	if e.Finish == 0 {
+		return ""
+	}
+	s := fmt.Sprintf("%s of %d", ordinal(e.Finish), e.FinishOf)
+	if e.Division.Name != "" {
+		s += " in " + e.Division.Name
+	}
+	return s
+}
+
+func record(r history.Record) string {
+	return fmt.Sprintf("%d–%d", r.Wins, r.Losses)
+}
@@ -2,16 +2,22 @@ package teams
 
 import (
 	"cmp"
+	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/cszczepaniak/cribbly/internal/persistence/games"
+	"github.com/cszczepaniak/cribbly/internal/persistence/players"
+	"github.com/cszczepaniak/cribbly/internal/persistence/tables"
 	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
 )
 
 type Handler struct {
-	GameRepo games.Repository
-	TeamRepo teams.Repository
+	GameRepo   games.Repository
+	TeamRepo   teams.Repository
+	TableRepo  tables.Repository
+	PlayerRepo players.Repository
 }
 
 type team struct {
@@ -23,6 +29,38 @@ type game struct {
 	id    string
 	team1 team
 	team2 team
+	// round is the game's round in the prelim schedule, or 0 if it isn't part of one.
+	round int
+	// table is the name of the table the game is played at, or empty if it doesn't have one.
+	table string
+	// crossover is true if the opponent is from another division.
+	crossover bool
+
+	// pending is true if the score is waiting for one team to confirm it, and reportedByUs if the
+	// team whose page this is entered it. disputed is true if the teams entered different scores.
+	pending      bool
+	reportedByUs bool
+	disputed     bool
+}
+
+// needsConfirmation reports whether the team whose page this is should confirm the game's score.
+func (g game) needsConfirmation() bool {
+	return g.pending && !g.reportedByUs && !g.disputed
+}
+
+// where describes when and where the game is played, e.g. "Round 2 — Table 7".
+func (g game) where() string {
+	var parts []string
+	if g.crossover {
+		parts = append(parts, "Crossover")
+	}
+	if g.round > 0 {
+		parts = append(parts, fmt.Sprintf("Round %d", g.round))
+	}
+	if g.table != "" {
+		parts = append(parts, g.table)
+	}
+	return strings.Join(parts, " — ")
 }
 
 func (g game) rankedTeams() [2]team {
@@ -56,6 +94,20 @@ func (h Handler) GetGames(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	pending, err := h.GameRepo.GetPendingScores(r.Context())
+	if err != nil {
+		return err
+	}
+
+	ts, err := h.TableRepo.GetAll(r.Context())
+	if err != nil {
+		return err
+	}
+	tableNamesByID := make(map[string]string, len(ts))
+	for _, t := range ts {
+		tableNamesByID[t.ID] = t.Name
+	}
+
 	var games []game
 	for gameID, scores := range gs {
 		team1, err := h.TeamRepo.Get(r.Context(), scores[0].TeamID)
@@ -78,6 +130,12 @@ func (h Handler) GetGames(w http.ResponseWriter, r *http.Request) error {
 				name:  team2.Name,
 				score: scores[1].Score,
 			},
+			round:        scores[0].Round,
+			table:        tableNamesByID[scores[0].TableID],
+			crossover:    scores[0].Crossover,
+			pending:      pending[gameID].GameID != "",
+			reportedByUs: pending[gameID].ReportedBy == id,
+			disputed:     pending[gameID].Disputed(),
 		})
 	}
 
@@ -90,8 +148,36 @@ func (h Handler) GetGames(w http.ResponseWriter, r *http.Request) error {
 			return -1
 		}
 
-		return cmp.Compare(a.id, b.id)
+		// Then in the order they're scheduled to be played
+		return cmp.Or(
+			cmp.Compare(a.round, b.round),
+			cmp.Compare(a.id, b.id),
+		)
 	})
 
-	return Games(t, games).Render(r.Context(), w)
+	allByes, err := h.GameRepo.GetByes(r.Context())
+	if err != nil {
+		return err
+	}
+	var byes []int
+	for _, b := range allByes {
+		if b.TeamID == id {
+			byes = append(byes, b.Round)
+		}
+	}
+
+	ps, err := h.PlayerRepo.GetForTeam(r.Context(), t.ID)
+	if err != nil {
+		return err
+	}
+
+	return Games(t, ps, games, byes).Render(r.Context(), w)
+}
+
+func upcomingGames(games []game) []game {
+	return slices.DeleteFunc(slices.Clone(games), game.complete)
+}
+
+func completedGames(games []game) []game {
+	return slices.DeleteFunc(slices.Clone(games), func(g game) bool { return !g.complete() })
 }
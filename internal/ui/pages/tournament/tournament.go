@@ -3,18 +3,26 @@ package tournament
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/starfederation/datastar-go/datastar"
 
+	"github.com/cszczepaniak/cribbly/internal/api/tournamentconnect"
+	cribblyv1 "github.com/cszczepaniak/cribbly/internal/gen/cribbly/v1"
 	"github.com/cszczepaniak/cribbly/internal/notifier"
-	"github.com/cszczepaniak/cribbly/internal/persistence/database"
-	"github.com/cszczepaniak/cribbly/internal/persistence/games"
-	"github.com/cszczepaniak/cribbly/internal/persistence/teams"
+	"github.com/cszczepaniak/cribbly/internal/server/middleware"
+	tournamentservice "github.com/cszczepaniak/cribbly/internal/service/tournament"
+	"github.com/cszczepaniak/cribbly/internal/ui/dstar"
 )
 
 type teamAreaProps struct {
+	// path is the bracket's URL, which the advance and revert buttons post under.
+	path          string
 	id            string
 	round         int
 	idx           int
@@ -25,6 +33,12 @@ type teamAreaProps struct {
 	showRevert    bool
 	revertFromIdx int
 	revertToRound int
+	// bye is true for the empty slot of a first-round bye.
+	bye bool
+	// series is true if the game is a series, which is won through its games rather than the
+	// advance button. wins is the number of the series' games the team has won.
+	series bool
+	wins   int
 }
 
 func (p teamAreaProps) isWinner() bool {
@@ -36,10 +50,18 @@ func (p teamAreaProps) isLoser() bool {
 }
 
 type Handler struct {
-	TeamRepo           teams.Repository
-	GameRepo           games.Repository
-	TournamentNotifier *notifier.Notifier
-	Transactor         database.Transactor
+	TournamentService  tournamentservice.Service
+	TournamentNotifier *tournamentservice.Notifier
+
+	consolation bool
+}
+
+// Consolation returns a Handler for the consolation bracket, which is served under
+// /tournament/consolation and shown in its own tab of the tournament page.
+func (h Handler) Consolation() Handler {
+	h.TournamentService = h.TournamentService.Consolation()
+	h.consolation = true
+	return h
 }
 
 type signalInt int
@@ -58,6 +80,7 @@ func (i signalInt) N() int {
 }
 
 type row struct {
+	side      tournamentservice.Side
 	round     int
 	idx       int
 	team1ID   string
@@ -66,41 +89,176 @@ type row struct {
 	team2Name string
 	winner    string
 	winnerID  string
+	bye       bool
+	// team1FromBye and team2FromBye are true when the team in that slot got there on a bye, so
+	// there's no result to revert.
+	team1FromBye bool
+	team2FromBye bool
+	bestOf       int
+	// series holds the scores of the games played in the series, in the order of the teams.
+	series [][2]int
+	wins   [2]int
+	// reports holds the results the teams have reported, waiting to be confirmed.
+	reports     []tournamentservice.Report
+	conflicting bool
+	// reportedWinner is who the reports say won, if they agree so far.
+	reportedWinner string
+}
+
+func (r row) ready() bool {
+	return r.team1ID != "" && r.team2ID != ""
+}
+
+func (r row) pending() bool {
+	return r.winnerID == "" && len(r.reports) > 0
+}
+
+// opponentID and opponentName return the team playing against teamID.
+func (r row) opponentID(teamID string) string {
+	if r.team1ID == teamID {
+		return r.team2ID
+	}
+	return r.team1ID
+}
+
+func (r row) opponentName(teamID string) string {
+	if r.team1ID == teamID {
+		return r.team2Name
+	}
+	return r.team1Name
+}
+
+// reportScore formats the score of a report from the reporting team's side, such as "121–95" for a
+// win.
+func reportScore(r tournamentservice.Report) string {
+	if r.Won {
+		return fmt.Sprintf("121–%d", r.LoserScore)
+	}
+	return fmt.Sprintf("%d–121", r.LoserScore)
+}
+
+// reportOf returns teamID's report of the game, if they've made one.
+func (r row) reportOf(teamID string) (tournamentservice.Report, bool) {
+	for _, rep := range r.reports {
+		if rep.Team.ID == teamID {
+			return rep, true
+		}
+	}
+	return tournamentservice.Report{}, false
 }
 
 type round struct {
 	games []row
 }
 
+// bracket is everything the bracket page shows.
+type bracket struct {
+	format tournamentservice.Format
+	// rounds is empty if the bracket hasn't been seeded. For double elimination it's the winners
+	// side.
+	rounds []round
+	losers []round
+	final  []round
+	// thirdPlace is the single-elimination third-place game, or nil if the bracket doesn't have
+	// one.
+	thirdPlace *row
+	teamCount  int
+	champ      champion
+	// pending holds the games with reported results waiting for confirmation, for the admin queue.
+	pending []row
+	// consolation is true for the consolation bracket, which is shown alongside the main one and so
+	// needs its own URLs, element IDs, and signals.
+	consolation bool
+}
+
+// path is the URL the bracket is served from.
+func (b bracket) path() string {
+	if b.consolation {
+		return "/tournament/consolation"
+	}
+	return "/tournament"
+}
+
+// elemID returns the ID of one of the bracket's elements.
+func (b bracket) elemID(name string) string {
+	if b.consolation {
+		return "consolation-" + name
+	}
+	return name
+}
+
+// seriesScoreSignal is the signal holding the losing score to record for the next game of g's
+// series.
+func (b bracket) seriesScoreSignal(g row) string {
+	name := fmt.Sprintf("series_score_%s_%d_%d", strings.ReplaceAll(string(g.side), "-", "_"), g.round, g.idx)
+	if b.consolation {
+		return "consolation_" + name
+	}
+	return name
+}
+
+// roundSignal is the signal holding the first round shown in the single-elimination view.
+func (b bracket) roundSignal() string {
+	if b.consolation {
+		return "consolation_round"
+	}
+	return "round"
+}
+
+func (b bracket) seeded() bool {
+	return len(b.rounds) > 0
+}
+
+func (b bracket) double() bool {
+	return b.format == tournamentservice.FormatDoubleElimination
+}
+
+// Index shows the main bracket and, in a second tab, the consolation bracket.
 func (h Handler) Index(w http.ResponseWriter, r *http.Request) error {
-	rounds, teamCount, err := h.loadRounds(r.Context())
+	main, err := h.loadBracket(r.Context())
 	if err != nil {
 		return err
 	}
 
-	return index(rounds, teamCount, championFromRounds(rounds)).Render(r.Context(), w)
+	consolation, err := h.Consolation().loadBracket(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return index(main, consolation).Render(r.Context(), w)
 }
 
+// Stream patches the bracket with every message of the WatchBracket stream. Each patch carries the
+// ID of the change it reflects, so a client that reconnects (sending Last-Event-ID) only gets the
+// bracket again if it missed anything in between.
 func (h Handler) Stream(w http.ResponseWriter, r *http.Request) error {
-	sub, done := h.TournamentNotifier.Subscribe()
-	defer done()
+	// On a first connection this is the zero ID, which gets the bracket straight away.
+	lastID, _ := dstar.LastEventID(r)
 
+	srv := &tournamentconnect.Server{
+		TournamentService:  h.TournamentService,
+		TournamentNotifier: h.TournamentNotifier,
+	}
 	sse := datastar.NewSSE(w, r)
-	for {
-		select {
-		case <-r.Context().Done():
-			return nil
-		case <-sub:
-			rounds, _, err := h.loadRounds(r.Context())
-			if err != nil {
-				return err
-			}
-			err = sse.PatchElementTempl(roundDisplay(rounds, 0, championFromRounds(rounds)), datastar.WithViewTransitions())
-			if err != nil {
-				return err
-			}
+
+	// Seeding or deleting the bracket swaps the whole page between the bracket and the seeding
+	// controls; otherwise only the bracket changes. The first patch doesn't know what the client
+	// has, so it's always the whole page.
+	var prev *bracket
+	return srv.Watch(r.Context(), lastID, func(res *cribblyv1.WatchBracketResponse, id notifier.ID) error {
+		b := newBracket(tournamentconnect.BracketFromProto(res.GetBracket()), h.consolation)
+		wholePage := prev == nil || prev.seeded() != b.seeded() || prev.format != b.format
+		prev = &b
+
+		if wholePage {
+			return sse.PatchElementTempl(tournamentPage(b), dstar.WithEventID(id))
 		}
-	}
+		return sse.PatchElementTempl(
+			bracketDisplay(b),
+			datastar.WithViewTransitions(),
+			dstar.WithEventID(id),
+		)
+	})
 }
 
 func (h Handler) AdvanceTeam(w http.ResponseWriter, r *http.Request) error {
@@ -116,216 +274,422 @@ func (h Handler) AdvanceTeam(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	err = h.GameRepo.SetTournamentGameWinner(r.Context(), toRound-1, fromIdx, teamID)
+	err = h.TournamentService.Advance(r.Context(), sideParam(r), toRound-1, fromIdx, teamID)
 	if err != nil {
 		return err
 	}
 
-	rounds, _, err := h.loadRounds(r.Context())
+	b, err := h.loadBracket(r.Context())
 	if err != nil {
 		return err
 	}
 
-	// Only advance team to next round if there is one (skip for final/champion game)
-	if toRound < len(rounds) {
-		newIdx := fromIdx / 2
-		if fromIdx%2 == 0 {
-			err = h.GameRepo.PutTeam1IntoTournamentGame(r.Context(), toRound, newIdx, teamID)
-		} else {
-			err = h.GameRepo.PutTeam2IntoTournamentGame(r.Context(), toRound, newIdx, teamID)
-		}
-		if err != nil {
-			return err
-		}
+	return datastar.NewSSE(w, r).PatchElementTempl(bracketDisplay(b), datastar.WithViewTransitions())
+}
+
+func (h Handler) RevertAdvance(w http.ResponseWriter, r *http.Request) error {
+	teamID := r.PathValue("id")
+
+	fromIdx, err := strconv.Atoi(r.URL.Query().Get("fromIdx"))
+	if err != nil {
+		return err
+	}
+
+	toRound, err := strconv.Atoi(r.URL.Query().Get("toRound"))
+	if err != nil {
+		return err
 	}
 
-	rounds, _, err = h.loadRounds(r.Context())
+	err = h.TournamentService.Revert(r.Context(), sideParam(r), toRound-1, fromIdx, teamID)
 	if err != nil {
 		return err
 	}
 
-	h.TournamentNotifier.Notify()
-	return datastar.NewSSE(w, r).PatchElementTempl(roundDisplay(rounds, 0, championFromRounds(rounds)), datastar.WithViewTransitions())
+	b, err := h.loadBracket(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return datastar.NewSSE(w, r).PatchElementTempl(bracketDisplay(b), datastar.WithViewTransitions())
 }
 
-func (h Handler) RevertAdvance(w http.ResponseWriter, r *http.Request) error {
-	teamID := r.PathValue("id")
+// sideParam returns the bracket side named by the side query parameter, which defaults to the
+// winners side (the whole bracket in single elimination).
+func sideParam(r *http.Request) tournamentservice.Side {
+	side := r.URL.Query().Get("side")
+	if side == "" {
+		return tournamentservice.SideWinners
+	}
+	return tournamentservice.Side(side)
+}
 
-	fromIdx, err := strconv.Atoi(r.URL.Query().Get("fromIdx"))
+// gameParams returns the game named by the side, round, and idx query parameters.
+func gameParams(r *http.Request) (tournamentservice.Side, int, int, error) {
+	round, err := strconv.Atoi(r.URL.Query().Get("round"))
 	if err != nil {
-		return err
+		return "", 0, 0, err
 	}
 
-	toRound, err := strconv.Atoi(r.URL.Query().Get("toRound"))
+	idx, err := strconv.Atoi(r.URL.Query().Get("idx"))
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	return sideParam(r), round, idx, nil
+}
+
+func (h Handler) SetSeriesLength(w http.ResponseWriter, r *http.Request) error {
+	side, round, idx, err := gameParams(r)
 	if err != nil {
 		return err
 	}
 
-	rounds, _, err := h.loadRounds(r.Context())
+	bestOf, err := strconv.Atoi(r.URL.Query().Get("bestOf"))
 	if err != nil {
 		return err
 	}
 
-	// Only allow revert from the team's furthest game: the game they're being removed from must have no winner yet
-	gameIdx := fromIdx / 2
-	if toRound >= len(rounds) {
-		// Final round: clearing champion is always allowed
-	} else {
-		game := rounds[toRound].games[gameIdx]
-		if game.winner != "" {
-			return errors.New("can only revert a team from their furthest game")
-		}
-		// Team must be in this game
-		if game.team1ID != teamID && game.team2ID != teamID {
-			return errors.New("team is not in this game")
-		}
+	err = h.TournamentService.SetSeriesLength(r.Context(), side, round, idx, bestOf)
+	if err != nil {
+		return err
 	}
 
-	err = h.Transactor.WithTx(r.Context(), func(ctx context.Context) error {
-		// Clear winner on the game this team was advanced from
-		err = h.GameRepo.ClearTournamentGameWinner(ctx, toRound-1, fromIdx)
-		if err != nil {
-			return err
-		}
+	return h.patchBracket(w, r)
+}
 
-		// If they were advanced into a next round, clear that slot too
-		if toRound < len(rounds) {
-			err = h.GameRepo.ClearTeamFromTournamentGame(ctx, toRound, gameIdx, teamID)
-			if err != nil {
-				return err
-			}
-		}
+// RecordSeriesGame records the next game of a series as won by the team in the path. The losing
+// score comes from the game's series score signal.
+func (h Handler) RecordSeriesGame(w http.ResponseWriter, r *http.Request) error {
+	side, round, idx, err := gameParams(r)
+	if err != nil {
+		return err
+	}
 
-		return nil
-	})
+	var signals map[string]any
+	err = datastar.ReadSignals(r, &signals)
 	if err != nil {
 		return err
 	}
 
-	rounds, _, err = h.loadRounds(r.Context())
+	name := bracket{consolation: h.consolation}.seriesScoreSignal(row{side: side, round: round, idx: idx})
+	loserScore, ok := signals[name].(float64)
+	if !ok {
+		return tournamentservice.ErrInvalidScore
+	}
+
+	err = h.TournamentService.RecordSeriesGame(r.Context(), side, round, idx, r.PathValue("id"), int(loserScore))
 	if err != nil {
 		return err
 	}
 
-	h.TournamentNotifier.Notify()
-	return datastar.NewSSE(w, r).PatchElementTempl(roundDisplay(rounds, 0, championFromRounds(rounds)), datastar.WithViewTransitions())
+	return h.patchBracket(w, r)
 }
 
-func (h Handler) Generate(w http.ResponseWriter, r *http.Request) error {
-	var signals struct {
-		Size signalInt `json:"size"`
+func (h Handler) UndoSeriesGame(w http.ResponseWriter, r *http.Request) error {
+	side, round, idx, err := gameParams(r)
+	if err != nil {
+		return err
 	}
-	err := datastar.ReadSignals(r, &signals)
+
+	err = h.TournamentService.UndoSeriesGame(r.Context(), side, round, idx)
 	if err != nil {
 		return err
 	}
 
-	standings, err := h.GameRepo.GetStandings(r.Context())
+	return h.patchBracket(w, r)
+}
+
+// ReportPage lets an admin record the score of a bracket game, or the next game of a series, like
+// the prelim game page does. Players report their results from TeamPage instead, so that the other
+// team confirms them.
+func (h Handler) ReportPage(w http.ResponseWriter, r *http.Request) error {
+	side, round, idx, err := gameParams(r)
 	if err != nil {
 		return err
 	}
 
-	if len(standings) < signals.Size.N() {
-		return errors.New("not enough teams to seed the tournament")
+	b, err := h.TournamentService.Get(r.Context())
+	if err != nil {
+		return err
+	}
+
+	g, ok := b.Game(side, round, idx)
+	if !ok {
+		return tournamentservice.ErrGameNotFound
+	}
+
+	return reportPage(bracket{consolation: h.consolation}, toRow(g)).Render(r.Context(), w)
+}
+
+// Report records the score submitted from ReportPage. The winner is the team that reached 121, so
+// only the losing score is entered. A game that already has a result can't be reported again.
+func (h Handler) Report(w http.ResponseWriter, r *http.Request) error {
+	side, round, idx, err := gameParams(r)
+	if err != nil {
+		return err
 	}
 
-	err = h.GameRepo.InitializeTournament(r.Context(), signals.Size.N())
+	var signals struct {
+		WinningTeamID string `json:"winner"`
+		LoserScore    int    `json:"loserScore"`
+	}
+	err = datastar.ReadSignals(r, &signals)
 	if err != nil {
 		return err
 	}
 
-	for i := range signals.Size.N() / 2 {
-		// compute 0,15 1,14 2,13 etc. for the tournament seeds
-		idx1 := i
-		idx2 := signals.Size.N() - (i + 1)
-		err := h.GameRepo.PutTeam1IntoTournamentGame(r.Context(), 0, i, standings[idx1].TeamID)
+	sse := datastar.NewSSE(w, r)
+	if signals.WinningTeamID == "" {
+		return sse.PatchElementTempl(reportWinnerError("Must select a winning team."))
+	}
+
+	err = h.TournamentService.RecordSeriesGame(r.Context(), side, round, idx, signals.WinningTeamID, signals.LoserScore)
+	switch {
+	case errors.Is(err, tournamentservice.ErrInvalidScore):
+		return sse.PatchElementTempl(reportScoreInput("Losing score must be between 1 and 120."))
+	case errors.Is(err, tournamentservice.ErrTeamNotInGame):
+		return sse.PatchElementTempl(reportWinnerError("Must select a winning team."))
+	case errors.Is(err, tournamentservice.ErrGameDecided):
+		return sse.PatchElementTempl(reportScoreInput("This game already has a result."))
+	case err != nil:
+		return err
+	}
+
+	return sse.Redirect(bracket{consolation: h.consolation}.path())
+}
+
+// TeamPage lets a team report whether they won or lost their current bracket game. The main bracket
+// is checked first, then the consolation bracket.
+func (h Handler) TeamPage(w http.ResponseWriter, r *http.Request) error {
+	teamID := r.PathValue("id")
+
+	for _, h := range []Handler{h, h.Consolation()} {
+		b, err := h.TournamentService.Get(r.Context())
 		if err != nil {
 			return err
 		}
-		err = h.GameRepo.PutTeam2IntoTournamentGame(r.Context(), 0, i, standings[idx2].TeamID)
-		if err != nil {
-			return err
+
+		g, ok := b.CurrentGame(teamID)
+		if ok {
+			return teamReportPage(bracket{consolation: h.consolation}, teamID, toRow(g)).Render(r.Context(), w)
 		}
 	}
 
-	rounds, _, err := h.loadRounds(r.Context())
+	return teamReportPage(bracket{}, teamID, row{}).Render(r.Context(), w)
+}
+
+// reporterCookie holds an ID for the browser that reports bracket results, so that the same
+// browser can't report for both teams in a game.
+const reporterCookie = "reporter"
+
+// reporterID returns the ID of the browser making the request, giving it one if it has none yet.
+func reporterID(w http.ResponseWriter, r *http.Request) string {
+	if c, err := r.Cookie(reporterCookie); err == nil && c.Value != "" {
+		return c.Value
+	}
+
+	id := uuid.NewString()
+	http.SetCookie(w, &http.Cookie{
+		Name:     reporterCookie,
+		Value:    id,
+		Path:     "/",
+		Expires:  time.Now().Add(24 * time.Hour),
+		HttpOnly: true,
+		Secure:   middleware.IsProd(r.Context()),
+		SameSite: http.SameSiteLaxMode,
+	})
+	return id
+}
+
+// ReportResult records the report of the team in the path for the game in the query. The won query
+// parameter says whether they won, and the loserScore signal is the losing team's score.
+func (h Handler) ReportResult(w http.ResponseWriter, r *http.Request) error {
+	teamID := r.PathValue("id")
+	reporter := reporterID(w, r)
+
+	side, round, idx, err := gameParams(r)
 	if err != nil {
 		return err
 	}
 
-	return datastar.NewSSE(w, r).PatchElementTempl(tournamentPage(rounds, 0, championFromRounds(rounds)))
+	won, err := strconv.ParseBool(r.URL.Query().Get("won"))
+	if err != nil {
+		return err
+	}
+
+	var signals struct {
+		LoserScore int `json:"loserScore"`
+	}
+	err = datastar.ReadSignals(r, &signals)
+	if err != nil {
+		return err
+	}
+
+	// If the game was decided in the meantime, showing the result is all there is to do.
+	err = h.TournamentService.ReportResult(r.Context(), side, round, idx, teamID, reporter, won, signals.LoserScore)
+	if errors.Is(err, tournamentservice.ErrInvalidScore) {
+		return datastar.NewSSE(w, r).PatchElementTempl(reportScoreInput("Losing score must be between 1 and 120."))
+	}
+	if errors.Is(err, tournamentservice.ErrSameReporter) {
+		return datastar.NewSSE(w, r).PatchElementTempl(
+			teamReportError("This device already reported for the other team. They need to confirm the result on their own device."),
+		)
+	}
+	if err != nil && !errors.Is(err, tournamentservice.ErrGameDecided) {
+		return err
+	}
+
+	b, err := h.TournamentService.Get(r.Context())
+	if err != nil {
+		return err
+	}
+
+	g, ok := b.Game(side, round, idx)
+	if !ok {
+		return tournamentservice.ErrGameNotFound
+	}
+
+	return datastar.NewSSE(w, r).PatchElementTempl(teamReport(bracket{consolation: h.consolation}, teamID, toRow(g)))
 }
 
-func (h Handler) Delete(w http.ResponseWriter, r *http.Request) error {
-	err := h.GameRepo.DeleteTournament(r.Context())
+func (h Handler) patchBracket(w http.ResponseWriter, r *http.Request) error {
+	b, err := h.loadBracket(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return datastar.NewSSE(w, r).PatchElementTempl(bracketDisplay(b), datastar.WithViewTransitions())
+}
+
+func (h Handler) Generate(w http.ResponseWriter, r *http.Request) error {
+	var signals struct {
+		Size       signalInt `json:"size"`
+		Double     bool      `json:"double"`
+		ThirdPlace bool      `json:"third_place"`
+	}
+	err := datastar.ReadSignals(r, &signals)
+	if err != nil {
+		return err
+	}
+
+	if signals.Double {
+		err = h.TournamentService.SeedDoubleElimination(r.Context(), signals.Size.N())
+	} else {
+		err = h.TournamentService.Seed(r.Context(), signals.Size.N(), signals.ThirdPlace)
+	}
 	if err != nil {
 		return err
 	}
 
-	allTeams, err := h.TeamRepo.GetAll(r.Context())
+	b, err := h.loadBracket(r.Context())
 	if err != nil {
 		return err
 	}
-	teamCount := len(allTeams)
 
-	return datastar.NewSSE(w, r).PatchElementTempl(tournamentPage(nil, teamCount, champion{}))
+	return datastar.NewSSE(w, r).PatchElementTempl(tournamentPage(b))
 }
 
-func (h Handler) loadRounds(ctx context.Context) ([]round, int, error) {
-	tourney, err := h.GameRepo.LoadTournament(ctx)
+func (h Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	err := h.TournamentService.Delete(r.Context())
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
 
-	ts, err := h.TeamRepo.GetAll(ctx)
+	b, err := h.loadBracket(r.Context())
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
-	teamNamesByID := make(map[string]string, len(ts))
-	for _, t := range ts {
-		teamNamesByID[t.ID] = t.Name
+
+	return datastar.NewSSE(w, r).PatchElementTempl(tournamentPage(b))
+}
+
+func (h Handler) loadBracket(ctx context.Context) (bracket, error) {
+	b, err := h.TournamentService.Get(ctx)
+	if err != nil {
+		return bracket{}, err
+	}
+	return newBracket(b, h.consolation), nil
+}
+
+func newBracket(b tournamentservice.Bracket, consolation bool) bracket {
+	fromBye := func(round, idx int) bool {
+		return round > 0 && b.Rounds[round-1].Games[idx].Bye
 	}
 
-	var rounds []round
-	for i, rnd := range tourney.Rounds {
-		var games []row
-		for j, game := range rnd.Games {
-			games = append(games, row{
-				round:     i,
-				idx:       j,
-				team1ID:   game.TeamIDs[0],
-				team1Name: teamNamesByID[game.TeamIDs[0]],
-				team2ID:   game.TeamIDs[1],
-				team2Name: teamNamesByID[game.TeamIDs[1]],
-				winner:    teamNamesByID[game.Winner],
-				winnerID:  game.Winner,
+	rows := func(rs []tournamentservice.Round) []round {
+		var rounds []round
+		for i, rnd := range rs {
+			var games []row
+			for j, g := range rnd.Games {
+				r := toRow(g)
+				if g.Side == tournamentservice.SideWinners {
+					r.team1FromBye = fromBye(i, 2*j)
+					r.team2FromBye = fromBye(i, 2*j+1)
+				}
+				games = append(games, r)
+			}
+
+			rounds = append(rounds, round{
+				games: games,
 			})
 		}
+		return rounds
+	}
 
-		rounds = append(rounds, round{
-			games: games,
-		})
+	var thirdPlace *row
+	if b.ThirdPlace != nil {
+		r := toRow(*b.ThirdPlace)
+		thirdPlace = &r
 	}
 
-	return rounds, len(ts), nil
-}
+	var pending []row
+	for _, g := range b.Pending() {
+		pending = append(pending, toRow(g))
+	}
 
-type champion struct {
-	Name string
-	ID   string
+	champ := b.Champion()
+	return bracket{
+		format:      b.Format,
+		rounds:      rows(b.Rounds),
+		losers:      rows(b.Losers),
+		final:       rows(b.Final),
+		thirdPlace:  thirdPlace,
+		teamCount:   b.TeamCount,
+		champ:       champion{Name: champ.Name, ID: champ.ID},
+		pending:     pending,
+		consolation: consolation,
+	}
 }
 
-func championFromRounds(rounds []round) champion {
-	if len(rounds) == 0 {
-		return champion{}
+func toRow(g tournamentservice.Game) row {
+	var series [][2]int
+	for _, sg := range g.Series {
+		series = append(series, sg.Scores)
 	}
-	last := rounds[len(rounds)-1]
-	if len(last.games) != 1 {
-		return champion{}
+	r := row{
+		side:        g.Side,
+		round:       g.Round,
+		idx:         g.Idx,
+		team1ID:     g.Teams[0].ID,
+		team1Name:   g.Teams[0].Name,
+		team2ID:     g.Teams[1].ID,
+		team2Name:   g.Teams[1].Name,
+		winner:      g.Winner.Name,
+		winnerID:    g.Winner.ID,
+		bye:         g.Bye,
+		bestOf:      g.BestOf,
+		series:      series,
+		wins:        g.Wins(),
+		reports:     g.Reports,
+		conflicting: g.Conflicting(),
 	}
-	g := last.games[0]
-	if g.winner == "" {
-		return champion{}
+	if w, ok := g.ReportedWinner(); ok {
+		r.reportedWinner = w.Name
 	}
-	return champion{Name: g.winner, ID: g.winnerID}
+	return r
+}
+
+type champion struct {
+	Name string
+	ID   string
 }